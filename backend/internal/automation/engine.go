@@ -0,0 +1,349 @@
+// Package automation runs board rules in response to card changes.
+package automation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/events"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+	"go.uber.org/zap"
+)
+
+// MaxDepth bounds how many rules a single change may cascade through.
+const MaxDepth = 5
+
+var errLoop = errors.New("rule already ran in this chain")
+
+// Event is a domain change that may fire rules on its board.
+type Event struct {
+	Trigger string
+	BoardID string
+	CardID  string
+	ListID  string
+	LabelID string
+}
+
+type Engine struct {
+	store  store.Storer
+	events events.Hub
+	logger *zap.Logger
+}
+
+func NewEngine(store store.Storer, hub events.Hub, logger *zap.Logger) *Engine {
+	return &Engine{
+		store:  store,
+		events: hub,
+		logger: logger,
+	}
+}
+
+type chainKey struct{}
+
+// chain returns the IDs of the rules that led to the current change.
+// Actions performed by a rule can fire further rules; carrying the chain in
+// the context stops a rule from re-triggering itself and caps the depth.
+func chain(ctx context.Context) []string {
+	ids, _ := ctx.Value(chainKey{}).([]string)
+	return ids
+}
+
+func withRule(ctx context.Context, automationID string) context.Context {
+	return context.WithValue(ctx, chainKey{}, append(slices.Clone(chain(ctx)), automationID))
+}
+
+// Handle runs every enabled rule on the event's board whose trigger matches.
+func (e *Engine) Handle(ctx context.Context, event *Event) {
+	if len(chain(ctx)) >= MaxDepth {
+		e.logger.Warn("automation chain too deep, dropping event",
+			zap.String("trigger", event.Trigger),
+			zap.String("cardID", event.CardID),
+			zap.Strings("chain", chain(ctx)),
+		)
+		return
+	}
+
+	automations, err := e.store.ListEnabledAutomations(ctx, event.BoardID, event.Trigger)
+	if err != nil {
+		e.logger.Error("failed to list automations", zap.String("boardID", event.BoardID), zap.Error(err))
+		return
+	}
+
+	for _, automation := range automations {
+		if !matches(&automation.Trigger, event) {
+			continue
+		}
+		e.run(ctx, automation, event)
+	}
+}
+
+// RunDueDateTriggers fires due_date_passed rules for overdue cards, checking
+// every interval until ctx is cancelled.
+func (e *Engine) RunDueDateTriggers(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		e.checkDueDates(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Engine) checkDueDates(ctx context.Context) {
+	automations, err := e.store.ListEnabledAutomations(ctx, "", types.TriggerDueDatePassed)
+	if err != nil {
+		e.logger.Error("failed to list due date automations", zap.Error(err))
+		return
+	}
+
+	for _, automation := range automations {
+		cardIDs, err := e.store.ListOverdueCardIDs(ctx, automation.BoardID, automation.ID)
+		if err != nil {
+			e.logger.Error("failed to list overdue cards", zap.String("automationID", automation.ID), zap.Error(err))
+			continue
+		}
+
+		for _, cardID := range cardIDs {
+			e.run(ctx, automation, &Event{
+				Trigger: types.TriggerDueDatePassed,
+				BoardID: automation.BoardID,
+				CardID:  cardID,
+			})
+		}
+	}
+}
+
+func matches(trigger *types.AutomationTrigger, event *Event) bool {
+	if trigger.Type != event.Trigger {
+		return false
+	}
+
+	switch trigger.Type {
+	case types.TriggerCardMoved:
+		return trigger.ListID == "" || trigger.ListID == event.ListID
+	case types.TriggerLabelAdded:
+		return trigger.LabelID == "" || trigger.LabelID == event.LabelID
+	default:
+		return true
+	}
+}
+
+// run executes the rule's actions against the event's card as the rule's
+// creator, records the outcome and then hands any changes the actions made
+// back to Handle.
+func (e *Engine) run(ctx context.Context, automation *types.Automation, event *Event) {
+	if slices.Contains(chain(ctx), automation.ID) {
+		e.record(ctx, automation, event, errLoop)
+		return
+	}
+
+	ctx = withRule(ctx, automation.ID)
+	ctx = context.WithValue(ctx, types.UserCtxKey, &types.User{ID: automation.CreatedBy})
+
+	followUps, err := e.execute(ctx, automation, event)
+	e.record(ctx, automation, event, err)
+	if err != nil {
+		return
+	}
+
+	e.publish(ctx, automation, event)
+
+	for _, followUp := range followUps {
+		e.Handle(ctx, followUp)
+	}
+}
+
+func (e *Engine) execute(ctx context.Context, automation *types.Automation, event *Event) ([]*Event, error) {
+	card, err := e.store.GetCardDetail(ctx, event.CardID)
+	if err != nil {
+		return nil, fmt.Errorf("load card: %w", err)
+	}
+
+	var followUps []*Event
+	for i, action := range automation.Actions {
+		followUp, err := e.apply(ctx, event.BoardID, card, &action)
+		if err != nil {
+			return nil, fmt.Errorf("action %d (%s): %w", i+1, action.Type, err)
+		}
+		if followUp != nil {
+			followUps = append(followUps, followUp)
+		}
+	}
+
+	return followUps, nil
+}
+
+// apply performs a single action, keeping card in sync with what was
+// written. Actions that would not change the card are skipped; the returned
+// event is nil unless the action itself can fire other rules.
+func (e *Engine) apply(ctx context.Context, boardID string, card *types.CompleteCard, action *types.AutomationAction) (*Event, error) {
+	switch action.Type {
+	case types.ActionMoveCard:
+		if card.ListID == action.ListID {
+			return nil, nil
+		}
+		if err := e.store.UpdateCard(ctx, card.ID, &types.UpdateCard{
+			CardID: card.ID,
+			ListID: action.ListID,
+		}); err != nil {
+			return nil, err
+		}
+		card.ListID = action.ListID
+		return &Event{
+			Trigger: types.TriggerCardMoved,
+			BoardID: boardID,
+			CardID:  card.ID,
+			ListID:  action.ListID,
+		}, nil
+
+	case types.ActionAddLabel:
+		if hasLabel(card, action.LabelID) {
+			return nil, nil
+		}
+		if err := e.store.AddLabelToCard(ctx, &types.ToggleLabelToCard{
+			Type:    "add",
+			LabelID: action.LabelID,
+			CardID:  card.ID,
+			BoardID: boardID,
+		}); err != nil {
+			return nil, err
+		}
+		card.Labels = append(card.Labels, &types.BoardLabel{LabelID: action.LabelID})
+		return &Event{
+			Trigger: types.TriggerLabelAdded,
+			BoardID: boardID,
+			CardID:  card.ID,
+			LabelID: action.LabelID,
+		}, nil
+
+	case types.ActionRemoveLabel:
+		if !hasLabel(card, action.LabelID) {
+			return nil, nil
+		}
+		if err := e.store.RemoveLabelFromCard(ctx, &types.ToggleLabelToCard{
+			Type:    "remove",
+			LabelID: action.LabelID,
+			CardID:  card.ID,
+			BoardID: boardID,
+		}); err != nil {
+			return nil, err
+		}
+		card.Labels = slices.DeleteFunc(card.Labels, func(l *types.BoardLabel) bool {
+			return l.LabelID == action.LabelID
+		})
+		return nil, nil
+
+	case types.ActionAssignMember:
+		if slices.Contains(card.MemberIDs, action.UserID) {
+			return nil, nil
+		}
+		if err := e.store.ToggleCardMembership(ctx, &types.ToggleCardMembership{
+			CardID: card.ID,
+			UserID: action.UserID,
+		}); err != nil {
+			return nil, err
+		}
+		card.MemberIDs = append(card.MemberIDs, action.UserID)
+		return nil, nil
+
+	case types.ActionMarkCompleted:
+		if card.Completed {
+			return nil, nil
+		}
+		completed := true
+		if err := e.store.UpdateCard(ctx, card.ID, &types.UpdateCard{
+			CardID:    card.ID,
+			ListID:    card.ListID,
+			Completed: &completed,
+		}); err != nil {
+			return nil, err
+		}
+		card.Completed = true
+		return nil, nil
+
+	case types.ActionSetDueDate:
+		if action.DueInDays == nil {
+			return nil, errors.New("dueInDays is required")
+		}
+		due := time.Now().Add(time.Duration(*action.DueInDays) * 24 * time.Hour)
+		if err := e.store.UpdateCard(ctx, card.ID, &types.UpdateCard{
+			CardID:  card.ID,
+			ListID:  card.ListID,
+			DueDate: &due,
+		}); err != nil {
+			return nil, err
+		}
+		card.Due = due
+		return nil, nil
+
+	case types.ActionPostComment:
+		user, _ := ctx.Value(types.UserCtxKey).(*types.User)
+		if _, err := e.store.CreateComment(ctx, &types.CreateComment{
+			CardID:  card.ID,
+			UserID:  user.ID,
+			Content: action.Comment,
+		}); err != nil {
+			return nil, err
+		}
+		return nil, nil
+
+	default:
+		return nil, fmt.Errorf("unknown action %q", action.Type)
+	}
+}
+
+func hasLabel(card *types.CompleteCard, labelID string) bool {
+	return slices.ContainsFunc(card.Labels, func(l *types.BoardLabel) bool {
+		return l.LabelID == labelID
+	})
+}
+
+func (e *Engine) record(ctx context.Context, automation *types.Automation, event *Event, err error) {
+	run := &types.CreateAutomationRun{
+		AutomationID: automation.ID,
+		CardID:       event.CardID,
+		Trigger:      event.Trigger,
+		Status:       types.AutomationRunSucceeded,
+	}
+
+	switch {
+	case errors.Is(err, errLoop):
+		run.Status = types.AutomationRunSkipped
+		run.Error = err.Error()
+	case err != nil:
+		run.Status = types.AutomationRunFailed
+		run.Error = err.Error()
+		e.logger.Warn("automation failed",
+			zap.String("automationID", automation.ID),
+			zap.String("cardID", event.CardID),
+			zap.Error(err),
+		)
+	}
+
+	if err := e.store.CreateAutomationRun(ctx, run); err != nil {
+		e.logger.Error("failed to record automation run", zap.String("automationID", automation.ID), zap.Error(err))
+	}
+}
+
+func (e *Engine) publish(ctx context.Context, automation *types.Automation, event *Event) {
+	ev, err := events.NewEvent(events.AutomationExecuted, event.BoardID, automation.CreatedBy, map[string]any{
+		"automationID": automation.ID,
+		"cardID":       event.CardID,
+	})
+	if err != nil {
+		return
+	}
+
+	if err := e.events.Publish(ctx, ev); err != nil {
+		e.logger.Error("failed to publish automation event", zap.String("automationID", automation.ID), zap.Error(err))
+	}
+}
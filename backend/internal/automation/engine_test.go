@@ -0,0 +1,118 @@
+package automation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/events"
+	m "github.com/vaidik-bajpai/Nexus/backend/internal/store/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+	"go.uber.org/zap"
+)
+
+const (
+	boardID = "board"
+	cardID  = "card"
+	todo    = "todo"
+	doing   = "doing"
+)
+
+func moveRule(id, from, to string) *types.Automation {
+	return &types.Automation{
+		ID:        id,
+		BoardID:   boardID,
+		CreatedBy: "user",
+		Enabled:   true,
+		Trigger:   types.AutomationTrigger{Type: types.TriggerCardMoved, ListID: from},
+		Actions:   []types.AutomationAction{{Type: types.ActionMoveCard, ListID: to}},
+	}
+}
+
+func TestEngineStopsRuleLoops(t *testing.T) {
+	ms := new(m.MockStore)
+	engine := NewEngine(ms, events.NewMemoryHub(), zap.NewNop())
+
+	// each rule moves the card into the list that fires the other one
+	forward := moveRule("forward", todo, doing)
+	back := moveRule("back", doing, todo)
+	card := &types.CompleteCard{ID: cardID, ListID: todo}
+
+	ms.On("ListEnabledAutomations", mock.Anything, boardID, types.TriggerCardMoved).
+		Return([]*types.Automation{forward, back}, nil)
+	ms.On("GetCardDetail", mock.Anything, cardID).Return(card, nil)
+	ms.On("UpdateCard", mock.Anything, cardID, mock.AnythingOfType("*types.UpdateCard")).Return(nil)
+
+	var runs []*types.CreateAutomationRun
+	ms.On("CreateAutomationRun", mock.Anything, mock.AnythingOfType("*types.CreateAutomationRun")).
+		Run(func(args mock.Arguments) {
+			runs = append(runs, args.Get(1).(*types.CreateAutomationRun))
+		}).
+		Return(nil)
+
+	engine.Handle(context.Background(), &Event{
+		Trigger: types.TriggerCardMoved,
+		BoardID: boardID,
+		CardID:  cardID,
+		ListID:  todo,
+	})
+
+	statuses := make([]string, 0, len(runs))
+	for _, run := range runs {
+		statuses = append(statuses, run.AutomationID+":"+run.Status)
+	}
+	assert.Equal(t, []string{
+		"forward:" + types.AutomationRunSucceeded,
+		"back:" + types.AutomationRunSucceeded,
+		"forward:" + types.AutomationRunSkipped,
+	}, statuses)
+	ms.AssertNumberOfCalls(t, "UpdateCard", 2)
+}
+
+func TestEngineRunsActionsAsRuleCreator(t *testing.T) {
+	ms := new(m.MockStore)
+	engine := NewEngine(ms, events.NewMemoryHub(), zap.NewNop())
+
+	rule := &types.Automation{
+		ID:        "label-done",
+		BoardID:   boardID,
+		CreatedBy: "creator",
+		Enabled:   true,
+		Trigger:   types.AutomationTrigger{Type: types.TriggerLabelAdded, LabelID: "done"},
+		Actions: []types.AutomationAction{
+			{Type: types.ActionMarkCompleted},
+			{Type: types.ActionPostComment, Comment: "closed automatically"},
+		},
+	}
+
+	ms.On("ListEnabledAutomations", mock.Anything, boardID, types.TriggerLabelAdded).
+		Return([]*types.Automation{rule}, nil)
+	ms.On("GetCardDetail", mock.Anything, cardID).Return(&types.CompleteCard{ID: cardID, ListID: todo}, nil)
+	ms.On("UpdateCard", mock.Anything, cardID, mock.MatchedBy(func(card *types.UpdateCard) bool {
+		return card.Completed != nil && *card.Completed && card.ListID == todo
+	})).Return(nil)
+	ms.On("CreateComment", mock.Anything, mock.MatchedBy(func(comment *types.CreateComment) bool {
+		return comment.UserID == "creator" && comment.Content == "closed automatically"
+	})).Return(&types.Comment{}, nil)
+	ms.On("CreateAutomationRun", mock.Anything, mock.MatchedBy(func(run *types.CreateAutomationRun) bool {
+		return run.Status == types.AutomationRunSucceeded
+	})).Return(nil)
+
+	// another label does not fire the rule
+	engine.Handle(context.Background(), &Event{
+		Trigger: types.TriggerLabelAdded,
+		BoardID: boardID,
+		CardID:  cardID,
+		LabelID: "urgent",
+	})
+	ms.AssertNotCalled(t, "GetCardDetail", mock.Anything, cardID)
+
+	engine.Handle(context.Background(), &Event{
+		Trigger: types.TriggerLabelAdded,
+		BoardID: boardID,
+		CardID:  cardID,
+		LabelID: "done",
+	})
+	ms.AssertExpectations(t)
+}
@@ -0,0 +1,111 @@
+// Package events fans out board changes to connected clients.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	BoardUpdated = "board.updated"
+
+	MemberInvited     = "member.invited"
+	MemberJoined      = "member.joined"
+	MemberRoleChanged = "member.role_changed"
+	MemberRemoved     = "member.removed"
+	MemberLeft        = "member.left"
+
+	ListCreated = "list.created"
+	ListUpdated = "list.updated"
+	ListDeleted = "list.deleted"
+
+	CardCreated       = "card.created"
+	CardUpdated       = "card.updated"
+	CardDeleted       = "card.deleted"
+	CardMemberToggled = "card.member_toggled"
+	CardLabelToggled  = "card.label_toggled"
+
+	CardDependencyAdded   = "card.dependency_added"
+	CardDependencyRemoved = "card.dependency_removed"
+
+	CardVoteToggled = "card.vote_toggled"
+
+	LabelCreated = "label.created"
+	LabelUpdated = "label.updated"
+	LabelDeleted = "label.deleted"
+
+	ChecklistCreated     = "checklist.created"
+	ChecklistDeleted     = "checklist.deleted"
+	ChecklistItemCreated = "checklist_item.created"
+	ChecklistItemUpdated = "checklist_item.updated"
+	ChecklistItemDeleted = "checklist_item.deleted"
+
+	CommentCreated = "comment.created"
+	CommentUpdated = "comment.updated"
+	CommentDeleted = "comment.deleted"
+
+	AttachmentCreated = "attachment.created"
+	AttachmentDeleted = "attachment.deleted"
+
+	AutomationExecuted = "automation.executed"
+)
+
+// subscriberBuffer is how many events a slow subscriber may lag behind
+// before further events are dropped for it.
+const subscriberBuffer = 64
+
+type Event struct {
+	Type      string          `json:"type"`
+	BoardID   string          `json:"boardID"`
+	ActorID   string          `json:"actorID,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+// NewEvent builds an event with data marshalled to JSON.
+func NewEvent(eventType, boardID, actorID string, data any) (*Event, error) {
+	event := &Event{
+		Type:      eventType,
+		BoardID:   boardID,
+		ActorID:   actorID,
+		CreatedAt: time.Now(),
+	}
+
+	if data != nil {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		event.Data = raw
+	}
+
+	return event, nil
+}
+
+// Hub delivers events published for a board to every subscriber of that
+// board. The returned channel is closed once ctx is done.
+type Hub interface {
+	Publish(ctx context.Context, event *Event) error
+	Subscribe(ctx context.Context, boardID string) (<-chan *Event, error)
+	Close() error
+}
+
+type Config struct {
+	Driver        string
+	RedisAddr     string
+	RedisPassword string
+	RedisDB       int
+}
+
+func New(cfg Config) (Hub, error) {
+	switch cfg.Driver {
+	case "", "memory":
+		return NewMemoryHub(), nil
+	case "redis":
+		return NewRedisHub(cfg)
+	default:
+		return nil, fmt.Errorf("events: unknown hub driver %q", cfg.Driver)
+	}
+}
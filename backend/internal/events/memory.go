@@ -0,0 +1,92 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryHub delivers events to subscribers within the same process.
+type MemoryHub struct {
+	mu     sync.RWMutex
+	boards map[string]map[chan *Event]struct{}
+	closed bool
+}
+
+func NewMemoryHub() *MemoryHub {
+	return &MemoryHub{
+		boards: make(map[string]map[chan *Event]struct{}),
+	}
+}
+
+func (h *MemoryHub) Publish(ctx context.Context, event *Event) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.boards[event.BoardID] {
+		select {
+		case ch <- event:
+		default:
+			// the subscriber is not keeping up; it will catch up on its
+			// next full fetch rather than block every publisher.
+		}
+	}
+	return nil
+}
+
+func (h *MemoryHub) Subscribe(ctx context.Context, boardID string) (<-chan *Event, error) {
+	ch := make(chan *Event, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		close(ch)
+		return ch, nil
+	}
+	subs, ok := h.boards[boardID]
+	if !ok {
+		subs = make(map[chan *Event]struct{})
+		h.boards[boardID] = subs
+	}
+	subs[ch] = struct{}{}
+	h.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		h.unsubscribe(boardID, ch)
+	}()
+
+	return ch, nil
+}
+
+func (h *MemoryHub) unsubscribe(boardID string, ch chan *Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subs, ok := h.boards[boardID]
+	if !ok {
+		return
+	}
+	if _, ok := subs[ch]; !ok {
+		return
+	}
+
+	delete(subs, ch)
+	close(ch)
+	if len(subs) == 0 {
+		delete(h.boards, boardID)
+	}
+}
+
+func (h *MemoryHub) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for boardID, subs := range h.boards {
+		for ch := range subs {
+			close(ch)
+		}
+		delete(h.boards, boardID)
+	}
+	h.closed = true
+	return nil
+}
@@ -0,0 +1,63 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryHub(t *testing.T) {
+	hub := NewMemoryHub()
+	defer hub.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := hub.Subscribe(ctx, "board-1")
+	require.NoError(t, err)
+
+	other, err := hub.Subscribe(context.Background(), "board-2")
+	require.NoError(t, err)
+
+	event, err := NewEvent(CardCreated, "board-1", "user-1", map[string]string{"cardID": "card-1"})
+	require.NoError(t, err)
+	require.NoError(t, hub.Publish(context.Background(), event))
+
+	select {
+	case got := <-events:
+		assert.Equal(t, CardCreated, got.Type)
+		assert.JSONEq(t, `{"cardID":"card-1"}`, string(got.Data))
+	case <-time.After(time.Second):
+		t.Fatal("event was not delivered")
+	}
+
+	select {
+	case got := <-other:
+		t.Fatalf("event leaked to another board: %v", got)
+	default:
+	}
+
+	cancel()
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok, "channel should be closed after unsubscribing")
+	case <-time.After(time.Second):
+		t.Fatal("channel was not closed")
+	}
+}
+
+func TestMemoryHubDropsForSlowSubscribers(t *testing.T) {
+	hub := NewMemoryHub()
+	defer hub.Close()
+
+	events, err := hub.Subscribe(context.Background(), "board-1")
+	require.NoError(t, err)
+
+	for i := 0; i < subscriberBuffer+10; i++ {
+		event, _ := NewEvent(CardUpdated, "board-1", "", nil)
+		require.NoError(t, hub.Publish(context.Background(), event))
+	}
+
+	assert.Len(t, events, subscriberBuffer)
+}
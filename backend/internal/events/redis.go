@@ -0,0 +1,94 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const channelPrefix = "nexus:board:"
+
+// RedisHub publishes events through Redis pub/sub so every backend instance
+// sees them. A single pattern subscription per instance feeds a MemoryHub
+// that does the local fan-out. The subscription reconnects on its own when
+// the connection drops.
+type RedisHub struct {
+	client *redis.Client
+	pubsub *redis.PubSub
+	local  *MemoryHub
+	done   chan struct{}
+}
+
+func NewRedisHub(cfg Config) (*RedisHub, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.RedisAddr,
+		Password: cfg.RedisPassword,
+		DB:       cfg.RedisDB,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	pubsub := client.PSubscribe(ctx, channelPrefix+"*")
+	// wait for the subscription to be confirmed so events published right
+	// after startup are not missed
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		client.Close()
+		return nil, err
+	}
+
+	h := &RedisHub{
+		client: client,
+		pubsub: pubsub,
+		local:  NewMemoryHub(),
+		done:   make(chan struct{}),
+	}
+	go h.run()
+
+	return h, nil
+}
+
+func (h *RedisHub) Publish(ctx context.Context, event *Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return h.client.Publish(ctx, channelPrefix+event.BoardID, payload).Err()
+}
+
+func (h *RedisHub) Subscribe(ctx context.Context, boardID string) (<-chan *Event, error) {
+	return h.local.Subscribe(ctx, boardID)
+}
+
+func (h *RedisHub) Close() error {
+	err := h.pubsub.Close()
+	<-h.done
+	h.local.Close()
+	if cerr := h.client.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// run hands every message of the subscription to the local hub until the
+// subscription is closed.
+func (h *RedisHub) run() {
+	defer close(h.done)
+
+	for msg := range h.pubsub.Channel() {
+		var event Event
+		if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+			continue
+		}
+		if event.BoardID == "" {
+			event.BoardID = strings.TrimPrefix(msg.Channel, channelPrefix)
+		}
+
+		h.local.Publish(context.Background(), &event)
+	}
+}
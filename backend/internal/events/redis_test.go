@@ -0,0 +1,133 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRedisHub(t *testing.T, addr string) *RedisHub {
+	t.Helper()
+
+	hub, err := NewRedisHub(Config{Driver: "redis", RedisAddr: addr})
+	require.NoError(t, err)
+	return hub
+}
+
+func receiveEvent(t *testing.T, ch <-chan *Event) *Event {
+	t.Helper()
+
+	select {
+	case event := <-ch:
+		return event
+	case <-time.After(2 * time.Second):
+		t.Fatal("event was not delivered")
+		return nil
+	}
+}
+
+func TestRedisHub(t *testing.T) {
+	srv := miniredis.RunT(t)
+
+	// two hubs stand in for two backend instances
+	publisher := newTestRedisHub(t, srv.Addr())
+	defer publisher.Close()
+	subscriber := newTestRedisHub(t, srv.Addr())
+	defer subscriber.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := subscriber.Subscribe(ctx, "board-1")
+	require.NoError(t, err)
+	other, err := subscriber.Subscribe(ctx, "board-2")
+	require.NoError(t, err)
+
+	event, err := NewEvent(CardCreated, "board-1", "user-1", map[string]string{"cardID": "card-1"})
+	require.NoError(t, err)
+	require.NoError(t, publisher.Publish(ctx, event))
+
+	got := receiveEvent(t, ch)
+	assert.Equal(t, CardCreated, got.Type)
+	assert.Equal(t, "user-1", got.ActorID)
+	assert.JSONEq(t, `{"cardID":"card-1"}`, string(got.Data))
+
+	select {
+	case got := <-other:
+		t.Fatalf("event leaked to another board: %v", got)
+	default:
+	}
+
+	t.Run("board taken from the channel", func(t *testing.T) {
+		srv.Publish(channelPrefix+"board-1", `{"type":"board.updated"}`)
+
+		got := receiveEvent(t, ch)
+		assert.Equal(t, BoardUpdated, got.Type)
+		assert.Equal(t, "board-1", got.BoardID)
+	})
+
+	t.Run("malformed payloads are skipped", func(t *testing.T) {
+		srv.Publish(channelPrefix+"board-1", "not json")
+		require.NoError(t, publisher.Publish(ctx, event))
+
+		assert.Equal(t, CardCreated, receiveEvent(t, ch).Type)
+	})
+}
+
+func TestRedisHubReconnects(t *testing.T) {
+	srv := miniredis.RunT(t)
+	hub := newTestRedisHub(t, srv.Addr())
+	defer hub.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := hub.Subscribe(ctx, "board-1")
+	require.NoError(t, err)
+
+	srv.Restart()
+
+	event, err := NewEvent(CardUpdated, "board-1", "user-1", nil)
+	require.NoError(t, err)
+
+	// the subscription comes back on its own; publish until it has
+	require.Eventually(t, func() bool {
+		if err := hub.Publish(ctx, event); err != nil {
+			return false
+		}
+		select {
+		case got := <-ch:
+			return got.Type == CardUpdated
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}, 10*time.Second, 50*time.Millisecond)
+}
+
+func TestRedisHubClose(t *testing.T) {
+	srv := miniredis.RunT(t)
+	hub := newTestRedisHub(t, srv.Addr())
+
+	ch, err := hub.Subscribe(context.Background(), "board-1")
+	require.NoError(t, err)
+
+	require.NoError(t, hub.Close())
+
+	select {
+	case _, ok := <-ch:
+		assert.False(t, ok, "subscriber channels are closed")
+	case <-time.After(time.Second):
+		t.Fatal("subscriber channel was not closed")
+	}
+}
+
+func TestNewRedisHubUnreachable(t *testing.T) {
+	srv := miniredis.RunT(t)
+	addr := srv.Addr()
+	srv.Close()
+
+	_, err := NewRedisHub(Config{Driver: "redis", RedisAddr: addr})
+	assert.Error(t, err)
+}
@@ -0,0 +1,307 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+	"go.uber.org/zap"
+)
+
+const (
+	// reauthWindow is how long a login or re-authentication allows
+	// sensitive account changes in the same session.
+	reauthWindow = 10 * time.Minute
+
+	// accountLinkTTL bounds both the provider round trip of a link or
+	// re-authentication and how long a pending link can be confirmed.
+	accountLinkTTL = 10 * time.Minute
+)
+
+// requireRecentAuth writes a 403 and returns false unless the user proved
+// their identity within reauthWindow in the current session.
+func (h *handler) requireRecentAuth(w http.ResponseWriter, r *http.Request, user *types.User) bool {
+	session, err := h.store.GetSession(r.Context(), user.ID, user.SessionID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.SendErrorResponse(h.logger, w, http.StatusUnauthorized, "session is no longer active", nil, nil)
+			return false
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return false
+	}
+
+	if time.Since(session.AuthenticatedAt) > reauthWindow {
+		helper.Forbidden(h.logger, w, "re-authentication required", map[string]any{"reauthenticate": true})
+		return false
+	}
+	return true
+}
+
+func (h *handler) handleListLoginMethods(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+
+	methods, err := h.store.ListLoginMethods(r.Context(), user.ID)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "login methods fetched successfully", methods)
+}
+
+// handleReauthenticate checks the user's password and refreshes the
+// session's authentication time.
+func (h *handler) handleReauthenticate(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+
+	var payload types.Reauthenticate
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.BadRequest(h.logger, w, "failed to read request body", nil)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.UnprocessableEntity(h.logger, w, "failed to validate request body", nil)
+		return
+	}
+
+	usr, err := h.store.GetUserByEmail(r.Context(), user.Email)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	if usr.Password == "" {
+		helper.BadRequest(h.logger, w, "account has no password, re-authenticate with a linked provider", nil)
+		return
+	}
+
+	if h.accountLocked(w, r, usr.Email) {
+		return
+	}
+
+	if err := helper.ComparePassword(usr.Password, payload.Password); err != nil {
+		if h.recordFailedLogin(w, r, usr.Email) {
+			return
+		}
+		helper.SendErrorResponse(h.logger, w, http.StatusUnauthorized, "invalid user credentials", nil, nil)
+		return
+	}
+
+	if err := h.store.MarkSessionAuthenticated(r.Context(), user.ID, user.SessionID); err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "re-authenticated successfully", nil)
+}
+
+// handleReauthenticateWithProvider starts a provider flow that
+// re-authenticates the session when it returns with an identity already
+// linked to the user.
+func (h *handler) handleReauthenticateWithProvider(w http.ResponseWriter, r *http.Request) {
+	h.startAccountIntent(w, r, types.AccountReauth)
+}
+
+// handleLinkAccount starts a provider flow that links the returned identity
+// to the signed-in user.
+func (h *handler) handleLinkAccount(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+	if !h.requireRecentAuth(w, r, user) {
+		return
+	}
+
+	h.startAccountIntent(w, r, types.AccountLinkIntent)
+}
+
+// startAccountIntent begins a provider flow on behalf of the signed-in user
+// and responds with the authorization URL. The browser carries the intent
+// to the callback in a signed cookie, since the callback request has no
+// access token.
+func (h *handler) startAccountIntent(w http.ResponseWriter, r *http.Request, purpose string) {
+	user := helper.GetUserFromRequestContext(r)
+
+	provider, ok := h.providers.Get(r.PathValue("provider"))
+	if !ok {
+		helper.BadRequest(h.logger, w, "provider not supported", nil)
+		return
+	}
+
+	intent, err := helper.GenerateAccountLinkToken(&types.AccountLinkClaims{
+		Purpose:   purpose,
+		UserID:    user.ID,
+		SessionID: user.SessionID,
+		Provider:  provider.Name(),
+	}, accountLinkTTL)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	authURL, ok := h.beginOAuth(w, r, provider)
+	if !ok {
+		return
+	}
+
+	setOAuthCookie(w, "oauth_intent", intent)
+
+	helper.OK(h.logger, w, "continue with the identity provider", map[string]any{"url": authURL})
+}
+
+// completeAccountIntent finishes a link or re-authentication started with
+// startAccountIntent and redirects back to the account settings page.
+func (h *handler) completeAccountIntent(w http.ResponseWriter, r *http.Request, intent string, identity *types.CreateOAuthUser) {
+	claims, err := helper.VerifyAccountLinkToken(intent, types.AccountLinkIntent)
+	if err != nil {
+		claims, err = helper.VerifyAccountLinkToken(intent, types.AccountReauth)
+	}
+	if err != nil || claims.Provider != identity.Provider {
+		redirectToFrontend(w, r, "/settings/accounts", url.Values{"error": {"invalid_request"}})
+		return
+	}
+
+	switch claims.Purpose {
+	case types.AccountLinkIntent:
+		err := h.store.LinkAccount(r.Context(), &types.LinkAccount{
+			UserID:            claims.UserID,
+			Provider:          identity.Provider,
+			ProviderAccountID: identity.ProviderAccountID,
+		})
+		if err != nil {
+			if errors.Is(err, store.ErrAccountLinked) {
+				redirectToFrontend(w, r, "/settings/accounts", url.Values{"error": {"account_linked_elsewhere"}})
+				return
+			}
+			h.logger.Error("failed to link account", zap.Error(err))
+			redirectToFrontend(w, r, "/settings/accounts", url.Values{"error": {"link_failed"}})
+			return
+		}
+
+		redirectToFrontend(w, r, "/settings/accounts", url.Values{"linked": {identity.Provider}})
+
+	case types.AccountReauth:
+		ownerID, err := h.store.GetAccountUserID(r.Context(), identity.Provider, identity.ProviderAccountID)
+		if err != nil || ownerID != claims.UserID {
+			if err != nil && !errors.Is(err, store.ErrNotFound) {
+				h.logger.Error("failed to look up account", zap.Error(err))
+			}
+			redirectToFrontend(w, r, "/settings/accounts", url.Values{"error": {"reauthentication_failed"}})
+			return
+		}
+
+		if err := h.store.MarkSessionAuthenticated(r.Context(), claims.UserID, claims.SessionID); err != nil {
+			h.logger.Error("failed to mark session authenticated", zap.Error(err))
+			redirectToFrontend(w, r, "/settings/accounts", url.Values{"error": {"reauthentication_failed"}})
+			return
+		}
+
+		redirectToFrontend(w, r, "/settings/accounts", url.Values{"reauthenticated": {identity.Provider}})
+	}
+}
+
+// offerAccountLink handles a provider sign-in whose email belongs to an
+// existing user. Rather than merging, it hands the frontend a pending link
+// that the owner confirms after signing in the usual way. The email is only
+// carried inside the signed token, not in the redirect URL.
+func (h *handler) offerAccountLink(w http.ResponseWriter, r *http.Request, identity *types.CreateOAuthUser) {
+	// linking on an unverified email would let anyone who can register
+	// that address with the provider take over the account
+	if !identity.EmailVerified {
+		redirectToFrontend(w, r, "/oauth/callback", url.Values{"error": {"email_unverified"}})
+		return
+	}
+
+	token, err := helper.GenerateAccountLinkToken(&types.AccountLinkClaims{
+		Purpose:           types.AccountLinkPending,
+		UserID:            identity.ID,
+		Provider:          identity.Provider,
+		ProviderAccountID: identity.ProviderAccountID,
+		Email:             identity.Email,
+	}, accountLinkTTL)
+	if err != nil {
+		h.logger.Error("failed to generate account link token", zap.Error(err))
+		redirectToFrontend(w, r, "/oauth/callback", url.Values{"error": {"link_failed"}})
+		return
+	}
+
+	redirectToFrontend(w, r, "/oauth/link", url.Values{
+		"provider": {identity.Provider},
+		"token":    {token},
+	})
+}
+
+// handleConfirmAccountLink links an identity offered by offerAccountLink
+// once its owner has signed in.
+func (h *handler) handleConfirmAccountLink(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+
+	var payload types.ConfirmAccountLink
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.BadRequest(h.logger, w, "failed to read request body", nil)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.UnprocessableEntity(h.logger, w, "failed to validate request body", nil)
+		return
+	}
+
+	claims, err := helper.VerifyAccountLinkToken(payload.Token, types.AccountLinkPending)
+	if err != nil || claims.UserID != user.ID {
+		helper.BadRequest(h.logger, w, "invalid or expired link token", nil)
+		return
+	}
+
+	if !h.requireRecentAuth(w, r, user) {
+		return
+	}
+
+	err = h.store.LinkAccount(r.Context(), &types.LinkAccount{
+		UserID:            user.ID,
+		Provider:          claims.Provider,
+		ProviderAccountID: claims.ProviderAccountID,
+	})
+	if err != nil {
+		if errors.Is(err, store.ErrAccountLinked) {
+			helper.Conflict(h.logger, w, "provider account is linked to another user", nil)
+			return
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "account linked successfully", nil)
+}
+
+func (h *handler) handleUnlinkAccount(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+
+	accountID := r.PathValue("accountID")
+	if err := h.validator.Var(accountID, "required,uuid"); err != nil {
+		helper.BadRequest(h.logger, w, "invalid account id", nil)
+		return
+	}
+
+	if !h.requireRecentAuth(w, r, user) {
+		return
+	}
+
+	if err := h.store.UnlinkAccount(r.Context(), user.ID, accountID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			helper.NotFound(h.logger, w, "account not found", nil)
+		case errors.Is(err, store.ErrLastLoginMethod):
+			helper.Conflict(h.logger, w, "cannot unlink your only way to sign in, set a password or link another provider first", nil)
+		default:
+			helper.InternalServerError(h.logger, w, nil, err)
+		}
+		return
+	}
+
+	helper.OK(h.logger, w, "account unlinked successfully", nil)
+}
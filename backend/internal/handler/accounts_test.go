@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	m "github.com/vaidik-bajpai/Nexus/backend/internal/store/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func TestOfferAccountLink(t *testing.T) {
+	t.Setenv("ACCESS_TOKEN_SECRET", "test-secret")
+
+	identity := func(verified bool) *types.CreateOAuthUser {
+		return &types.CreateOAuthUser{
+			ID:                testUserID,
+			Email:             "ada@example.com",
+			EmailVerified:     verified,
+			Provider:          "github",
+			ProviderAccountID: "42",
+		}
+	}
+
+	t.Run("verified email", func(t *testing.T) {
+		h := createTestHandler(new(m.MockStore), nil)
+		rr := httptest.NewRecorder()
+
+		h.offerAccountLink(rr, httptest.NewRequest(http.MethodGet, "/", nil), identity(true))
+
+		require.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		location, err := url.Parse(rr.Header().Get("Location"))
+		require.NoError(t, err)
+		assert.Equal(t, "/oauth/link", location.Path)
+		assert.Empty(t, location.Query().Get("email"), "the email must not leak into the URL")
+
+		claims, err := helper.VerifyAccountLinkToken(location.Query().Get("token"), types.AccountLinkPending)
+		require.NoError(t, err)
+		assert.Equal(t, testUserID, claims.UserID)
+		assert.Equal(t, "ada@example.com", claims.Email)
+	})
+
+	t.Run("unverified email", func(t *testing.T) {
+		h := createTestHandler(new(m.MockStore), nil)
+		rr := httptest.NewRecorder()
+
+		h.offerAccountLink(rr, httptest.NewRequest(http.MethodGet, "/", nil), identity(false))
+
+		require.Equal(t, http.StatusTemporaryRedirect, rr.Code)
+		location, err := url.Parse(rr.Header().Get("Location"))
+		require.NoError(t, err)
+		assert.Equal(t, "/oauth/callback", location.Path)
+		assert.Equal(t, "email_unverified", location.Query().Get("error"))
+		assert.Empty(t, location.Query().Get("token"))
+	})
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+)
+
+func (h *handler) handleListBoardActivity(w http.ResponseWriter, r *http.Request) {
+	boardID := r.PathValue("boardID")
+	paginate := helper.GetPaginateFromRequestContext(r)
+
+	activities, err := h.store.ListBoardActivity(r.Context(), boardID, paginate)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "board activity fetched successfully", map[string]any{"activities": activities})
+}
+
+func (h *handler) handleListCardActivity(w http.ResponseWriter, r *http.Request) {
+	if !h.cardOnBoard(w, r) {
+		return
+	}
+	cardID := r.PathValue("cardID")
+
+	paginate := helper.GetPaginateFromRequestContext(r)
+
+	activities, err := h.store.ListCardActivity(r.Context(), cardID, paginate)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "card activity fetched successfully", map[string]any{"activities": activities})
+}
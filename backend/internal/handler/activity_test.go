@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	m "github.com/vaidik-bajpai/Nexus/backend/internal/store/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func TestHandleListBoardActivity(t *testing.T) {
+	ms := new(m.MockStore)
+	ms.On("ListBoardActivity", mock.Anything, testBoardID, mock.Anything).Return([]*types.Activity{
+		{ID: "a1", BoardID: testBoardID, Type: types.ActivityCardCreated, Metadata: json.RawMessage(`{"after":{"title":"x"}}`)},
+	}, nil)
+	h := createTestHandler(ms, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.SetPathValue("boardID", testBoardID)
+	req = helper.SetPaginateInRequestContext(req, types.DefaultPaginate())
+	rr := httptest.NewRecorder()
+
+	h.handleListBoardActivity(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var body struct {
+		Data struct {
+			Activities []*types.Activity `json:"activities"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	require.Len(t, body.Data.Activities, 1)
+	assert.Equal(t, types.ActivityCardCreated, body.Data.Activities[0].Type)
+	assert.JSONEq(t, `{"after":{"title":"x"}}`, string(body.Data.Activities[0].Metadata))
+}
+
+func TestHandleListCardActivity(t *testing.T) {
+	tests := []struct {
+		name           string
+		boardID        string
+		expectedStatus int
+	}{
+		{"card on the board", testBoardID, http.StatusOK},
+		{"card on another board", "another-board", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(m.MockStore)
+			ms.On("GetCardBoardID", mock.Anything, testCardID).Return(tt.boardID, nil)
+			ms.On("ListCardActivity", mock.Anything, testCardID, mock.Anything).Return([]*types.Activity{}, nil).Maybe()
+			h := createTestHandler(ms, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.SetPathValue("boardID", testBoardID)
+			req.SetPathValue("cardID", testCardID)
+			req = helper.SetPaginateInRequestContext(req, types.DefaultPaginate())
+			rr := httptest.NewRecorder()
+
+			h.handleListCardActivity(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus != http.StatusOK {
+				ms.AssertNotCalled(t, "ListCardActivity", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+// handleCreateAPIToken issues a personal access token. The token is only
+// returned here; afterwards just its prefix is shown.
+func (h *handler) handleCreateAPIToken(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+
+	var payload types.CreateAPIToken
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.BadRequest(h.logger, w, "failed to read request body", nil)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.UnprocessableEntity(h.logger, w, "failed to validate request body", nil)
+		return
+	}
+
+	if payload.ExpiresAt != nil && !payload.ExpiresAt.After(time.Now()) {
+		helper.UnprocessableEntity(h.logger, w, "expiry must be in the future", nil)
+		return
+	}
+
+	if !h.requireRecentAuth(w, r, user) {
+		return
+	}
+
+	token, tokenHash, prefix, err := helper.GenerateAPIToken()
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	payload.UserID = user.ID
+	payload.TokenHash = tokenHash
+	payload.Prefix = prefix
+
+	if err := h.store.CreateAPIToken(r.Context(), &payload); err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.Created(h.logger, w, "api token created, copy it now as it will not be shown again", map[string]any{
+		"token": token,
+		"apiToken": &types.APIToken{
+			ID:        payload.ID,
+			Name:      payload.Name,
+			Prefix:    prefix,
+			Scopes:    payload.Scopes,
+			ExpiresAt: payload.ExpiresAt,
+			CreatedAt: time.Now(),
+		},
+	})
+}
+
+func (h *handler) handleListAPITokens(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+
+	tokens, err := h.store.ListAPITokens(r.Context(), user.ID)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "api tokens fetched successfully", map[string]any{"apiTokens": tokens})
+}
+
+func (h *handler) handleRevokeAPIToken(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+
+	tokenID := r.PathValue("tokenID")
+	if err := h.validator.Var(tokenID, "required,uuid"); err != nil {
+		helper.BadRequest(h.logger, w, "invalid token id", nil)
+		return
+	}
+
+	if err := h.store.RevokeAPIToken(r.Context(), user.ID, tokenID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.NotFound(h.logger, w, "api token not found", nil)
+			return
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "api token revoked successfully", nil)
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/events"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+	"go.uber.org/zap"
+)
+
+func (h *handler) handleCreateAttachment(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+	if !h.cardOnBoard(w, r) {
+		return
+	}
+	cardID := r.PathValue("cardID")
+
+	upload, err := readUploadedFile(w, r)
+	if err != nil {
+		if errors.Is(err, errFileTooLarge) {
+			helper.BadRequest(h.logger, w, errFileTooLarge.Error(), nil)
+			return
+		}
+		helper.BadRequest(h.logger, w, "failed to retrieve file from form", err)
+		return
+	}
+	defer upload.file.Close()
+
+	payload := types.CreateAttachment{
+		CardID:   cardID,
+		UserID:   user.ID,
+		FileName: upload.name,
+		FileKey:  fmt.Sprintf("cards/%s/%s%s", cardID, uuid.New().String(), filepath.Ext(upload.name)),
+		FileType: upload.contentType,
+		FileSize: int(upload.size),
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "failed validation on the request payload", err)
+		return
+	}
+
+	if err := h.blob.Put(r.Context(), payload.FileKey, upload.body, upload.size, upload.contentType); err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	attachment, err := h.store.CreateAttachment(r.Context(), &payload)
+	if err != nil {
+		if err := h.blob.Delete(r.Context(), payload.FileKey); err != nil {
+			h.logger.Error("failed to clean up orphaned blob", zap.String("key", payload.FileKey), zap.Error(err))
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	attachment.URL = h.attachmentURL(r, attachment)
+
+	h.publish(r, events.AttachmentCreated, attachment)
+
+	helper.Created(h.logger, w, "attachment uploaded successfully", attachment)
+}
+
+func (h *handler) handleListCardAttachments(w http.ResponseWriter, r *http.Request) {
+	if !h.cardOnBoard(w, r) {
+		return
+	}
+	cardID := r.PathValue("cardID")
+
+	attachments, err := h.store.ListCardAttachments(r.Context(), cardID)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	for _, attachment := range attachments {
+		attachment.URL = h.attachmentURL(r, attachment)
+	}
+
+	helper.OK(h.logger, w, "attachments fetched successfully", map[string]any{"attachments": attachments})
+}
+
+func (h *handler) handleDownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	attachment, ok := h.getCardAttachment(w, r)
+	if !ok {
+		return
+	}
+
+	h.serveBlob(w, r, attachment.FileKey, attachment.FileType, attachment.FileName)
+}
+
+func (h *handler) handleDeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+	attachment, ok := h.getCardAttachment(w, r)
+	if !ok {
+		return
+	}
+
+	if attachment.UploadedBy != user.ID {
+		member := helper.GetBoardMemberFromRequestContext(r)
+		if !member.Can(types.PermissionManage) {
+			helper.Forbidden(h.logger, w, "only the uploader or a board admin can delete this attachment", nil)
+			return
+		}
+	}
+
+	if err := h.store.DeleteAttachment(r.Context(), attachment.ID); err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	if err := h.blob.Delete(r.Context(), attachment.FileKey); err != nil {
+		h.logger.Error("failed to delete attachment blob", zap.String("key", attachment.FileKey), zap.Error(err))
+	}
+
+	h.publish(r, events.AttachmentDeleted, map[string]any{"cardID": attachment.CardID, "attachmentID": attachment.ID})
+
+	helper.OK(h.logger, w, "attachment deleted successfully", nil)
+}
+
+// getCardAttachment loads the attachment from the path and makes sure it
+// belongs to the card and board in the path, writing the error response
+// otherwise.
+func (h *handler) getCardAttachment(w http.ResponseWriter, r *http.Request) (*types.Attachment, bool) {
+	attachmentID := r.PathValue("attachmentID")
+	if err := h.validator.Var(attachmentID, "required,uuid"); err != nil {
+		helper.BadRequest(h.logger, w, "invalid attachment id", nil)
+		return nil, false
+	}
+
+	if !h.cardOnBoard(w, r) {
+		return nil, false
+	}
+
+	attachment, err := h.store.GetAttachment(r.Context(), attachmentID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.NotFound(h.logger, w, "attachment not found", nil)
+			return nil, false
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return nil, false
+	}
+
+	if attachment.CardID != r.PathValue("cardID") {
+		helper.NotFound(h.logger, w, "attachment not found", nil)
+		return nil, false
+	}
+
+	return attachment, true
+}
+
+func (h *handler) attachmentURL(r *http.Request, attachment *types.Attachment) string {
+	return fmt.Sprintf(
+		"%s/api/v1/boards/%s/lists/%s/cards/%s/attachments/%s/download",
+		h.publicURL, r.PathValue("boardID"), r.PathValue("listID"), attachment.CardID, attachment.ID,
+	)
+}
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/events"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/storage"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	m "github.com/vaidik-bajpai/Nexus/backend/internal/store/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+const testAttachmentID = "4d5e6f70-8192-4a3b-8c4d-5e6f708192a3"
+
+func newAttachmentRequest(t *testing.T, method string, content []byte) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	contentType := ""
+	if content != nil {
+		mw := multipart.NewWriter(&body)
+		fw, err := mw.CreateFormFile("file", "notes.txt")
+		require.NoError(t, err)
+		_, err = fw.Write(content)
+		require.NoError(t, err)
+		require.NoError(t, mw.Close())
+		contentType = mw.FormDataContentType()
+	}
+
+	req := httptest.NewRequest(method, "/", &body)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.SetPathValue("boardID", testBoardID)
+	req.SetPathValue("cardID", testCardID)
+	req.SetPathValue("attachmentID", testAttachmentID)
+	req = helper.SetUserInRequestContext(req, &types.User{ID: testUserID})
+	return helper.SetBoardMemberInRequestContext(req, &types.BoardMember{BoardID: testBoardID, UserID: testUserID, Role: types.RoleNormal})
+}
+
+func createAttachmentTestHandler(t *testing.T, ms *m.MockStore) (*handler, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	blob, err := storage.NewLocal(dir)
+	require.NoError(t, err)
+
+	h := createTestHandler(ms, nil)
+	h.blob = blob
+	h.events = events.NewMemoryHub()
+	return h, dir
+}
+
+func TestHandleCreateAttachment(t *testing.T) {
+	t.Run("successful upload", func(t *testing.T) {
+		ms := new(m.MockStore)
+		ms.On("GetCardBoardID", mock.Anything, testCardID).Return(testBoardID, nil)
+		ms.On("CreateAttachment", mock.Anything, mock.MatchedBy(func(a *types.CreateAttachment) bool {
+			return a.CardID == testCardID && a.FileName == "notes.txt" && a.FileSize == 5
+		})).Return(&types.Attachment{ID: testAttachmentID, CardID: testCardID}, nil)
+		h, _ := createAttachmentTestHandler(t, ms)
+
+		rr := httptest.NewRecorder()
+		h.handleCreateAttachment(rr, newAttachmentRequest(t, http.MethodPost, []byte("hello")))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		ms.AssertExpectations(t)
+	})
+
+	t.Run("card on another board", func(t *testing.T) {
+		ms := new(m.MockStore)
+		ms.On("GetCardBoardID", mock.Anything, testCardID).Return("another-board", nil)
+		h, dir := createAttachmentTestHandler(t, ms)
+
+		rr := httptest.NewRecorder()
+		h.handleCreateAttachment(rr, newAttachmentRequest(t, http.MethodPost, []byte("hello")))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assertNoBlobs(t, dir)
+	})
+
+	t.Run("file too large", func(t *testing.T) {
+		ms := new(m.MockStore)
+		ms.On("GetCardBoardID", mock.Anything, testCardID).Return(testBoardID, nil)
+		h, dir := createAttachmentTestHandler(t, ms)
+
+		rr := httptest.NewRecorder()
+		h.handleCreateAttachment(rr, newAttachmentRequest(t, http.MethodPost, bytes.Repeat([]byte("a"), maxUploadSize+1)))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), errFileTooLarge.Error())
+		ms.AssertNotCalled(t, "CreateAttachment", mock.Anything, mock.Anything)
+		assertNoBlobs(t, dir)
+	})
+}
+
+func TestHandleDownloadAttachment(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(*m.MockStore)
+		expectedStatus int
+	}{
+		{
+			name: "card on another board",
+			setupMock: func(ms *m.MockStore) {
+				ms.On("GetCardBoardID", mock.Anything, testCardID).Return("another-board", nil)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "unknown attachment",
+			setupMock: func(ms *m.MockStore) {
+				ms.On("GetCardBoardID", mock.Anything, testCardID).Return(testBoardID, nil)
+				ms.On("GetAttachment", mock.Anything, testAttachmentID).Return(nil, store.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "attachment on another card",
+			setupMock: func(ms *m.MockStore) {
+				ms.On("GetCardBoardID", mock.Anything, testCardID).Return(testBoardID, nil)
+				ms.On("GetAttachment", mock.Anything, testAttachmentID).Return(&types.Attachment{ID: testAttachmentID, CardID: "another-card"}, nil)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(m.MockStore)
+			tt.setupMock(ms)
+			h, _ := createAttachmentTestHandler(t, ms)
+
+			rr := httptest.NewRecorder()
+			h.handleDownloadAttachment(rr, newAttachmentRequest(t, http.MethodGet, nil))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			ms.AssertExpectations(t)
+		})
+	}
+}
+
+func assertNoBlobs(t *testing.T, dir string) {
+	t.Helper()
+
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			files = append(files, path)
+		}
+		return err
+	})
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/automation"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func (h *handler) handleCreateAutomation(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+	var payload types.CreateAutomation
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.UnprocessableEntity(h.logger, w, "invalid request payload", nil)
+		return
+	}
+
+	payload.BoardID = r.PathValue("boardID")
+	payload.CreatedBy = user.ID
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "failed validation on the request payload", err)
+		return
+	}
+
+	if !h.checkAutomationRefs(w, r, &payload.Trigger, payload.Actions) {
+		return
+	}
+
+	created, err := h.store.CreateAutomation(r.Context(), &payload)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.Created(h.logger, w, "automation created successfully", created)
+}
+
+func (h *handler) handleListAutomations(w http.ResponseWriter, r *http.Request) {
+	boardID := r.PathValue("boardID")
+
+	automations, err := h.store.ListBoardAutomations(r.Context(), boardID)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "automations fetched successfully", map[string]any{"automations": automations})
+}
+
+func (h *handler) handleUpdateAutomation(w http.ResponseWriter, r *http.Request) {
+	current, ok := h.getBoardAutomation(w, r)
+	if !ok {
+		return
+	}
+
+	var payload types.UpdateAutomation
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.UnprocessableEntity(h.logger, w, "invalid request payload", nil)
+		return
+	}
+
+	payload.AutomationID = current.ID
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "failed validation on the request payload", err)
+		return
+	}
+
+	trigger, actions := &current.Trigger, current.Actions
+	if payload.Trigger != nil {
+		trigger = payload.Trigger
+	}
+	if payload.Actions != nil {
+		actions = payload.Actions
+	}
+	if !h.checkAutomationRefs(w, r, trigger, actions) {
+		return
+	}
+
+	updated, err := h.store.UpdateAutomation(r.Context(), &payload)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "automation updated successfully", updated)
+}
+
+func (h *handler) handleToggleAutomation(w http.ResponseWriter, r *http.Request) {
+	current, ok := h.getBoardAutomation(w, r)
+	if !ok {
+		return
+	}
+
+	enabled := !current.Enabled
+	updated, err := h.store.UpdateAutomation(r.Context(), &types.UpdateAutomation{
+		AutomationID: current.ID,
+		Enabled:      &enabled,
+	})
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "automation toggled successfully", updated)
+}
+
+func (h *handler) handleDeleteAutomation(w http.ResponseWriter, r *http.Request) {
+	current, ok := h.getBoardAutomation(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.store.DeleteAutomation(r.Context(), current.ID); err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "automation deleted successfully", nil)
+}
+
+func (h *handler) handleListAutomationRuns(w http.ResponseWriter, r *http.Request) {
+	current, ok := h.getBoardAutomation(w, r)
+	if !ok {
+		return
+	}
+
+	paginate := helper.GetPaginateFromRequestContext(r)
+
+	runs, err := h.store.ListAutomationRuns(r.Context(), current.ID, paginate)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "automation runs fetched successfully", map[string]any{"runs": runs})
+}
+
+// getBoardAutomation loads the automation in the request path and makes sure
+// it belongs to the board in the path. It writes the error response itself.
+func (h *handler) getBoardAutomation(w http.ResponseWriter, r *http.Request) (*types.Automation, bool) {
+	automationID := r.PathValue("automationID")
+	if err := h.validator.Var(automationID, "required,uuid"); err != nil {
+		helper.BadRequest(h.logger, w, "invalid automation id", nil)
+		return nil, false
+	}
+
+	current, err := h.store.GetAutomation(r.Context(), automationID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.NotFound(h.logger, w, "automation not found", nil)
+			return nil, false
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return nil, false
+	}
+
+	if current.BoardID != r.PathValue("boardID") {
+		helper.NotFound(h.logger, w, "automation not found", nil)
+		return nil, false
+	}
+
+	return current, true
+}
+
+// checkAutomationRefs rejects rules pointing at lists, labels or members of
+// another board. It writes the error response itself.
+func (h *handler) checkAutomationRefs(w http.ResponseWriter, r *http.Request, trigger *types.AutomationTrigger, actions []types.AutomationAction) bool {
+	var refs types.AutomationRefs
+	if trigger.ListID != "" {
+		refs.ListIDs = append(refs.ListIDs, trigger.ListID)
+	}
+	if trigger.LabelID != "" {
+		refs.LabelIDs = append(refs.LabelIDs, trigger.LabelID)
+	}
+	for _, action := range actions {
+		if action.ListID != "" {
+			refs.ListIDs = append(refs.ListIDs, action.ListID)
+		}
+		if action.LabelID != "" {
+			refs.LabelIDs = append(refs.LabelIDs, action.LabelID)
+		}
+		if action.UserID != "" {
+			refs.UserIDs = append(refs.UserIDs, action.UserID)
+		}
+	}
+
+	ok, err := h.store.BoardRefsExist(r.Context(), r.PathValue("boardID"), &refs)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return false
+	}
+	if !ok {
+		helper.BadRequest(h.logger, w, "automation refers to lists, labels or members outside this board", nil)
+		return false
+	}
+
+	return true
+}
+
+// fireAutomations runs the board's rules for event in the background so the
+// response does not wait on them.
+func (h *handler) fireAutomations(r *http.Request, event *automation.Event) {
+	go h.automations.Handle(context.WithoutCancel(r.Context()), event)
+}
+
+// RunAutomations fires due_date_passed rules, checking every interval until
+// ctx is cancelled.
+func (h *handler) RunAutomations(ctx context.Context, interval time.Duration) {
+	h.automations.RunDueDateTriggers(ctx, interval)
+}
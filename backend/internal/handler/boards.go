@@ -1,12 +1,15 @@
 package handler
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/vaidik-bajpai/Nexus/backend/internal/events"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
 )
 
@@ -23,8 +26,15 @@ func (h *handler) handleCreateBoard(w http.ResponseWriter, r *http.Request) {
 	}
 
 	owner := helper.GetUserFromRequestContext(r)
+	if !h.requireVerifiedEmail(w, owner, VerificationPolicyCreateBoard) {
+		return
+	}
 	board.OwnerID = owner.ID
 
+	if board.WorkspaceID != "" && !h.requireWorkspaceMember(w, r, board.WorkspaceID, owner.ID) {
+		return
+	}
+
 	if err := h.store.CreateBoard(r.Context(), &board); err != nil {
 		helper.InternalServerError(h.logger, w, nil, err)
 		return
@@ -37,7 +47,30 @@ func (h *handler) handleListBoards(w http.ResponseWriter, r *http.Request) {
 	user := helper.GetUserFromRequestContext(r)
 	paginate := helper.GetPaginateFromRequestContext(r)
 
-	boards, err := h.store.ListBoards(r.Context(), user.ID, paginate)
+	query := r.URL.Query()
+	filter := &types.ListBoardsFilter{
+		UserID:     user.ID,
+		Archived:   query.Get("archived"),
+		Ownership:  query.Get("ownership"),
+		Visibility: query.Get("visibility"),
+		Search:     strings.TrimSpace(query.Get("search")),
+		SortBy:     paginate.SortBy,
+	}
+
+	if starred := query.Get("starred"); starred != "" {
+		var err error
+		if filter.Starred, err = strconv.ParseBool(starred); err != nil {
+			helper.BadRequest(h.logger, w, "invalid starred filter", nil)
+			return
+		}
+	}
+
+	if err := h.validator.Struct(filter); err != nil {
+		helper.BadRequest(h.logger, w, "invalid board filters; sort_by must be one of name, created_at, updated_at", nil)
+		return
+	}
+
+	boards, err := h.store.ListBoards(r.Context(), filter, paginate)
 	if err != nil {
 		helper.InternalServerError(h.logger, w, nil, err)
 		return
@@ -46,9 +79,34 @@ func (h *handler) handleListBoards(w http.ResponseWriter, r *http.Request) {
 	helper.OK(h.logger, w, "boards fetched successfully", map[string]any{"boards": boards})
 }
 
+func (h *handler) handleStarBoard(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+
+	var payload types.StarBoard
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.UnprocessableEntity(h.logger, w, "invalid request payload", nil)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "validation on request payload", nil)
+		return
+	}
+
+	if err := h.store.SetBoardStarred(r.Context(), r.PathValue("boardID"), user.ID, *payload.Starred); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.Forbidden(h.logger, w, "only board members can star a board", nil)
+			return
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "board star updated successfully", map[string]any{"starred": *payload.Starred})
+}
+
 func (h *handler) handleInviteToBoard(w http.ResponseWriter, r *http.Request) {
 	user := helper.GetUserFromRequestContext(r)
-	board := helper.GetBoardFromRequestContext(r)
 	boardID := r.PathValue("boardID")
 
 	var payload *types.BoardInvitation
@@ -57,15 +115,22 @@ func (h *handler) handleInviteToBoard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	token, err := helper.GenerateInvitationToken()
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
 	payload.BoardID = boardID
 	payload.InvitedBy = user.ID
-	payload.Token = strconv.Itoa(helper.CreateRandomToken())
-	payload.ExpiredAt = time.Now().Add(2 * 24 * time.Hour)
+	payload.Token = token
+	payload.ExpiredAt = time.Now().Add(boardInvitationTTL)
 
 	if err := h.validator.Struct(payload); err != nil {
 		helper.BadRequest(h.logger, w, "validation on request payload", nil)
 		return
 	}
+	payload.Role = types.NormalizeRole(payload.Role)
 
 	if isMember, err := h.store.IsABoardMember(r.Context(), payload.Email, payload.BoardID); !isMember {
 		if err != nil {
@@ -76,54 +141,39 @@ func (h *handler) handleInviteToBoard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := h.store.CreateBoardInvitation(r.Context(), payload)
-	if err != nil {
+	if err := h.store.CreateBoardInvitation(r.Context(), payload); err != nil {
+		if errors.Is(err, store.ErrInvitationPending) {
+			helper.Conflict(h.logger, w, "an invitation is already pending for this email; resend it instead", nil)
+			return
+		}
 		helper.InternalServerError(h.logger, w, nil, err)
 		return
 	}
 
-	if err = h.mailer.SendBoardInvitationEmail(
-		[]string{payload.Email},
-		"Invitation to join nexus",
-		user.Username,
-		board.Name,
-		fmt.Sprintf("http://localhost:3000/join?token=%s", payload.Token),
-	); err != nil {
+	if err := h.sendBoardInvitation(r, payload); err != nil {
 		helper.InternalServerError(h.logger, w, nil, err)
 		return
 	}
 
+	h.publish(r, events.MemberInvited, map[string]any{"email": payload.Email, "role": payload.Role})
+
 	helper.OK(h.logger, w, "invitation email sent successfully", nil)
 }
 
 func (h *handler) handleAcceptInviteToBoard(w http.ResponseWriter, r *http.Request) {
 	user := helper.GetUserFromRequestContext(r)
-	token := r.URL.Query().Get("token")
-	if token == "" {
-		helper.BadRequest(h.logger, w, "invalid request token", nil)
+	token, ok := h.readBoardInvitationToken(w, r)
+	if !ok {
 		return
 	}
 
-	invitation, err := h.store.GetBoardInvitationByToken(r.Context(), token)
+	invitation, err := h.store.AcceptBoardInvitation(r.Context(), token, user.ID, user.Email)
 	if err != nil {
-		helper.InternalServerError(h.logger, w, nil, err)
+		h.writeInvitationError(w, err)
 		return
 	}
 
-	if invitation.ExpiredAt.Before(time.Now()) {
-		helper.BadRequest(h.logger, w, "invitation expired", nil)
-		return
-	}
-
-	if invitation.Email != user.Email {
-		helper.BadRequest(h.logger, w, "invalid request", nil)
-		return
-	}
-
-	if err := h.store.AcceptBoardInvitation(r.Context(), invitation.Token, user.ID, invitation.Role); err != nil {
-		helper.InternalServerError(h.logger, w, nil, err)
-		return
-	}
+	h.publish(r, events.MemberJoined, map[string]any{"userID": user.ID, "role": invitation.Role})
 
 	helper.OK(h.logger, w, "invitation accepted successfully", nil)
 }
@@ -148,6 +198,8 @@ func (h *handler) handleUpdateBoard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.publish(r, events.BoardUpdated, payload)
+
 	helper.OK(h.logger, w, "board updated successfully", nil)
 }
 
@@ -164,12 +216,22 @@ func (h *handler) handleDeleteBoard(w http.ResponseWriter, r *http.Request) {
 func (h *handler) handleGetCardsAndLists(w http.ResponseWriter, r *http.Request) {
 	boardID := r.PathValue("boardID")
 
+	sortBy := r.URL.Query().Get("sort")
+	if err := h.validator.Var(sortBy, "omitempty,oneof=position votes"); err != nil {
+		helper.BadRequest(h.logger, w, "invalid sort", nil)
+		return
+	}
+
 	board, err := h.store.GetCardsAndLists(r.Context(), boardID)
 	if err != nil {
 		helper.InternalServerError(h.logger, w, nil, err)
 		return
 	}
 
+	if sortBy == "votes" {
+		sortCardsByVotes(board)
+	}
+
 	helper.OK(h.logger, w, "board detail fetched successfully", map[string]any{"board": board})
 }
 
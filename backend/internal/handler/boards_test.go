@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/events"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	mailerMock "github.com/vaidik-bajpai/Nexus/backend/internal/mailer/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	m "github.com/vaidik-bajpai/Nexus/backend/internal/store/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func TestHandleListBoards(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedFilter *types.ListBoardsFilter
+		expectedStatus int
+	}{
+		{
+			name:           "default sort",
+			query:          "",
+			expectedFilter: &types.ListBoardsFilter{UserID: testUserID, SortBy: "created_at"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "sort by name",
+			query:          "sort_by=name",
+			expectedFilter: &types.ListBoardsFilter{UserID: testUserID, SortBy: "name"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "sort by updated_at",
+			query:          "sort_by=updated_at",
+			expectedFilter: &types.ListBoardsFilter{UserID: testUserID, SortBy: "updated_at"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "filters",
+			query: "archived=all&ownership=shared&visibility=team&starred=true&search=+roadmap+",
+			expectedFilter: &types.ListBoardsFilter{
+				UserID:     testUserID,
+				Archived:   "all",
+				Ownership:  "shared",
+				Visibility: "team",
+				Search:     "roadmap",
+				Starred:    true,
+				SortBy:     "created_at",
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "sort by a column outside the whitelist",
+			query:          "sort_by=password",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "sort by an injected expression",
+			query:          "sort_by=name%3B+DROP+TABLE+boards",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown ownership",
+			query:          "ownership=everyone",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid starred",
+			query:          "starred=maybe",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(m.MockStore)
+			if tt.expectedFilter != nil {
+				ms.On("ListBoards", mock.Anything, tt.expectedFilter, mock.Anything).Return([]*types.Board{}, nil)
+			}
+			h := createTestHandler(ms, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+			q := req.URL.Query()
+			req = helper.SetUserInRequestContext(req, &types.User{ID: testUserID})
+			req = helper.SetPaginateInRequestContext(req, types.ParsePaginateFromQuery(q.Get("page"), q.Get("size"), q.Get("sort_by"), q.Get("sort_order")))
+			rr := httptest.NewRecorder()
+
+			h.handleListBoards(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedFilter != nil {
+				ms.AssertExpectations(t)
+			} else {
+				ms.AssertNotCalled(t, "ListBoards", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestHandleInviteToBoard(t *testing.T) {
+	const inviteeEmail = "new.person@example.com"
+
+	tests := []struct {
+		name         string
+		inviteeUser  *types.User
+		expectedLink string
+	}{
+		{
+			name:         "email without an account gets a signup link",
+			expectedLink: "http://localhost:3000/signup?email=new.person%40example.com&boardID=" + testBoardID + "&token=",
+		},
+		{
+			name:         "existing user gets a join link",
+			inviteeUser:  &types.User{ID: "invitee", Email: inviteeEmail},
+			expectedLink: "http://localhost:3000/join?boardID=" + testBoardID + "&token=",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(m.MockStore)
+			mm := new(mailerMock.MockMailer)
+			ms.On("IsABoardMember", mock.Anything, inviteeEmail, testBoardID).Return(nil)
+			ms.On("CreateBoardInvitation", mock.Anything, mock.MatchedBy(func(i *types.BoardInvitation) bool {
+				return i.BoardID == testBoardID && i.Email == inviteeEmail && i.InvitedBy == testUserID && i.Role == types.RoleNormal
+			})).Return(nil)
+			ms.On("GetUserByID", mock.Anything, testUserID).Return(&types.User{ID: testUserID, Username: "inviter"}, nil)
+			if tt.inviteeUser != nil {
+				ms.On("GetUserByEmail", mock.Anything, inviteeEmail).Return(tt.inviteeUser, nil)
+			} else {
+				ms.On("GetUserByEmail", mock.Anything, inviteeEmail).Return(nil, store.ErrNotFound)
+			}
+			mm.On("SendBoardInvitationEmail", []string{inviteeEmail}, mock.Anything, "inviter", "Roadmap", mock.MatchedBy(func(link string) bool {
+				return strings.HasPrefix(link, tt.expectedLink) && len(link) > len(tt.expectedLink)
+			})).Return(nil)
+
+			h := createTestHandler(ms, mm)
+			h.events = events.NewMemoryHub()
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"email":"`+inviteeEmail+`","role":"member"}`))
+			req.SetPathValue("boardID", testBoardID)
+			req = helper.SetUserInRequestContext(req, &types.User{ID: testUserID, Email: "inviter@example.com"})
+			req = helper.SetBoardInRequestContext(req, &types.Board{ID: testBoardID, Name: "Roadmap"})
+			rr := httptest.NewRecorder()
+
+			h.handleInviteToBoard(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+			ms.AssertExpectations(t)
+			mm.AssertExpectations(t)
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/vaidik-bajpai/Nexus/backend/internal/automation"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/events"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
 	"go.uber.org/zap"
 )
@@ -27,15 +31,20 @@ func (h *handler) handleCreateCard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.store.CreateCard(r.Context(), &payload); err != nil {
+	card, err := h.store.CreateCard(r.Context(), &payload)
+	if err != nil {
 		helper.InternalServerError(h.logger, w, nil, err)
 		return
 	}
 
-	helper.Created(h.logger, w, "card created successfully", nil)
+	h.publish(r, events.CardCreated, map[string]any{"listID": listID, "card": card})
+
+	helper.Created(h.logger, w, "card created successfully", card)
 }
 
 func (h *handler) handleUpdateCard(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+	boardID := r.PathValue("boardID")
 	listID := r.PathValue("listID")
 	cardID := r.PathValue("cardID")
 	var payload types.UpdateCard
@@ -55,11 +64,36 @@ func (h *handler) handleUpdateCard(w http.ResponseWriter, r *http.Request) {
 
 	h.logger.Info("payload", zap.Any("payload", payload))
 
+	current, err := h.store.GetCardDetail(r.Context(), cardID)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
 	if err := h.store.UpdateCard(r.Context(), cardID, &payload); err != nil {
+		if errors.Is(err, store.ErrCardBlocked) {
+			helper.Conflict(h.logger, w, "card is blocked by incomplete cards, set force to complete it anyway", nil)
+			return
+		}
 		helper.InternalServerError(h.logger, w, nil, err)
 		return
 	}
 
+	if payload.Description != nil {
+		h.notifyMentions(r.Context(), user.ID, boardID, cardID, helper.NewMentions(current.Description, *payload.Description))
+	}
+
+	if current.ListID != listID {
+		h.fireAutomations(r, &automation.Event{
+			Trigger: types.TriggerCardMoved,
+			BoardID: boardID,
+			CardID:  cardID,
+			ListID:  listID,
+		})
+	}
+
+	h.publish(r, events.CardUpdated, map[string]any{"listID": listID, "cardID": cardID, "changes": payload})
+
 	helper.Created(h.logger, w, "card updated successfully", nil)
 }
 
@@ -81,6 +115,8 @@ func (h *handler) handleDeleteCard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.publish(r, events.CardDeleted, map[string]any{"listID": r.PathValue("listID"), "cardID": cardID})
+
 	helper.Created(h.logger, w, "card deleted successfully", nil)
 }
 
@@ -105,5 +141,35 @@ func (h *handler) handleToggleCardMembership(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	h.publish(r, events.CardMemberToggled, map[string]any{"listID": r.PathValue("listID"), "cardID": cardID, "userID": payload.UserID})
+
 	helper.Created(h.logger, w, "member added to card successfully", nil)
-}
\ No newline at end of file
+}
+
+// cardOnBoard checks that the card in the path is on the board in the path.
+// The permission middleware only vouches for the board, so card routes have
+// to make sure the card is not from somewhere else.
+func (h *handler) cardOnBoard(w http.ResponseWriter, r *http.Request) bool {
+	cardID := r.PathValue("cardID")
+	if err := h.validator.Var(cardID, "required,uuid"); err != nil {
+		helper.BadRequest(h.logger, w, "invalid card id", nil)
+		return false
+	}
+
+	boardID, err := h.store.GetCardBoardID(r.Context(), cardID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.NotFound(h.logger, w, "card not found", nil)
+			return false
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return false
+	}
+
+	if boardID != r.PathValue("boardID") {
+		helper.NotFound(h.logger, w, "card not found", nil)
+		return false
+	}
+
+	return true
+}
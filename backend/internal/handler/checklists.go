@@ -3,8 +3,11 @@ package handler
 import (
 	"net/http"
 
+	"github.com/vaidik-bajpai/Nexus/backend/internal/automation"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/events"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+	"go.uber.org/zap"
 )
 
 func (h *handler) handleAddChecklistToCard(w http.ResponseWriter, r *http.Request) {
@@ -27,6 +30,8 @@ func (h *handler) handleAddChecklistToCard(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	h.publish(r, events.ChecklistCreated, map[string]any{"cardID": cardID, "name": createChecklist.Name})
+
 	helper.OK(h.logger, w, "Checklist added to card successfully", nil)
 }
 
@@ -58,6 +63,8 @@ func (h *handler) handleDeleteChecklist(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	h.publish(r, events.ChecklistDeleted, map[string]any{"cardID": r.PathValue("cardID"), "checklistID": checklistID})
+
 	helper.OK(h.logger, w, "Checklist deleted successfully", nil)
 }
 
@@ -82,6 +89,8 @@ func (h *handler) handleAddChecklistItem(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	h.publish(r, events.ChecklistItemCreated, map[string]any{"cardID": r.PathValue("cardID"), "checklistID": checklistID, "item": item})
+
 	helper.Created(h.logger, w, "Checklist item added successfully", item)
 }
 
@@ -97,6 +106,8 @@ func (h *handler) handleDeleteChecklistItem(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	h.publish(r, events.ChecklistItemDeleted, map[string]any{"cardID": r.PathValue("cardID"), "checklistID": r.PathValue("checklistID"), "itemID": itemID})
+
 	helper.OK(h.logger, w, "Checklist item deleted successfully", nil)
 }
 
@@ -120,5 +131,33 @@ func (h *handler) handleUpdateChecklistItem(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if updateItem.Completed != nil && *updateItem.Completed {
+		h.fireChecklistCompleted(r)
+	}
+
+	h.publish(r, events.ChecklistItemUpdated, map[string]any{"cardID": r.PathValue("cardID"), "checklistID": r.PathValue("checklistID"), "itemID": itemID, "changes": updateItem})
+
 	helper.OK(h.logger, w, "Checklist item updated successfully", nil)
 }
+
+// fireChecklistCompleted fires checklist_completed rules once the last open
+// item of the checklist in the request path has been ticked.
+func (h *handler) fireChecklistCompleted(r *http.Request) {
+	checklist, err := h.store.GetChecklist(r.Context(), r.PathValue("checklistID"))
+	if err != nil {
+		h.logger.Error("failed to load checklist for automations", zap.Error(err))
+		return
+	}
+
+	for _, item := range checklist.CheckItems {
+		if !item.Completed {
+			return
+		}
+	}
+
+	h.fireAutomations(r, &automation.Event{
+		Trigger: types.TriggerChecklistCompleted,
+		BoardID: r.PathValue("boardID"),
+		CardID:  r.PathValue("cardID"),
+	})
+}
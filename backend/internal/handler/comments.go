@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/events"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func (h *handler) handleCreateComment(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+	var payload types.CreateComment
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.BadRequest(h.logger, w, "failed to read the request payload", err)
+		return
+	}
+
+	payload.BoardID = r.PathValue("boardID")
+	payload.CardID = r.PathValue("cardID")
+	payload.UserID = user.ID
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "failed validation on the request payload", err)
+		return
+	}
+
+	comment, err := h.store.CreateComment(r.Context(), &payload)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.NotFound(h.logger, w, "card not found", nil)
+			return
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	h.notifyMentions(r.Context(), user.ID, payload.BoardID, payload.CardID, helper.ParseMentions(payload.Content))
+
+	h.publish(r, events.CommentCreated, comment)
+
+	helper.Created(h.logger, w, "comment created successfully", comment)
+}
+
+func (h *handler) handleListCardComments(w http.ResponseWriter, r *http.Request) {
+	if !h.cardOnBoard(w, r) {
+		return
+	}
+	cardID := r.PathValue("cardID")
+
+	paginate := helper.GetPaginateFromRequestContext(r)
+
+	comments, err := h.store.ListCardComments(r.Context(), cardID, paginate)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "comments fetched successfully", map[string]any{"comments": comments})
+}
+
+func (h *handler) handleUpdateComment(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+	var payload types.UpdateComment
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.BadRequest(h.logger, w, "failed to read the request payload", err)
+		return
+	}
+
+	payload.CommentID = r.PathValue("commentID")
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "failed validation on the request payload", err)
+		return
+	}
+
+	if !h.cardOnBoard(w, r) {
+		return
+	}
+
+	comment, err := h.store.GetComment(r.Context(), payload.CommentID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.NotFound(h.logger, w, "comment not found", nil)
+			return
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	if comment.CardID != r.PathValue("cardID") {
+		helper.NotFound(h.logger, w, "comment not found", nil)
+		return
+	}
+
+	if comment.UserID != user.ID {
+		helper.Forbidden(h.logger, w, "only the author can edit this comment", nil)
+		return
+	}
+
+	updated, err := h.store.UpdateComment(r.Context(), &payload)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	h.notifyMentions(r.Context(), user.ID, r.PathValue("boardID"), comment.CardID, helper.NewMentions(comment.Content, payload.Content))
+
+	h.publish(r, events.CommentUpdated, updated)
+
+	helper.OK(h.logger, w, "comment updated successfully", updated)
+}
+
+func (h *handler) handleDeleteComment(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+	commentID := r.PathValue("commentID")
+	if err := h.validator.Var(commentID, "required,uuid"); err != nil {
+		helper.BadRequest(h.logger, w, "invalid comment id", nil)
+		return
+	}
+
+	if !h.cardOnBoard(w, r) {
+		return
+	}
+
+	comment, err := h.store.GetComment(r.Context(), commentID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.NotFound(h.logger, w, "comment not found", nil)
+			return
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	if comment.CardID != r.PathValue("cardID") {
+		helper.NotFound(h.logger, w, "comment not found", nil)
+		return
+	}
+
+	if comment.UserID != user.ID {
+		member := helper.GetBoardMemberFromRequestContext(r)
+		if !member.Can(types.PermissionManage) {
+			helper.Forbidden(h.logger, w, "only the author or a board admin can delete this comment", nil)
+			return
+		}
+	}
+
+	if err := h.store.DeleteComment(r.Context(), commentID); err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	h.publish(r, events.CommentDeleted, map[string]any{"cardID": comment.CardID, "commentID": commentID})
+
+	helper.OK(h.logger, w, "comment deleted successfully", nil)
+}
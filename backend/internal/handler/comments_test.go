@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/events"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	m "github.com/vaidik-bajpai/Nexus/backend/internal/store/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+const (
+	testBoardID   = "6f1c1b2e-0d3a-4e55-9a51-3c1b9f0e7a01"
+	testCardID    = "0b8e6f63-8a2f-4f0e-b9a3-7d5e4c3b2a10"
+	testCommentID = "9c7d4c8e-1f2a-4b3c-8d4e-5f6a7b8c9d01"
+	testUserID    = "2a3b4c5d-6e7f-4a8b-9c0d-1e2f3a4b5c6d"
+)
+
+// newCommentRequest builds a request for a card comment route as the
+// router would hand it over: user and board member set, path values filled.
+func newCommentRequest(method, body, role string) *http.Request {
+	req := httptest.NewRequest(method, "/", strings.NewReader(body))
+	req.SetPathValue("boardID", testBoardID)
+	req.SetPathValue("cardID", testCardID)
+	req.SetPathValue("commentID", testCommentID)
+	req = helper.SetUserInRequestContext(req, &types.User{ID: testUserID})
+	req = helper.SetPaginateInRequestContext(req, types.DefaultPaginate())
+	return helper.SetBoardMemberInRequestContext(req, &types.BoardMember{BoardID: testBoardID, UserID: testUserID, Role: role})
+}
+
+func TestHandleCreateComment(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(*m.MockStore)
+		expectedStatus int
+	}{
+		{
+			name: "successful creation",
+			setupMock: func(ms *m.MockStore) {
+				ms.On("CreateComment", mock.Anything, mock.MatchedBy(func(c *types.CreateComment) bool {
+					return c.BoardID == testBoardID && c.CardID == testCardID && c.UserID == testUserID
+				})).Return(&types.Comment{ID: testCommentID, CardID: testCardID}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "card on another board",
+			setupMock: func(ms *m.MockStore) {
+				ms.On("CreateComment", mock.Anything, mock.Anything).Return(nil, store.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(m.MockStore)
+			tt.setupMock(ms)
+			h := createTestHandler(ms, nil)
+			h.events = events.NewMemoryHub()
+
+			rr := httptest.NewRecorder()
+			h.handleCreateComment(rr, newCommentRequest(http.MethodPost, `{"content":"looks good"}`, types.RoleNormal))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			ms.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleListCardComments(t *testing.T) {
+	t.Run("card on another board", func(t *testing.T) {
+		ms := new(m.MockStore)
+		ms.On("GetCardBoardID", mock.Anything, testCardID).Return("another-board", nil)
+		h := createTestHandler(ms, nil)
+
+		rr := httptest.NewRecorder()
+		h.handleListCardComments(rr, newCommentRequest(http.MethodGet, "", types.RoleObserver))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		ms.AssertNotCalled(t, "ListCardComments", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("card on the board", func(t *testing.T) {
+		ms := new(m.MockStore)
+		ms.On("GetCardBoardID", mock.Anything, testCardID).Return(testBoardID, nil)
+		ms.On("ListCardComments", mock.Anything, testCardID, mock.Anything).Return([]*types.Comment{}, nil)
+		h := createTestHandler(ms, nil)
+
+		rr := httptest.NewRecorder()
+		h.handleListCardComments(rr, newCommentRequest(http.MethodGet, "", types.RoleObserver))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		ms.AssertExpectations(t)
+	})
+}
+
+func TestHandleUpdateComment(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(*m.MockStore)
+		expectedStatus int
+	}{
+		{
+			name: "card on another board",
+			setupMock: func(ms *m.MockStore) {
+				ms.On("GetCardBoardID", mock.Anything, testCardID).Return("another-board", nil)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "unknown comment",
+			setupMock: func(ms *m.MockStore) {
+				ms.On("GetCardBoardID", mock.Anything, testCardID).Return(testBoardID, nil)
+				ms.On("GetComment", mock.Anything, testCommentID).Return(nil, store.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "not the author",
+			setupMock: func(ms *m.MockStore) {
+				ms.On("GetCardBoardID", mock.Anything, testCardID).Return(testBoardID, nil)
+				ms.On("GetComment", mock.Anything, testCommentID).Return(&types.Comment{ID: testCommentID, CardID: testCardID, UserID: "someone-else"}, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "comment on another card",
+			setupMock: func(ms *m.MockStore) {
+				ms.On("GetCardBoardID", mock.Anything, testCardID).Return(testBoardID, nil)
+				ms.On("GetComment", mock.Anything, testCommentID).Return(&types.Comment{ID: testCommentID, CardID: "another-card", UserID: testUserID}, nil)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(m.MockStore)
+			tt.setupMock(ms)
+			h := createTestHandler(ms, nil)
+
+			rr := httptest.NewRecorder()
+			h.handleUpdateComment(rr, newCommentRequest(http.MethodPut, `{"content":"edited"}`, types.RoleNormal))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			ms.AssertNotCalled(t, "UpdateComment", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestHandleDeleteComment(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           string
+		setupMock      func(*m.MockStore)
+		expectedStatus int
+	}{
+		{
+			name: "card on another board",
+			role: types.RoleAdmin,
+			setupMock: func(ms *m.MockStore) {
+				ms.On("GetCardBoardID", mock.Anything, testCardID).Return("another-board", nil)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "comment on another card",
+			role: types.RoleAdmin,
+			setupMock: func(ms *m.MockStore) {
+				ms.On("GetCardBoardID", mock.Anything, testCardID).Return(testBoardID, nil)
+				ms.On("GetComment", mock.Anything, testCommentID).Return(&types.Comment{ID: testCommentID, CardID: "another-card", UserID: testUserID}, nil)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "someone else's comment as a normal member",
+			role: types.RoleNormal,
+			setupMock: func(ms *m.MockStore) {
+				ms.On("GetCardBoardID", mock.Anything, testCardID).Return(testBoardID, nil)
+				ms.On("GetComment", mock.Anything, testCommentID).Return(&types.Comment{ID: testCommentID, CardID: testCardID, UserID: "someone-else"}, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "someone else's comment as an admin",
+			role: types.RoleAdmin,
+			setupMock: func(ms *m.MockStore) {
+				ms.On("GetCardBoardID", mock.Anything, testCardID).Return(testBoardID, nil)
+				ms.On("GetComment", mock.Anything, testCommentID).Return(&types.Comment{ID: testCommentID, CardID: testCardID, UserID: "someone-else"}, nil)
+				ms.On("DeleteComment", mock.Anything, testCommentID).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(m.MockStore)
+			tt.setupMock(ms)
+			h := createTestHandler(ms, nil)
+			h.events = events.NewMemoryHub()
+
+			rr := httptest.NewRecorder()
+			h.handleDeleteComment(rr, newCommentRequest(http.MethodDelete, "", tt.role))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			ms.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/events"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func (h *handler) handleAddCardDependency(w http.ResponseWriter, r *http.Request) {
+	var payload types.AddCardDependency
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.UnprocessableEntity(h.logger, w, "invalid request payload", nil)
+		return
+	}
+
+	payload.BoardID = r.PathValue("boardID")
+	payload.CardID = r.PathValue("cardID")
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "failed validation on the request payload", err)
+		return
+	}
+
+	if err := h.store.AddCardDependency(r.Context(), &payload); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			helper.NotFound(h.logger, w, "card not found on this board", nil)
+		case errors.Is(err, store.ErrDependencyCycle):
+			helper.Conflict(h.logger, w, "dependency would create a cycle", nil)
+		default:
+			helper.InternalServerError(h.logger, w, nil, err)
+		}
+		return
+	}
+
+	h.publish(r, events.CardDependencyAdded, map[string]any{"cardID": payload.CardID, "dependsOnID": payload.DependsOnID})
+
+	helper.Created(h.logger, w, "dependency added successfully", nil)
+}
+
+func (h *handler) handleRemoveCardDependency(w http.ResponseWriter, r *http.Request) {
+	payload := types.RemoveCardDependency{
+		BoardID:     r.PathValue("boardID"),
+		CardID:      r.PathValue("cardID"),
+		DependsOnID: r.PathValue("dependsOnID"),
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "failed validation on the request payload", err)
+		return
+	}
+
+	if err := h.store.RemoveCardDependency(r.Context(), &payload); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.NotFound(h.logger, w, "dependency not found", nil)
+			return
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	h.publish(r, events.CardDependencyRemoved, map[string]any{"cardID": payload.CardID, "dependsOnID": payload.DependsOnID})
+
+	helper.OK(h.logger, w, "dependency removed successfully", nil)
+}
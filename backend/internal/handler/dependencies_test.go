@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/events"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	m "github.com/vaidik-bajpai/Nexus/backend/internal/store/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+const testDependsOnID = "7081a2b3-c4d5-4e6f-8a7b-8c9d0e1f2a3b"
+
+func newDependencyRequest(method, body string) *http.Request {
+	req := httptest.NewRequest(method, "/", strings.NewReader(body))
+	req.SetPathValue("boardID", testBoardID)
+	req.SetPathValue("cardID", testCardID)
+	req.SetPathValue("dependsOnID", testDependsOnID)
+	return helper.SetUserInRequestContext(req, &types.User{ID: testUserID})
+}
+
+func TestHandleAddCardDependency(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		storeErr       error
+		expectedStatus int
+	}{
+		{"added", `{"dependsOnID":"` + testDependsOnID + `"}`, nil, http.StatusCreated},
+		{"cycle", `{"dependsOnID":"` + testDependsOnID + `"}`, store.ErrDependencyCycle, http.StatusConflict},
+		{"card on another board", `{"dependsOnID":"` + testDependsOnID + `"}`, store.ErrNotFound, http.StatusNotFound},
+		{"depends on itself", `{"dependsOnID":"` + testCardID + `"}`, nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(m.MockStore)
+			ms.On("AddCardDependency", mock.Anything, mock.MatchedBy(func(d *types.AddCardDependency) bool {
+				return d.BoardID == testBoardID && d.CardID == testCardID
+			})).Return(tt.storeErr).Maybe()
+			h := createTestHandler(ms, nil)
+			h.events = events.NewMemoryHub()
+
+			rr := httptest.NewRecorder()
+			h.handleAddCardDependency(rr, newDependencyRequest(http.MethodPost, tt.body))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
+
+func TestHandleRemoveCardDependency(t *testing.T) {
+	tests := []struct {
+		name           string
+		storeErr       error
+		expectedStatus int
+	}{
+		{"removed", nil, http.StatusOK},
+		{"no such dependency", store.ErrNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(m.MockStore)
+			ms.On("RemoveCardDependency", mock.Anything, &types.RemoveCardDependency{
+				BoardID:     testBoardID,
+				CardID:      testCardID,
+				DependsOnID: testDependsOnID,
+			}).Return(tt.storeErr)
+			h := createTestHandler(ms, nil)
+			h.events = events.NewMemoryHub()
+
+			rr := httptest.NewRecorder()
+			h.handleRemoveCardDependency(rr, newDependencyRequest(http.MethodDelete, ""))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			ms.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/events"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"go.uber.org/zap"
+)
+
+// eventsHeartbeat keeps idle event streams from being closed by proxies.
+const eventsHeartbeat = 25 * time.Second
+
+// handleBoardEvents streams the board's events to the client as
+// server-sent events until the client disconnects.
+func (h *handler) handleBoardEvents(w http.ResponseWriter, r *http.Request) {
+	boardID := r.PathValue("boardID")
+
+	stream, err := h.events.Subscribe(r.Context(), boardID)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		h.logger.Error("event stream does not support flushing", zap.Error(err))
+		return
+	}
+
+	heartbeat := time.NewTicker(eventsHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-stream:
+			if !ok {
+				return
+			}
+
+			payload, err := json.Marshal(event)
+			if err != nil {
+				h.logger.Error("failed to marshal board event", zap.Error(err))
+				continue
+			}
+
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, payload); err != nil {
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// publish notifies subscribers of the board in the request path. The
+// mutation has already been committed, so failures are only logged.
+func (h *handler) publish(r *http.Request, eventType string, data any) {
+	user := helper.GetUserFromRequestContext(r)
+	boardID := r.PathValue("boardID")
+
+	event, err := events.NewEvent(eventType, boardID, user.ID, data)
+	if err != nil {
+		h.logger.Error("failed to build board event", zap.String("type", eventType), zap.Error(err))
+		return
+	}
+
+	if err := h.events.Publish(r.Context(), event); err != nil {
+		h.logger.Error("failed to publish board event", zap.String("type", eventType), zap.String("boardID", boardID), zap.Error(err))
+	}
+}
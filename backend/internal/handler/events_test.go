@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/events"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	m "github.com/vaidik-bajpai/Nexus/backend/internal/store/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+// publishedEvent runs handle against a board subscribed on a fresh hub and
+// returns the event it published.
+func publishedEvent(t *testing.T, ms *m.MockStore, body string, handle func(*handler, http.ResponseWriter, *http.Request)) *events.Event {
+	t.Helper()
+
+	h := createTestHandler(ms, nil)
+	hub := events.NewMemoryHub()
+	h.events = hub
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := hub.Subscribe(ctx, testBoardID)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.SetPathValue("boardID", testBoardID)
+	req = helper.SetUserInRequestContext(req, &types.User{ID: testUserID})
+	rr := httptest.NewRecorder()
+
+	handle(h, rr, req)
+	require.Less(t, rr.Code, 300, rr.Body.String())
+
+	select {
+	case event := <-ch:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no event published")
+		return nil
+	}
+}
+
+func TestListCreatedEventCarriesStoredList(t *testing.T) {
+	const listID = "5e6f7081-92a3-4b4c-9d5e-6f708192a3b4"
+
+	ms := new(m.MockStore)
+	ms.On("CreateList", mock.Anything, mock.Anything).Return(&types.List{ID: listID, BoardID: testBoardID, Name: "Todo", Position: 1}, nil)
+
+	event := publishedEvent(t, ms, `{"name":"Todo","position":1}`, (*handler).handleCreateList)
+
+	assert.Equal(t, events.ListCreated, event.Type)
+	assert.JSONEq(t, `{"id":"`+listID+`","board_id":"`+testBoardID+`","name":"Todo","position":1}`, string(event.Data))
+}
+
+func TestLabelDeleteEvent(t *testing.T) {
+	const labelID = "6f708192-a3b4-4c5d-8e6f-708192a3b4c5"
+
+	ms := new(m.MockStore)
+	ms.On("DeleteLabel", mock.Anything, mock.Anything).Return(nil)
+
+	event := publishedEvent(t, ms, `{"id":"`+labelID+`","type":"delete"}`, func(h *handler, w http.ResponseWriter, r *http.Request) {
+		r.SetPathValue("boardID", testBoardID)
+		h.handleModifyLabel(w, r)
+	})
+
+	assert.Equal(t, events.LabelDeleted, event.Type)
+	assert.JSONEq(t, `{"labelID":"`+labelID+`"}`, string(event.Data))
+}
@@ -0,0 +1,443 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/trello"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+	"go.uber.org/zap"
+)
+
+const (
+	// exportBatchSize is how many cards are read and written at a time, so
+	// an export holds one batch in memory rather than the whole board.
+	exportBatchSize = 200
+
+	maxBoardImportSize = 32 << 20 // 32 MB
+)
+
+var boardExportCSVHeader = []string{
+	"id", "list", "title", "description", "labels", "members",
+	"due_date", "start_date", "completed", "archived", "position",
+	"checklist_items", "checklist_items_done", "comments", "attachments",
+	"votes", "created_at",
+}
+
+func (h *handler) handleExportBoard(w http.ResponseWriter, r *http.Request) {
+	boardID := r.PathValue("boardID")
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = types.ExportFormatJSON
+	}
+	if err := h.validator.Var(format, "oneof=json csv"); err != nil {
+		helper.BadRequest(h.logger, w, "format must be json or csv", nil)
+		return
+	}
+
+	export, err := h.store.GetBoardExport(r.Context(), boardID)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	filename := fmt.Sprintf("board-%s-%s.%s", boardID, time.Now().Format("20060102"), format)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	// the status is sent with the first bytes, so a failure past this point
+	// can only cut the download short
+	switch format {
+	case types.ExportFormatCSV:
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		err = h.writeBoardExportCSV(w, r, export)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		err = h.writeBoardExportJSON(w, r, export)
+	}
+	if err != nil {
+		h.logger.Error("board export interrupted", zap.String("boardID", boardID), zap.Error(err))
+	}
+}
+
+// writeBoardExportJSON writes the export document, streaming the cards in
+// batches after the rest of the board.
+func (h *handler) writeBoardExportJSON(w http.ResponseWriter, r *http.Request, export *types.BoardExport) error {
+	bw := bufio.NewWriter(w)
+
+	// keep in step with the json tags of types.BoardExport
+	fields := []struct {
+		key   string
+		value any
+	}{
+		{"version", export.Version},
+		{"exported_at", export.ExportedAt},
+		{"board", export.Board},
+		{"labels", export.Labels},
+		{"members", export.Members},
+		{"lists", export.Lists},
+	}
+
+	bw.WriteString("{")
+	for _, field := range fields {
+		raw, err := json.Marshal(field.value)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(bw, "%q:%s,", field.key, raw)
+	}
+	bw.WriteString(`"cards":[`)
+
+	first := true
+	err := h.eachExportCardBatch(r.Context(), export.Board.ID, func(cards []*types.ExportCard) error {
+		for _, card := range cards {
+			raw, err := json.Marshal(card)
+			if err != nil {
+				return err
+			}
+			if !first {
+				bw.WriteString(",")
+			}
+			first = false
+			bw.Write(raw)
+		}
+		return flushExport(w, bw)
+	})
+	if err != nil {
+		return err
+	}
+
+	bw.WriteString("]}\n")
+	return bw.Flush()
+}
+
+// writeBoardExportCSV writes one row per card. Lists, labels and members
+// are written by name; checklists, comments and attachments are counted.
+// Text written by users goes through csvText.
+func (h *handler) writeBoardExportCSV(w http.ResponseWriter, r *http.Request, export *types.BoardExport) error {
+	lists := make(map[string]string, len(export.Lists))
+	for _, list := range export.Lists {
+		lists[list.ID] = list.Name
+	}
+	labels := make(map[string]string, len(export.Labels))
+	for _, label := range export.Labels {
+		labels[label.ID] = label.Name
+	}
+	members := make(map[string]string, len(export.Members))
+	for _, member := range export.Members {
+		members[member.UserID] = member.Username
+		if member.Username == "" {
+			members[member.UserID] = member.Email
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	cw := csv.NewWriter(bw)
+	if err := cw.Write(boardExportCSVHeader); err != nil {
+		return err
+	}
+
+	err := h.eachExportCardBatch(r.Context(), export.Board.ID, func(cards []*types.ExportCard) error {
+		for _, card := range cards {
+			items, done := 0, 0
+			for _, checklist := range card.Checklists {
+				for _, item := range checklist.Items {
+					items++
+					if item.Completed {
+						done++
+					}
+				}
+			}
+
+			if err := cw.Write([]string{
+				card.ID,
+				csvText(lists[card.ListID]),
+				csvText(card.Title),
+				csvText(card.Description),
+				csvText(joinNames(card.LabelIDs, labels)),
+				csvText(joinNames(card.MemberIDs, members)),
+				formatExportTime(card.DueDate),
+				formatExportTime(card.StartDate),
+				strconv.FormatBool(card.Completed),
+				strconv.FormatBool(card.Archived),
+				strconv.FormatFloat(card.Position, 'f', -1, 64),
+				strconv.Itoa(items),
+				strconv.Itoa(done),
+				strconv.Itoa(len(card.Comments)),
+				strconv.Itoa(len(card.Attachments)),
+				strconv.Itoa(card.VoteCount),
+				card.CreatedAt.Format(time.RFC3339),
+			}); err != nil {
+				return err
+			}
+		}
+
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+		return flushExport(w, bw)
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// eachExportCardBatch calls fn with the board's cards, exportBatchSize at
+// a time.
+func (h *handler) eachExportCardBatch(ctx context.Context, boardID string, fn func([]*types.ExportCard) error) error {
+	after := ""
+	for {
+		cards, err := h.store.ListBoardExportCards(ctx, boardID, after, exportBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(cards) == 0 {
+			return nil
+		}
+
+		if err := fn(cards); err != nil {
+			return err
+		}
+
+		if len(cards) < exportBatchSize {
+			return nil
+		}
+		after = cards[len(cards)-1].ID
+	}
+}
+
+// flushExport pushes what has been written so far to the client.
+func flushExport(w http.ResponseWriter, bw *bufio.Writer) error {
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	if err := http.NewResponseController(w).Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+func joinNames(ids []string, names map[string]string) string {
+	res := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if name, ok := names[id]; ok && name != "" {
+			res = append(res, name)
+		}
+	}
+	return strings.Join(res, "; ")
+}
+
+// csvText keeps user text from being read as a formula when the CSV is
+// opened in a spreadsheet: cells starting with a formula trigger are
+// prefixed with a quote, which spreadsheets show as text.
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func (h *handler) handleImportBoard(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+	if !h.requireVerifiedEmail(w, user, VerificationPolicyCreateBoard) {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBoardImportSize)
+
+	var export types.BoardExport
+	if err := helper.ReadJSON(r, &export); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			helper.BadRequest(h.logger, w, "board export is too large to import", nil)
+			return
+		}
+		if errors.Is(err, io.EOF) {
+			helper.UnprocessableEntity(h.logger, w, "empty request payload", nil)
+			return
+		}
+		helper.UnprocessableEntity(h.logger, w, "invalid board export", nil)
+		return
+	}
+
+	// only the importer is carried over from a Nexus export; other members
+	// may not want to be added to the copy
+	members, invitations, _, err := importMembers(user, export.Members, false)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	payload := &types.ImportBoard{
+		OwnerID:     user.ID,
+		Export:      &export,
+		Members:     members,
+		Invitations: invitations,
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "failed validation on the board export", err)
+		return
+	}
+
+	result, err := h.store.ImportBoard(r.Context(), payload)
+	if err != nil {
+		if errors.Is(err, store.ErrInvalidImport) {
+			helper.BadRequest(h.logger, w, strings.TrimPrefix(err.Error(), "store: "), nil)
+			return
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.Created(h.logger, w, "board imported successfully", result)
+}
+
+// handleImportTrelloBoard imports a Trello board export. The importer keeps
+// their own cards and comments, the other members are invited by email, and
+// the response reports what happened to each kind of record.
+func (h *handler) handleImportTrelloBoard(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+	if !h.requireVerifiedEmail(w, user, VerificationPolicyCreateBoard) {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBoardImportSize)
+
+	board, err := trello.Parse(r.Body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			helper.BadRequest(h.logger, w, "trello export is too large to import", nil)
+			return
+		}
+		helper.UnprocessableEntity(h.logger, w, "invalid trello board export", nil)
+		return
+	}
+
+	export, report := trello.Convert(board)
+
+	members, invitations, memberReport, err := importMembers(user, export.Members, true)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+	report.Members = *memberReport
+
+	payload := &types.ImportBoard{
+		OwnerID:     user.ID,
+		Export:      export,
+		Members:     members,
+		Invitations: invitations,
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "the trello board cannot be imported", err)
+		return
+	}
+
+	result, err := h.store.ImportBoard(r.Context(), payload)
+	if err != nil {
+		if errors.Is(err, store.ErrInvalidImport) {
+			helper.BadRequest(h.logger, w, strings.TrimPrefix(err.Error(), "store: "), nil)
+			return
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+	report.BoardID = result.BoardID
+
+	// the board exists now; a failed email leaves the invitation pending
+	// for an admin to resend
+	r = helper.SetBoardInRequestContext(r, &types.Board{ID: result.BoardID, Name: export.Board.Name})
+	for _, invitation := range invitations {
+		if err := h.sendBoardInvitation(r, invitation); err != nil {
+			h.logger.Error("failed to send imported board invitation", zap.String("boardID", result.BoardID), zap.Error(err))
+			report.Members.Warnings = append(report.Members.Warnings,
+				fmt.Sprintf("the invitation email to %s could not be sent; resend it from the board", invitation.Email))
+		}
+	}
+
+	helper.Created(h.logger, w, "trello board imported successfully", report)
+}
+
+// importMembers decides how exported members are carried over. The member
+// with the importer's email is the importer and is the only one mapped. With
+// inviteOthers, every other member with an email is invited to the new
+// board, whether or not they have an account, so nobody is added to a board
+// they did not agree to join. Members without an email and repeats of an
+// email already invited are skipped.
+func importMembers(user *types.User, members []*types.ExportMember, inviteOthers bool) (map[string]string, []*types.BoardInvitation, *types.ImportMemberReport, error) {
+	mapped := make(map[string]string, len(members))
+	report := &types.ImportMemberReport{}
+	var invitations []*types.BoardInvitation
+	invited := make(map[string]bool)
+
+	for _, member := range members {
+		email := strings.ToLower(strings.TrimSpace(member.Email))
+		switch {
+		case email != "" && email == strings.ToLower(user.Email):
+			mapped[member.UserID] = user.ID
+			report.Matched++
+			continue
+		case !inviteOthers:
+			report.Skipped++
+			continue
+		case email == "":
+			report.Skipped++
+			report.Warnings = append(report.Warnings, fmt.Sprintf("member %q has no email in the export", member.Username))
+			continue
+		case invited[email]:
+			report.Skipped++
+			report.Warnings = append(report.Warnings, fmt.Sprintf("member %q shares the email %s with another member, who was invited instead", member.Username, email))
+			continue
+		}
+		invited[email] = true
+
+		token, err := helper.GenerateInvitationToken()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		role := types.NormalizeRole(member.Role)
+		if !types.RoleCan(role, types.PermissionView) {
+			role = types.RoleNormal
+		}
+
+		invitations = append(invitations, &types.BoardInvitation{
+			Email:     email,
+			InvitedBy: user.ID,
+			Token:     token,
+			Role:      role,
+			ExpiredAt: time.Now().Add(boardInvitationTTL),
+		})
+		report.Invited++
+	}
+
+	return mapped, invitations, report, nil
+}
@@ -0,0 +1,358 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	mailerMock "github.com/vaidik-bajpai/Nexus/backend/internal/mailer/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	m "github.com/vaidik-bajpai/Nexus/backend/internal/store/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func TestHandleExportBoard(t *testing.T) {
+	const boardID = "b1"
+
+	export := &types.BoardExport{
+		Version: types.BoardExportVersion,
+		Board:   &types.ExportBoard{ID: boardID, Name: "Roadmap"},
+		Labels:  []*types.ExportLabel{{ID: "l1", Name: "bug", Color: "#ff0000"}},
+		Members: []*types.ExportMember{{UserID: "u1", Username: "ada", Email: "ada@example.com", Role: types.RoleAdmin}},
+		Lists:   []*types.ExportList{{ID: "list1", Name: "Todo"}},
+	}
+
+	// one full batch and a short one, so the export has to page
+	first := make([]*types.ExportCard, exportBatchSize)
+	for i := range first {
+		first[i] = &types.ExportCard{ID: fmt.Sprintf("c%03d", i), ListID: "list1", Title: "card"}
+	}
+	last := first[len(first)-1].ID
+	second := []*types.ExportCard{{
+		ID:        "z1",
+		ListID:    "list1",
+		Title:     "last card",
+		LabelIDs:  []string{"l1"},
+		MemberIDs: []string{"u1"},
+		Checklists: []*types.ExportChecklist{{
+			Name:  "steps",
+			Items: []*types.ExportChecklistItem{{Text: "a", Completed: true}, {Text: "b"}},
+		}},
+	}}
+
+	setup := func() *m.MockStore {
+		ms := new(m.MockStore)
+		ms.On("GetBoardExport", mock.Anything, boardID).Return(export, nil)
+		ms.On("ListBoardExportCards", mock.Anything, boardID, "", exportBatchSize).Return(first, nil)
+		ms.On("ListBoardExportCards", mock.Anything, boardID, last, exportBatchSize).Return(second, nil)
+		return ms
+	}
+
+	t.Run("json", func(t *testing.T) {
+		ms := setup()
+		h := createTestHandler(ms, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/?format=json", nil)
+		req.SetPathValue("boardID", boardID)
+		rr := httptest.NewRecorder()
+
+		h.handleExportBoard(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var got types.BoardExport
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &got))
+		assert.Equal(t, types.BoardExportVersion, got.Version)
+		assert.Equal(t, "Roadmap", got.Board.Name)
+		assert.Len(t, got.Lists, 1)
+		assert.Len(t, got.Cards, exportBatchSize+1)
+		assert.Equal(t, "z1", got.Cards[exportBatchSize].ID)
+		ms.AssertExpectations(t)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		ms := setup()
+		h := createTestHandler(ms, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/?format=csv", nil)
+		req.SetPathValue("boardID", boardID)
+		rr := httptest.NewRecorder()
+
+		h.handleExportBoard(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		rows, err := csv.NewReader(rr.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, exportBatchSize+2)
+		assert.Equal(t, boardExportCSVHeader, rows[0])
+
+		row := rows[len(rows)-1]
+		assert.Equal(t, []string{"z1", "Todo", "last card", "", "bug", "ada"}, row[:6])
+		assert.Equal(t, "2", row[11])
+		assert.Equal(t, "1", row[12])
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		h := createTestHandler(new(m.MockStore), nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/?format=xml", nil)
+		req.SetPathValue("boardID", boardID)
+		rr := httptest.NewRecorder()
+
+		h.handleExportBoard(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestHandleExportBoardCSVFormulas(t *testing.T) {
+	const boardID = "b1"
+
+	ms := new(m.MockStore)
+	ms.On("GetBoardExport", mock.Anything, boardID).Return(&types.BoardExport{
+		Version: types.BoardExportVersion,
+		Board:   &types.ExportBoard{ID: boardID, Name: "Roadmap"},
+		Labels:  []*types.ExportLabel{{ID: "l1", Name: "@ops"}},
+		Lists:   []*types.ExportList{{ID: "list1", Name: "=cmd|' /C calc'!A0"}},
+	}, nil)
+	ms.On("ListBoardExportCards", mock.Anything, boardID, "", exportBatchSize).Return([]*types.ExportCard{
+		{ID: "c1", ListID: "list1", Title: `=HYPERLINK("http://evil.example","x")`, Description: "+1+1", LabelIDs: []string{"l1"}, Position: -1},
+		{ID: "c2", ListID: "list1", Title: "-2", Description: "\tindented"},
+		{ID: "c3", ListID: "list1", Title: "plain title", Description: "a = b"},
+	}, nil)
+	h := createTestHandler(ms, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/?format=csv", nil)
+	req.SetPathValue("boardID", boardID)
+	rr := httptest.NewRecorder()
+
+	h.handleExportBoard(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	rows, err := csv.NewReader(rr.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+
+	assert.Equal(t, []string{"c1", "'=cmd|' /C calc'!A0", `'=HYPERLINK("http://evil.example","x")`, "'+1+1", "'@ops"}, rows[1][:5])
+	assert.Equal(t, "-1", rows[1][10], "numeric cells are not quoted")
+	assert.Equal(t, []string{"'-2", "'\tindented"}, rows[2][2:4])
+	assert.Equal(t, []string{"plain title", "a = b"}, rows[3][2:4])
+}
+
+func TestHandleImportBoard(t *testing.T) {
+	const exportedOwnerID = "exported-owner"
+
+	validExport := func(version int) string {
+		export := &types.BoardExport{
+			Version: version,
+			Board:   &types.ExportBoard{ID: "b1", Name: "Roadmap"},
+			Members: []*types.ExportMember{
+				{UserID: exportedOwnerID, Email: "Importer@Example.com", Role: types.RoleAdmin},
+				{UserID: "someone", Email: "someone@example.com", Role: types.RoleNormal},
+			},
+			Lists: []*types.ExportList{{ID: "list1", Name: "Todo"}},
+			Cards: []*types.ExportCard{{ID: "c1", ListID: "list1", Title: "card"}},
+		}
+		body, err := json.Marshal(export)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*m.MockStore)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name: "successful import",
+			body: validExport(types.BoardExportVersion),
+			setupMock: func(ms *m.MockStore) {
+				ms.On("ImportBoard", mock.Anything, mock.MatchedBy(func(in *types.ImportBoard) bool {
+					return in.OwnerID == testUserID &&
+						len(in.Members) == 1 && in.Members[exportedOwnerID] == testUserID &&
+						len(in.Invitations) == 0
+				})).Return(&types.BoardImportResult{BoardID: "new-board", Lists: 1, Cards: 1, SkippedMembers: 1}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "newer version",
+			body: validExport(types.BoardExportVersion + 1),
+			setupMock: func(ms *m.MockStore) {
+				ms.On("ImportBoard", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: version 2 is newer than this server supports", store.ErrInvalidImport))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "invalid board export: version 2 is newer than this server supports",
+		},
+		{
+			name: "duplicate ids",
+			body: validExport(types.BoardExportVersion),
+			setupMock: func(ms *m.MockStore) {
+				ms.On("ImportBoard", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: duplicate card id %q", store.ErrInvalidImport, "c1"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    `duplicate card id \"c1\"`,
+		},
+		{
+			name:           "missing version",
+			body:           validExport(0),
+			setupMock:      func(*m.MockStore) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "not json",
+			body:           "board,export",
+			setupMock:      func(*m.MockStore) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(m.MockStore)
+			tt.setupMock(ms)
+			h := createTestHandler(ms, nil)
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			req = helper.SetUserInRequestContext(req, &types.User{ID: testUserID, Email: "importer@example.com", EmailVerified: true})
+			rr := httptest.NewRecorder()
+
+			h.handleImportBoard(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code, rr.Body.String())
+			if tt.expectedMsg != "" {
+				assert.Contains(t, rr.Body.String(), tt.expectedMsg)
+			}
+			ms.AssertExpectations(t)
+		})
+	}
+}
+
+func TestImportMembers(t *testing.T) {
+	importer := &types.User{ID: testUserID, Email: "ada@example.com"}
+	members := []*types.ExportMember{
+		{UserID: "m1", Username: "ada", Email: " ADA@example.com ", Role: types.RoleAdmin},
+		{UserID: "m2", Username: "carol", Email: "carol@example.com", Role: types.RoleObserver},
+		{UserID: "m3", Username: "carol-alt", Email: "Carol@Example.com", Role: types.RoleNormal},
+		{UserID: "m4", Username: "bob"},
+		{UserID: "m5", Username: "dan", Email: "dan@example.com", Role: "guest"},
+	}
+
+	t.Run("invites everyone but the importer", func(t *testing.T) {
+		mapped, invitations, report, err := importMembers(importer, members, true)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]string{"m1": testUserID}, mapped)
+
+		require.Len(t, invitations, 2)
+		assert.Equal(t, "carol@example.com", invitations[0].Email)
+		assert.Equal(t, types.RoleObserver, invitations[0].Role)
+		assert.Equal(t, "dan@example.com", invitations[1].Email)
+		assert.Equal(t, types.RoleNormal, invitations[1].Role, "unknown roles are invited as normal members")
+		for _, invitation := range invitations {
+			assert.Equal(t, testUserID, invitation.InvitedBy)
+			assert.NotEmpty(t, invitation.Token)
+		}
+
+		assert.Equal(t, 1, report.Matched)
+		assert.Equal(t, 2, report.Invited)
+		assert.Equal(t, 2, report.Skipped, "the member without an email and the repeated email")
+		assert.Equal(t, len(members), report.Matched+report.Invited+report.Skipped)
+		assert.Len(t, report.Warnings, 2)
+	})
+
+	t.Run("nexus export keeps only the importer", func(t *testing.T) {
+		mapped, invitations, report, err := importMembers(importer, members, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]string{"m1": testUserID}, mapped)
+		assert.Empty(t, invitations)
+		assert.Equal(t, 1, report.Matched)
+		assert.Equal(t, 4, report.Skipped)
+	})
+}
+
+func TestHandleImportTrelloBoard(t *testing.T) {
+	fixture, err := os.ReadFile("../trello/testdata/board.json")
+	require.NoError(t, err)
+
+	importer := &types.User{ID: testUserID, Username: "ada", Email: "ada@example.com", EmailVerified: true}
+
+	tests := []struct {
+		name     string
+		mailErr  error
+		warnings int
+	}{
+		{name: "invitations sent"},
+		{name: "invitation email fails", mailErr: errors.New("smtp down"), warnings: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(m.MockStore)
+			mm := new(mailerMock.MockMailer)
+
+			// carol has an account but is still only invited
+			ms.On("ImportBoard", mock.Anything, mock.MatchedBy(func(in *types.ImportBoard) bool {
+				return in.OwnerID == testUserID &&
+					len(in.Members) == 1 && in.Members["mem1"] == testUserID &&
+					len(in.Invitations) == 1 && in.Invitations[0].Email == "carol@example.com" &&
+					in.Invitations[0].Role == types.RoleObserver
+			})).Return(&types.BoardImportResult{BoardID: "new-board"}, nil)
+			ms.On("GetUserByID", mock.Anything, testUserID).Return(importer, nil)
+			ms.On("GetUserByEmail", mock.Anything, "carol@example.com").Return(&types.User{ID: "carol", Email: "carol@example.com"}, nil)
+			mm.On("SendBoardInvitationEmail", []string{"carol@example.com"}, mock.Anything, "ada", mock.Anything, mock.MatchedBy(func(link string) bool {
+				return strings.HasPrefix(link, "http://localhost:3000/join?boardID=new-board&token=")
+			})).Return(tt.mailErr)
+
+			h := createTestHandler(ms, mm)
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(fixture))
+			req = helper.SetUserInRequestContext(req, importer)
+			rr := httptest.NewRecorder()
+
+			h.handleImportTrelloBoard(rr, req)
+
+			require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+			var resp struct {
+				Data types.ImportReport `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			assert.Equal(t, "new-board", resp.Data.BoardID)
+			assert.Equal(t, 1, resp.Data.Members.Matched)
+			assert.Equal(t, 1, resp.Data.Members.Invited)
+			assert.Equal(t, 1, resp.Data.Members.Skipped, "bob has no email")
+			assert.Len(t, resp.Data.Members.Warnings, 1+tt.warnings)
+			ms.AssertExpectations(t)
+			mm.AssertExpectations(t)
+		})
+	}
+
+	t.Run("not a trello export", func(t *testing.T) {
+		ms := new(m.MockStore)
+		h := createTestHandler(ms, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("not json"))
+		req = helper.SetUserInRequestContext(req, importer)
+		rr := httptest.NewRecorder()
+
+		h.handleImportTrelloBoard(rr, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		ms.AssertNotCalled(t, "ImportBoard", mock.Anything, mock.Anything)
+	})
+}
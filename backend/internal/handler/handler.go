@@ -1,31 +1,43 @@
 package handler
 
 import (
-	"net/http"
-	"os"
-	"path/filepath"
-	"strings"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 	"github.com/go-playground/validator/v10"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/automation"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/events"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/mailer"
 	m "github.com/vaidik-bajpai/Nexus/backend/internal/middleware"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/oauth"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/ratelimit"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/storage"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
 	"go.uber.org/zap"
-	"golang.org/x/oauth2"
-	"golang.org/x/oauth2/google"
 )
 
 type handler struct {
-	logger     *zap.Logger
-	validator  *validator.Validate
-	store      store.Storer
-	mailer     mailer.Mailer
-	oauth2     map[string]*oauth2.Config
-	middleware *m.Middleware
+	logger      *zap.Logger
+	validator   *validator.Validate
+	store       store.Storer
+	mailer      mailer.Mailer
+	providers   *oauth.Registry
+	middleware  *m.Middleware
+	blob        storage.Blob
+	publicURL   string
+	events      events.Hub
+	automations *automation.Engine
+
+	verificationPolicy string
+	// totpKey encrypts TOTP secrets at rest
+	totpKey string
+
+	limiter    *ratelimit.Limiter
+	rateLimits map[string]ratelimit.Policy
 }
 
 func NewHandler(store *store.Store) *handler {
@@ -35,24 +47,89 @@ func NewHandler(store *store.Store) *handler {
 		panic(err)
 	}
 
-	oauth2Configs := make(map[string]*oauth2.Config)
-	redirectURL := helper.GetStrEnvOrPanic("GOOGLE_REDIRECT_URL")
+	blob, err := storage.New(storage.Config{
+		Driver:   helper.GetStrEnvOrDefault("BLOB_STORAGE", "local"),
+		LocalDir: helper.GetStrEnvOrDefault("BLOB_LOCAL_DIR", "./uploads"),
+		S3: storage.S3Config{
+			Endpoint:  helper.GetStrEnvOrDefault("S3_ENDPOINT", ""),
+			Region:    helper.GetStrEnvOrDefault("S3_REGION", "us-east-1"),
+			Bucket:    helper.GetStrEnvOrDefault("S3_BUCKET", ""),
+			AccessKey: helper.GetStrEnvOrDefault("S3_ACCESS_KEY", ""),
+			SecretKey: helper.GetStrEnvOrDefault("S3_SECRET_KEY", ""),
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	publicURL := helper.GetStrEnvOrDefault("PUBLIC_URL", "http://localhost:8080")
+
+	oauthConfigs, err := oauth.LoadConfig(publicURL)
+	if err != nil {
+		panic(err)
+	}
+
+	providers, err := oauth.NewRegistry(oauthConfigs, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	redisDB, err := strconv.Atoi(helper.GetStrEnvOrDefault("REDIS_DB", "0"))
+	if err != nil {
+		panic(err)
+	}
+
+	hub, err := events.New(events.Config{
+		Driver:        helper.GetStrEnvOrDefault("EVENTS_HUB", "memory"),
+		RedisAddr:     helper.GetStrEnvOrDefault("REDIS_ADDR", "localhost:6379"),
+		RedisPassword: helper.GetStrEnvOrDefault("REDIS_PASSWORD", ""),
+		RedisDB:       redisDB,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	verificationPolicy := helper.GetStrEnvOrDefault("EMAIL_VERIFICATION_POLICY", VerificationPolicyOff)
+	if err := v.Var(verificationPolicy, "oneof=off login create_board"); err != nil {
+		panic("invalid EMAIL_VERIFICATION_POLICY: " + verificationPolicy)
+	}
+
+	limiter, err := ratelimit.New(ratelimit.Config{
+		Driver:        helper.GetStrEnvOrDefault("RATE_LIMIT_BACKEND", "memory"),
+		RedisAddr:     helper.GetStrEnvOrDefault("REDIS_ADDR", "localhost:6379"),
+		RedisPassword: helper.GetStrEnvOrDefault("REDIS_PASSWORD", ""),
+		RedisDB:       redisDB,
+		Lockout:       ratelimit.DefaultLockout,
+	})
+	if err != nil {
+		panic(err)
+	}
 
-	oauth2Configs["google"] = &oauth2.Config{
-		ClientID:     helper.GetStrEnvOrPanic("GOOGLE_CLIENT_ID"),
-		ClientSecret: helper.GetStrEnvOrPanic("GOOGLE_CLIENT_SECRET"),
-		RedirectURL:  redirectURL,
-		Scopes:       []string{"email", "profile"},
-		Endpoint:     google.Endpoint,
+	rateLimits, err := loadRateLimits()
+	if err != nil {
+		panic(err)
 	}
 
+	// TOTP secrets fall back to the token secret so existing deployments
+	// keep working; set a dedicated key to rotate the two independently
+	totpKey := helper.GetStrEnvOrDefault("TOTP_ENCRYPTION_KEY", helper.GetStrEnvOrPanic("ACCESS_TOKEN_SECRET"))
+
 	return &handler{
-		logger:     l,
-		validator:  v,
-		store:      store,
-		mailer:     mailer.NewSMTPMailer(),
-		oauth2:     oauth2Configs,
-		middleware: m.NewMiddleware(store, l, v),
+		logger:      l,
+		validator:   v,
+		store:       store,
+		mailer:      mailer.NewSMTPMailer(),
+		providers:   providers,
+		middleware:  m.NewMiddleware(store, l, v, limiter),
+		blob:        blob,
+		publicURL:   publicURL,
+		events:      hub,
+		automations: automation.NewEngine(store, hub, l),
+
+		verificationPolicy: verificationPolicy,
+		totpKey:            totpKey,
+		limiter:            limiter,
+		rateLimits:         rateLimits,
 	}
 }
 
@@ -72,77 +149,271 @@ func (h *handler) SetupRoutes() *chi.Mux {
 
 	r.Route("/api/v1/", func(r chi.Router) {
 		r.Route("/users", func(r chi.Router) {
-			r.Post("/register", h.handleUserRegistration)
-			r.Post("/login", h.handleUserLogin)
+			r.With(h.middleware.RateLimit(h.rateLimits[RateLimitRegister])).Post("/register", h.handleUserRegistration)
+			r.With(h.middleware.RateLimit(h.rateLimits[RateLimitLogin])).Post("/login", h.handleUserLogin)
+			r.With(h.middleware.RateLimit(h.rateLimits[RateLimitTwoFactor])).Post("/login/2fa", h.handleTwoFactorLogin)
+			r.Get("/oauth/providers", h.handleListOAuthProviders)
 			r.Get("/{provider}", h.handleUserOAuthFlow)
 			r.Get("/{provider}/callback", h.handleUserOAuthCallback)
-			r.With(h.middleware.VerifyAccessToken).Post("/logout", h.handleUserLogout)
-			r.Post("/reset-password", h.handlePasswordResetFlow)
-			r.Post("/password/reset", h.handlePasswordReset)
+			r.With(h.middleware.RateLimit(h.rateLimits[RateLimitPasswordReset])).Post("/reset-password", h.handlePasswordResetFlow)
+			r.With(h.middleware.RateLimit(h.rateLimits[RateLimitPasswordReset])).Post("/password/reset", h.handlePasswordReset)
 			r.Post("/refresh-token", h.handleRefreshToken)
+			r.With(h.middleware.RateLimit(h.rateLimits[RateLimitVerification])).Post("/verify-email", h.handleVerifyEmail)
+			r.With(h.middleware.RateLimit(h.rateLimits[RateLimitVerification])).Post("/verify-email/resend", h.handleResendEmailVerification)
 			r.With(h.middleware.VerifyAccessToken).Get("/me", h.handleGetMe)
+
+			// account and security routes take a signed-in session; no api
+			// token scope grants them
+			r.Group(func(r chi.Router) {
+				r.Use(h.middleware.VerifyAccessToken, h.middleware.RequireSession)
+				r.Post("/logout", h.handleUserLogout)
+
+				r.Route("/me/sessions", func(r chi.Router) {
+					r.Get("/list", h.handleListSessions)
+					r.Post("/revoke-all", h.handleRevokeAllSessions)
+					r.Delete("/{sessionID}/revoke", h.handleRevokeSession)
+				})
+
+				r.Route("/me/reauthenticate", func(r chi.Router) {
+					r.Use(h.middleware.RateLimit(h.rateLimits[RateLimitLogin]))
+					r.Post("/", h.handleReauthenticate)
+					r.Post("/{provider}", h.handleReauthenticateWithProvider)
+				})
+
+				r.Route("/me/accounts", func(r chi.Router) {
+					r.Get("/list", h.handleListLoginMethods)
+					r.Post("/confirm-link", h.handleConfirmAccountLink)
+					r.Post("/{provider}/link", h.handleLinkAccount)
+					r.Delete("/{accountID}/unlink", h.handleUnlinkAccount)
+				})
+
+				r.Route("/me/2fa", func(r chi.Router) {
+					r.Get("/", h.handleGetTwoFactorStatus)
+					r.Post("/enroll", h.handleEnrollTwoFactor)
+					r.Post("/confirm", h.handleConfirmTwoFactor)
+					r.Post("/recovery-codes/regenerate", h.handleRegenerateRecoveryCodes)
+					r.Post("/disable", h.handleDisableTwoFactor)
+				})
+
+				r.Route("/me/notifications", func(r chi.Router) {
+					r.With(h.middleware.Paginate).Get("/list", h.handleListNotifications)
+					r.Get("/unread-count", h.handleCountUnreadNotifications)
+					r.Post("/read-all", h.handleMarkAllNotificationsRead)
+					r.Post("/{notificationID}/read", h.handleMarkNotificationRead)
+				})
+
+				r.Route("/me/tokens", func(r chi.Router) {
+					r.Post("/create", h.handleCreateAPIToken)
+					r.Get("/list", h.handleListAPITokens)
+					r.Delete("/{tokenID}/revoke", h.handleRevokeAPIToken)
+				})
+			})
+		})
+
+		r.Route("/templates", func(r chi.Router) {
+			r.Use(h.middleware.VerifyAccessToken, h.middleware.RequireScope(types.ScopeTemplatesRead, types.ScopeTemplatesWrite))
+			r.With(h.middleware.Paginate).Get("/list", h.handleListBoardTemplates)
+			r.Route("/{templateID}", func(r chi.Router) {
+				r.Get("/detail", h.handleGetBoardTemplate)
+				r.Post("/create-board", h.handleCreateBoardFromTemplate)
+				r.Delete("/delete", h.handleDeleteBoardTemplate)
+			})
+		})
+
+		r.Route("/workspaces", func(r chi.Router) {
+			r.Use(h.middleware.VerifyAccessToken, h.middleware.RequireScope(types.ScopeBoardsRead, types.ScopeBoardsWrite))
+			r.Post("/create", h.handleCreateWorkspace)
+			r.Get("/list", h.handleListWorkspaces)
+
+			r.Route("/{workspaceID}", func(r chi.Router) {
+				r.Post("/accept-invite", h.handleAcceptWorkspaceInvite)
+
+				r.Group(func(r chi.Router) {
+					r.Use(h.middleware.IsWorkspaceMember)
+					r.Get("/details", h.handleGetWorkspace)
+					r.With(h.middleware.Paginate).Get("/boards", h.handleListWorkspaceBoards)
+					r.Post("/leave", h.handleLeaveWorkspace)
+				})
+
+				r.Group(func(r chi.Router) {
+					r.Use(h.middleware.IsWorkspaceAdmin)
+					r.Put("/update", h.handleUpdateWorkspace)
+					r.Delete("/delete", h.handleDeleteWorkspace)
+					r.Post("/invite", h.handleInviteToWorkspace)
+					r.Route("/members/{userID}", func(r chi.Router) {
+						r.Put("/role", h.handleUpdateWorkspaceMemberRole)
+						r.Delete("/remove", h.handleRemoveWorkspaceMember)
+					})
+				})
+			})
+		})
+
+		// public boards and boards shared by token are readable without an
+		// account; member emails are never served here
+		r.Route("/shared/boards/{boardID}", func(r chi.Router) {
+			r.Use(h.middleware.RateLimit(h.rateLimits[RateLimitSharedBoard]), h.middleware.IsSharedBoard)
+			r.Get("/details", h.handleGetSharedBoardDetails)
+			// the lists and cards payload only carries member ids
+			r.Get("/cards-and-lists", h.handleGetCardsAndLists)
 		})
 
 		r.Route("/boards", func(r chi.Router) {
 			r.Use(h.middleware.VerifyAccessToken)
-			r.Post("/create", h.handleCreateBoard)
 
-			r.With(h.middleware.Paginate).Get("/list", h.handleListBoards)
+			// board structure takes the boards scopes; cards further down
+			// take the cards scopes
+			boardsScope := h.middleware.RequireScope(types.ScopeBoardsRead, types.ScopeBoardsWrite)
+
+			// IsMember admits observers, so every route that changes board
+			// content also takes canEdit; IsAdmin covers members and settings
+			canEdit := h.middleware.CanEdit
+
+			r.With(boardsScope).Post("/create", h.handleCreateBoard)
+
+			r.With(boardsScope, h.middleware.Paginate).Get("/list", h.handleListBoards)
+
+			r.With(boardsScope).Post("/import", h.handleImportBoard)
+			r.With(boardsScope).Post("/import/trello", h.handleImportTrelloBoard)
 
 			r.Route("/{boardID}", func(r chi.Router) {
-				r.Post("/accept-invite", h.handleAcceptInviteToBoard)
+				r.With(boardsScope).Post("/accept-invite", h.handleAcceptInviteToBoard)
+				r.With(boardsScope).Post("/decline-invite", h.handleDeclineInviteToBoard)
+				r.With(boardsScope).Post("/join", h.handleJoinBoardWithLink)
 
 				r.Group(func(r chi.Router) {
-					r.Use(h.middleware.IsMember)
+					r.Use(boardsScope, h.middleware.IsMember)
 					r.Get("/cards-and-lists", h.handleGetCardsAndLists)
 					r.Get("/details", h.handleGetBoardDetails)
+					r.With(h.middleware.Paginate).Get("/activity", h.handleListBoardActivity)
+					r.Get("/events", h.handleBoardEvents)
+					r.With(canEdit).Post("/save-as-template", h.handleSaveBoardAsTemplate)
+					r.Get("/voting", h.handleGetVotingSettings)
+					r.Post("/leave", h.handleLeaveBoard)
+					r.Put("/star", h.handleStarBoard)
 				})
 
 				r.Group(func(r chi.Router) {
-					r.Use(h.middleware.IsAdmin)
+					r.Use(boardsScope, h.middleware.IsAdmin)
 					r.Post("/invite", h.handleInviteToBoard)
 					r.Put("/update", h.handleUpdateBoard)
 					r.Delete("/delete", h.handleDeleteBoard)
+					r.Put("/voting", h.handleUpdateVotingSettings)
+					r.Post("/move", h.handleMoveBoard)
+					r.Get("/export", h.handleExportBoard)
+					r.Route("/members/{userID}", func(r chi.Router) {
+						r.Put("/role", h.handleUpdateBoardMemberRole)
+						r.Delete("/remove", h.handleRemoveBoardMember)
+					})
+					r.Route("/invitations", func(r chi.Router) {
+						r.Get("/list", h.handleListBoardInvitations)
+						r.Post("/{invitationID}/resend", h.handleResendBoardInvitation)
+						r.Delete("/{invitationID}/revoke", h.handleRevokeBoardInvitation)
+					})
+					r.Route("/join-links", func(r chi.Router) {
+						r.Post("/create", h.handleCreateJoinLink)
+						r.Get("/list", h.handleListJoinLinks)
+						r.Delete("/{linkID}/revoke", h.handleRevokeJoinLink)
+					})
+					r.Route("/share-links", func(r chi.Router) {
+						r.Post("/create", h.handleCreateShareToken)
+						r.Get("/list", h.handleListShareTokens)
+						r.Delete("/{shareID}/revoke", h.handleRevokeShareToken)
+					})
+				})
+
+				r.Route("/automations", func(r chi.Router) {
+					r.Use(boardsScope, h.middleware.IsAdmin)
+					r.Post("/create", h.handleCreateAutomation)
+					r.Get("/list", h.handleListAutomations)
+					r.Route("/{automationID}", func(r chi.Router) {
+						r.Put("/update", h.handleUpdateAutomation)
+						r.Post("/toggle", h.handleToggleAutomation)
+						r.Delete("/delete", h.handleDeleteAutomation)
+						r.With(h.middleware.Paginate).Get("/runs", h.handleListAutomationRuns)
+					})
+				})
+
+				r.Route("/views", func(r chi.Router) {
+					r.Use(boardsScope, h.middleware.IsMember)
+					r.With(canEdit).Post("/create", h.handleCreateView)
+					r.Get("/list", h.handleListViews)
+					r.Route("/{viewID}", func(r chi.Router) {
+						r.With(canEdit).Put("/update", h.handleUpdateView)
+						r.With(canEdit).Delete("/delete", h.handleDeleteView)
+						r.Get("/query", h.handleQueryView)
+					})
 				})
 
 				r.Route("/labels", func(r chi.Router) {
-					r.Use(h.middleware.IsMember)
-					r.Post("/create", h.handleCreateLabel)
-					r.Post("/modify", h.handleModifyLabel)
+					r.Use(boardsScope, h.middleware.IsMember)
+					r.With(canEdit).Post("/create", h.handleCreateLabel)
+					r.With(canEdit).Post("/modify", h.handleModifyLabel)
 					r.Get("/list", h.handleListBoardLabels)
 				})
 
 				r.Route("/lists", func(r chi.Router) {
 					r.Use(h.middleware.IsMember)
-					r.Post("/create", h.handleCreateList)
+					r.With(boardsScope, canEdit).Post("/create", h.handleCreateList)
 					r.Route("/{listID}", func(r chi.Router) {
-						r.Put("/update", h.handleUpdateList)
-						r.Delete("/delete", h.handleDeleteList)
+						r.With(boardsScope, canEdit).Put("/update", h.handleUpdateList)
+						r.With(boardsScope, canEdit).Delete("/delete", h.handleDeleteList)
 
 						r.Route("/cards", func(r chi.Router) {
-							r.Post("/create", h.handleCreateCard)
+							r.Use(h.middleware.RequireScope(types.ScopeCardsRead, types.ScopeCardsWrite))
+							r.With(canEdit).Post("/create", h.handleCreateCard)
 							r.Route("/{cardID}", func(r chi.Router) {
-								r.Put("/update", h.handleUpdateCard)
+								r.With(canEdit).Put("/update", h.handleUpdateCard)
 								r.Get("/detail", h.handleGetCardDetail)
-								r.Delete("/delete", h.handleDeleteCard)
-								r.Post("/toggle-member", h.handleToggleCardMembership)
+								r.With(canEdit).Delete("/delete", h.handleDeleteCard)
+								r.With(canEdit).Post("/toggle-member", h.handleToggleCardMembership)
+								r.With(h.middleware.Paginate).Get("/activity", h.handleListCardActivity)
+
+								// voting is governed by the board's voting settings,
+								// which decide whether observers may vote
+								r.Route("/votes", func(r chi.Router) {
+									r.Post("/toggle", h.handleToggleCardVote)
+									r.Get("/list", h.handleListCardVoters)
+								})
+
+								r.Route("/dependencies", func(r chi.Router) {
+									r.With(canEdit).Post("/add", h.handleAddCardDependency)
+									r.With(canEdit).Delete("/{dependsOnID}/remove", h.handleRemoveCardDependency)
+								})
+
+								r.Route("/comments", func(r chi.Router) {
+									r.With(canEdit).Post("/create", h.handleCreateComment)
+									r.With(h.middleware.Paginate).Get("/list", h.handleListCardComments)
+									r.Route("/{commentID}", func(r chi.Router) {
+										r.With(canEdit).Put("/update", h.handleUpdateComment)
+										r.With(canEdit).Delete("/delete", h.handleDeleteComment)
+									})
+								})
+
+								r.Route("/attachments", func(r chi.Router) {
+									r.With(canEdit).Post("/create", h.handleCreateAttachment)
+									r.Get("/list", h.handleListCardAttachments)
+									r.Route("/{attachmentID}", func(r chi.Router) {
+										r.Get("/download", h.handleDownloadAttachment)
+										r.With(canEdit).Delete("/delete", h.handleDeleteAttachment)
+									})
+								})
 
 								r.Route("/labels", func(r chi.Router) {
-									r.Post("/toggle", h.handleToggleLabelToCard)
+									r.With(canEdit).Post("/toggle", h.handleToggleLabelToCard)
 									r.Post("/list", h.handleListCardLabels)
 								})
 
 								r.Route("/checklists", func(r chi.Router) {
-									r.Post("/create", h.handleAddChecklistToCard)
+									r.With(canEdit).Post("/create", h.handleAddChecklistToCard)
 									r.Route("/{checklistID}", func(r chi.Router) {
 										r.Get("/detail", h.handleGetChecklist)
-										r.Delete("/delete", h.handleDeleteChecklist)
+										r.With(canEdit).Delete("/delete", h.handleDeleteChecklist)
 
 										r.Route("/items", func(r chi.Router) {
-											r.Post("/create", h.handleAddChecklistItem)
+											r.With(canEdit).Post("/create", h.handleAddChecklistItem)
 											r.Route("/{itemID}", func(r chi.Router) {
-												r.Delete("/delete", h.handleDeleteChecklistItem)
-												r.Put("/update", h.handleUpdateChecklistItem)
+												r.With(canEdit).Delete("/delete", h.handleDeleteChecklistItem)
+												r.With(canEdit).Put("/update", h.handleUpdateChecklistItem)
 											})
 										})
 									})
@@ -156,30 +427,10 @@ func (h *handler) SetupRoutes() *chi.Mux {
 
 	})
 
-	workDir, _ := os.Getwd()
-	filesDir := http.Dir(filepath.Join(workDir, "uploads"))
-	FileServer(r, "/uploads", filesDir)
-
 	r.Post("/api/v1/upload", h.handleUpload)
+	r.Get("/api/v1/uploads/*", h.handleServeUpload)
+	// covers uploaded before blob storage were saved with URLs under /uploads
+	r.Get("/uploads/*", h.handleServeUpload)
 
 	return r
 }
-
-func FileServer(r chi.Router, path string, root http.FileSystem) {
-	if strings.ContainsAny(path, "{}*") {
-		panic("FileServer does not permit any URL parameters.")
-	}
-
-	if path != "/" && path[len(path)-1] != '/' {
-		r.Get(path, http.RedirectHandler(path+"/", 301).ServeHTTP)
-		path += "/"
-	}
-	path += "*"
-
-	r.Get(path, func(w http.ResponseWriter, r *http.Request) {
-		rctx := chi.RouteContext(r.Context())
-		pathPrefix := strings.TrimSuffix(rctx.RoutePattern(), "/*")
-		fs := http.StripPrefix(pathPrefix, http.FileServer(root))
-		fs.ServeHTTP(w, r)
-	})
-}
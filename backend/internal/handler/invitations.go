@@ -0,0 +1,280 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/events"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+// boardInvitationTTL matches the expiry promised in the invitation email.
+const boardInvitationTTL = 7 * 24 * time.Hour
+
+func (h *handler) handleDeclineInviteToBoard(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+	token, ok := h.readBoardInvitationToken(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.store.DeclineBoardInvitation(r.Context(), token, user.Email); err != nil {
+		h.writeInvitationError(w, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "invitation declined successfully", nil)
+}
+
+func (h *handler) handleListBoardInvitations(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	if err := h.validator.Var(status, "omitempty,oneof=pending accepted declined revoked"); err != nil {
+		helper.BadRequest(h.logger, w, "invalid status filter", nil)
+		return
+	}
+
+	invitations, err := h.store.ListBoardInvitations(r.Context(), r.PathValue("boardID"), status)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "invitations fetched successfully", map[string]any{"invitations": invitations})
+}
+
+func (h *handler) handleResendBoardInvitation(w http.ResponseWriter, r *http.Request) {
+	invitationID := r.PathValue("invitationID")
+	if err := h.validator.Var(invitationID, "required,uuid"); err != nil {
+		helper.BadRequest(h.logger, w, "invalid invitation id", nil)
+		return
+	}
+
+	token, err := helper.GenerateInvitationToken()
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	invitation, err := h.store.ResendBoardInvitation(r.Context(), r.PathValue("boardID"), invitationID, token, time.Now().Add(boardInvitationTTL))
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.NotFound(h.logger, w, "no pending invitation with this id", nil)
+			return
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	if err := h.sendBoardInvitation(r, invitation); err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "invitation resent successfully", invitation)
+}
+
+func (h *handler) handleRevokeBoardInvitation(w http.ResponseWriter, r *http.Request) {
+	invitationID := r.PathValue("invitationID")
+	if err := h.validator.Var(invitationID, "required,uuid"); err != nil {
+		helper.BadRequest(h.logger, w, "invalid invitation id", nil)
+		return
+	}
+
+	if err := h.store.RevokeBoardInvitation(r.Context(), r.PathValue("boardID"), invitationID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.NotFound(h.logger, w, "no pending invitation with this id", nil)
+			return
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "invitation revoked successfully", nil)
+}
+
+func (h *handler) handleCreateJoinLink(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+
+	var payload types.CreateJoinLink
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.UnprocessableEntity(h.logger, w, "invalid request payload", nil)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "validation on request payload", nil)
+		return
+	}
+
+	token, err := helper.GenerateInvitationToken()
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	payload.BoardID = r.PathValue("boardID")
+	payload.CreatedBy = user.ID
+	payload.Token = token
+
+	link, err := h.store.CreateJoinLink(r.Context(), &payload)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.Created(h.logger, w, "join link created successfully", map[string]any{
+		"link": link,
+		"url":  joinLinkURL(payload.BoardID, link.Token),
+	})
+}
+
+func (h *handler) handleListJoinLinks(w http.ResponseWriter, r *http.Request) {
+	links, err := h.store.ListJoinLinks(r.Context(), r.PathValue("boardID"))
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "join links fetched successfully", map[string]any{"links": links})
+}
+
+func (h *handler) handleRevokeJoinLink(w http.ResponseWriter, r *http.Request) {
+	linkID := r.PathValue("linkID")
+	if err := h.validator.Var(linkID, "required,uuid"); err != nil {
+		helper.BadRequest(h.logger, w, "invalid join link id", nil)
+		return
+	}
+
+	if err := h.store.RevokeJoinLink(r.Context(), r.PathValue("boardID"), linkID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.NotFound(h.logger, w, "no active join link with this id", nil)
+			return
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "join link revoked successfully", nil)
+}
+
+func (h *handler) handleJoinBoardWithLink(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+	boardID := r.PathValue("boardID")
+	if err := h.validator.Var(boardID, "required,uuid"); err != nil {
+		helper.BadRequest(h.logger, w, "invalid boardID", nil)
+		return
+	}
+
+	var payload types.JoinBoard
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.UnprocessableEntity(h.logger, w, "invalid request payload", nil)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "validation on request payload", nil)
+		return
+	}
+
+	link, err := h.store.JoinBoardWithLink(r.Context(), boardID, payload.Token, user.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			helper.NotFound(h.logger, w, "join link not found", nil)
+		case errors.Is(err, store.ErrTokenExpired):
+			helper.BadRequest(h.logger, w, "join link expired", nil)
+		case errors.Is(err, store.ErrJoinLinkExhausted):
+			helper.Forbidden(h.logger, w, "join link has reached its maximum number of uses", nil)
+		case errors.Is(err, store.ErrAlreadyMember):
+			helper.Conflict(h.logger, w, "you are already a member of this board", nil)
+		default:
+			helper.InternalServerError(h.logger, w, nil, err)
+		}
+		return
+	}
+
+	h.publish(r, events.MemberJoined, map[string]any{"userID": user.ID, "role": link.Role})
+
+	helper.OK(h.logger, w, "joined the board successfully", nil)
+}
+
+// readBoardInvitationToken reads the invitation token from the query and
+// makes sure it belongs to the board in the path.
+func (h *handler) readBoardInvitationToken(w http.ResponseWriter, r *http.Request) (string, bool) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		helper.BadRequest(h.logger, w, "invalid request token", nil)
+		return "", false
+	}
+
+	invitation, err := h.store.GetBoardInvitationByToken(r.Context(), token)
+	if err != nil {
+		h.writeInvitationError(w, err)
+		return "", false
+	}
+
+	if invitation.BoardID != r.PathValue("boardID") {
+		helper.NotFound(h.logger, w, "invitation not found", nil)
+		return "", false
+	}
+
+	return token, true
+}
+
+func (h *handler) writeInvitationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		helper.NotFound(h.logger, w, "invitation not found", nil)
+	case errors.Is(err, store.ErrInvitationClosed):
+		helper.Conflict(h.logger, w, "invitation is no longer pending", nil)
+	case errors.Is(err, store.ErrTokenExpired):
+		helper.BadRequest(h.logger, w, "invitation expired", nil)
+	case errors.Is(err, store.ErrInvitationEmailMismatch):
+		helper.Forbidden(h.logger, w, "this invitation was sent to another email", nil)
+	case errors.Is(err, store.ErrAlreadyMember):
+		helper.Conflict(h.logger, w, "you are already a member of this board", nil)
+	default:
+		helper.InternalServerError(h.logger, w, nil, err)
+	}
+}
+
+// sendBoardInvitation mails the invitation link in the name of whoever sent
+// the invitation. Emails without an account get a signup link instead; the
+// invitation is accepted for them once they verify the address.
+func (h *handler) sendBoardInvitation(r *http.Request, invitation *types.BoardInvitation) error {
+	board := helper.GetBoardFromRequestContext(r)
+
+	inviter, err := h.store.GetUserByID(r.Context(), invitation.InvitedBy)
+	if err != nil {
+		return err
+	}
+	inviterName := inviter.Username
+	if inviterName == "" {
+		inviterName = inviter.Email
+	}
+
+	link := fmt.Sprintf("http://localhost:3000/join?boardID=%s&token=%s", invitation.BoardID, url.QueryEscape(invitation.Token))
+	if _, err := h.store.GetUserByEmail(r.Context(), invitation.Email); err != nil {
+		if !errors.Is(err, store.ErrNotFound) {
+			return err
+		}
+		link = fmt.Sprintf("http://localhost:3000/signup?email=%s&boardID=%s&token=%s", url.QueryEscape(invitation.Email), invitation.BoardID, url.QueryEscape(invitation.Token))
+	}
+
+	return h.mailer.SendBoardInvitationEmail(
+		[]string{invitation.Email},
+		"Invitation to join nexus",
+		inviterName,
+		board.Name,
+		link,
+	)
+}
+
+func joinLinkURL(boardID, token string) string {
+	return fmt.Sprintf("http://localhost:3000/join-board?boardID=%s&token=%s", boardID, url.QueryEscape(token))
+}
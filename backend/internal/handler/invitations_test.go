@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/events"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	m "github.com/vaidik-bajpai/Nexus/backend/internal/store/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+const testInviteeEmail = "jane@example.com"
+
+func newInvitationRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.SetPathValue("boardID", testBoardID)
+	return helper.SetUserInRequestContext(req, &types.User{ID: testUserID, Email: testInviteeEmail})
+}
+
+func TestHandleAcceptInviteToBoard(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(*m.MockStore)
+		expectedStatus int
+	}{
+		{
+			name: "accepted",
+			setupMock: func(ms *m.MockStore) {
+				ms.On("GetBoardInvitationByToken", mock.Anything, "invite-token").Return(&types.BoardInvitation{BoardID: testBoardID}, nil)
+				ms.On("AcceptBoardInvitation", mock.Anything, "invite-token", testUserID, testInviteeEmail).Return(&types.BoardInvitation{BoardID: testBoardID, Role: types.RoleNormal}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "invitation for another board",
+			setupMock: func(ms *m.MockStore) {
+				ms.On("GetBoardInvitationByToken", mock.Anything, "invite-token").Return(&types.BoardInvitation{BoardID: "another-board"}, nil)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "expired",
+			setupMock: func(ms *m.MockStore) {
+				ms.On("GetBoardInvitationByToken", mock.Anything, "invite-token").Return(&types.BoardInvitation{BoardID: testBoardID}, nil)
+				ms.On("AcceptBoardInvitation", mock.Anything, "invite-token", testUserID, testInviteeEmail).Return(nil, store.ErrTokenExpired)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "sent to another email",
+			setupMock: func(ms *m.MockStore) {
+				ms.On("GetBoardInvitationByToken", mock.Anything, "invite-token").Return(&types.BoardInvitation{BoardID: testBoardID}, nil)
+				ms.On("AcceptBoardInvitation", mock.Anything, "invite-token", testUserID, testInviteeEmail).Return(nil, store.ErrInvitationEmailMismatch)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "already declined",
+			setupMock: func(ms *m.MockStore) {
+				ms.On("GetBoardInvitationByToken", mock.Anything, "invite-token").Return(&types.BoardInvitation{BoardID: testBoardID}, nil)
+				ms.On("AcceptBoardInvitation", mock.Anything, "invite-token", testUserID, testInviteeEmail).Return(nil, store.ErrInvitationClosed)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(m.MockStore)
+			tt.setupMock(ms)
+			h := createTestHandler(ms, nil)
+			h.events = events.NewMemoryHub()
+
+			rr := httptest.NewRecorder()
+			h.handleAcceptInviteToBoard(rr, newInvitationRequest(http.MethodPost, "/?token=invite-token", ""))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			ms.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleDeclineInviteToBoard(t *testing.T) {
+	tests := []struct {
+		name           string
+		declineErr     error
+		expectedStatus int
+	}{
+		{"declined", nil, http.StatusOK},
+		{"expired", store.ErrTokenExpired, http.StatusBadRequest},
+		{"sent to another email", store.ErrInvitationEmailMismatch, http.StatusForbidden},
+		{"no longer pending", store.ErrInvitationClosed, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(m.MockStore)
+			ms.On("GetBoardInvitationByToken", mock.Anything, "invite-token").Return(&types.BoardInvitation{BoardID: testBoardID}, nil)
+			ms.On("DeclineBoardInvitation", mock.Anything, "invite-token", testInviteeEmail).Return(tt.declineErr)
+			h := createTestHandler(ms, nil)
+
+			rr := httptest.NewRecorder()
+			h.handleDeclineInviteToBoard(rr, newInvitationRequest(http.MethodPost, "/?token=invite-token", ""))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			ms.AssertExpectations(t)
+		})
+	}
+
+	t.Run("missing token", func(t *testing.T) {
+		ms := new(m.MockStore)
+		h := createTestHandler(ms, nil)
+
+		rr := httptest.NewRecorder()
+		h.handleDeclineInviteToBoard(rr, newInvitationRequest(http.MethodPost, "/", ""))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		ms.AssertNotCalled(t, "DeclineBoardInvitation", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestHandleCreateJoinLink(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{"normal role", `{"role":"normal","max_uses":25}`, http.StatusCreated},
+		{"observer role", `{"role":"observer"}`, http.StatusCreated},
+		{"admin role", `{"role":"admin"}`, http.StatusBadRequest},
+		{"legacy member role", `{"role":"member"}`, http.StatusBadRequest},
+		{"missing role", `{}`, http.StatusBadRequest},
+		{"zero max uses", `{"role":"normal","max_uses":0}`, http.StatusBadRequest},
+		{"expiry in the past", `{"role":"normal","expires_at":"2000-01-01T00:00:00Z"}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(m.MockStore)
+			ms.On("CreateJoinLink", mock.Anything, mock.MatchedBy(func(l *types.CreateJoinLink) bool {
+				return l.BoardID == testBoardID && l.CreatedBy == testUserID && l.Token != ""
+			})).Return(&types.JoinLink{ID: "link", Token: "join-token"}, nil)
+			h := createTestHandler(ms, nil)
+
+			rr := httptest.NewRecorder()
+			h.handleCreateJoinLink(rr, newInvitationRequest(http.MethodPost, "/", tt.body))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus != http.StatusCreated {
+				ms.AssertNotCalled(t, "CreateJoinLink", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestHandleJoinBoardWithLink(t *testing.T) {
+	tests := []struct {
+		name           string
+		joinErr        error
+		expectedStatus int
+	}{
+		{"joined", nil, http.StatusOK},
+		{"unknown link", store.ErrNotFound, http.StatusNotFound},
+		{"expired", store.ErrTokenExpired, http.StatusBadRequest},
+		{"used up", store.ErrJoinLinkExhausted, http.StatusForbidden},
+		{"already a member", store.ErrAlreadyMember, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(m.MockStore)
+			if tt.joinErr == nil {
+				ms.On("JoinBoardWithLink", mock.Anything, testBoardID, "join-token", testUserID).Return(&types.JoinLink{Role: types.RoleNormal}, nil)
+			} else {
+				ms.On("JoinBoardWithLink", mock.Anything, testBoardID, "join-token", testUserID).Return(nil, tt.joinErr)
+			}
+			h := createTestHandler(ms, nil)
+			h.events = events.NewMemoryHub()
+
+			rr := httptest.NewRecorder()
+			h.handleJoinBoardWithLink(rr, newInvitationRequest(http.MethodPost, "/", `{"token":"join-token"}`))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			ms.AssertExpectations(t)
+		})
+	}
+}
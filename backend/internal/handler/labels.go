@@ -3,6 +3,8 @@ package handler
 import (
 	"net/http"
 
+	"github.com/vaidik-bajpai/Nexus/backend/internal/automation"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/events"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
 	"go.uber.org/zap"
@@ -31,6 +33,8 @@ func (h *handler) handleCreateLabel(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.publish(r, events.LabelCreated, newLabel)
+
 	helper.Created(h.logger, w, "label created successfully", newLabel)
 }
 
@@ -42,6 +46,8 @@ func (h *handler) handleModifyLabel(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	modifyLabel.BoardID = r.PathValue("boardID")
+
 	if err := h.validator.Struct(modifyLabel); err != nil {
 		helper.BadRequest(h.logger, w, "validation failed", err)
 		return
@@ -53,11 +59,15 @@ func (h *handler) handleModifyLabel(w http.ResponseWriter, r *http.Request) {
 			helper.InternalServerError(h.logger, w, "failed to update label", err)
 			return
 		}
+
+		h.publish(r, events.LabelUpdated, modifyLabel)
 	case "delete":
 		if err := h.store.DeleteLabel(r.Context(), modifyLabel); err != nil {
 			helper.InternalServerError(h.logger, w, "failed to delete label", err)
 			return
 		}
+
+		h.publish(r, events.LabelDeleted, map[string]any{"labelID": modifyLabel.ID})
 	default:
 		helper.BadRequest(h.logger, w, "invalid request body", nil)
 		return
@@ -90,6 +100,13 @@ func (h *handler) handleToggleLabelToCard(w http.ResponseWriter, r *http.Request
 			helper.InternalServerError(h.logger, w, "failed to add label to card", err)
 			return
 		}
+
+		h.fireAutomations(r, &automation.Event{
+			Trigger: types.TriggerLabelAdded,
+			BoardID: boardID,
+			CardID:  cardID,
+			LabelID: addLabelToCard.LabelID,
+		})
 	case "remove":
 		if err := h.store.RemoveLabelFromCard(r.Context(), addLabelToCard); err != nil {
 			helper.InternalServerError(h.logger, w, "failed to remove label from card", err)
@@ -100,6 +117,8 @@ func (h *handler) handleToggleLabelToCard(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	h.publish(r, events.CardLabelToggled, map[string]any{"listID": r.PathValue("listID"), "cardID": cardID, "labelID": addLabelToCard.LabelID, "type": addLabelToCard.Type})
+
 	helper.Created(h.logger, w, "label added to card successfully", nil)
 }
 
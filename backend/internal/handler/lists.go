@@ -3,6 +3,7 @@ package handler
 import (
 	"net/http"
 
+	"github.com/vaidik-bajpai/Nexus/backend/internal/events"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
 )
@@ -22,12 +23,15 @@ func (h *handler) handleCreateList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.store.CreateList(r.Context(), payload); err != nil {
+	list, err := h.store.CreateList(r.Context(), payload)
+	if err != nil {
 		helper.InternalServerError(h.logger, w, nil, err)
 		return
 	}
 
-	helper.Created(h.logger, w, "list created successfully", nil)
+	h.publish(r, events.ListCreated, list)
+
+	helper.Created(h.logger, w, "list created successfully", list)
 }
 
 func (h *handler) handleUpdateList(w http.ResponseWriter, r *http.Request) {
@@ -50,6 +54,8 @@ func (h *handler) handleUpdateList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.publish(r, events.ListUpdated, map[string]any{"listID": listID, "changes": payload})
+
 	helper.Created(h.logger, w, "list updated successfully", nil)
 }
 
@@ -65,5 +71,7 @@ func (h *handler) handleDeleteList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.publish(r, events.ListDeleted, map[string]any{"listID": listID})
+
 	helper.Created(h.logger, w, "list deleted successfully", nil)
 }
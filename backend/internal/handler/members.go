@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/events"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func (h *handler) handleUpdateBoardMemberRole(w http.ResponseWriter, r *http.Request) {
+	var payload types.UpdateBoardMemberRole
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.UnprocessableEntity(h.logger, w, "invalid request payload", nil)
+		return
+	}
+
+	payload.BoardID = r.PathValue("boardID")
+	payload.UserID = r.PathValue("userID")
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "validation on request payload", nil)
+		return
+	}
+
+	if err := h.store.UpdateBoardMemberRole(r.Context(), &payload); err != nil {
+		h.writeMemberError(w, err)
+		return
+	}
+
+	h.publish(r, events.MemberRoleChanged, map[string]any{"userID": payload.UserID, "role": payload.Role})
+
+	helper.OK(h.logger, w, "member role updated successfully", nil)
+}
+
+func (h *handler) handleRemoveBoardMember(w http.ResponseWriter, r *http.Request) {
+	boardID := r.PathValue("boardID")
+	userID := r.PathValue("userID")
+	if err := h.validator.Var(userID, "required,uuid"); err != nil {
+		helper.BadRequest(h.logger, w, "invalid user id", nil)
+		return
+	}
+
+	if err := h.store.RemoveBoardMember(r.Context(), boardID, userID, types.ActivityMemberRemoved); err != nil {
+		h.writeMemberError(w, err)
+		return
+	}
+
+	h.publish(r, events.MemberRemoved, map[string]any{"userID": userID})
+
+	helper.OK(h.logger, w, "member removed successfully", nil)
+}
+
+func (h *handler) handleLeaveBoard(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+	boardID := r.PathValue("boardID")
+
+	if err := h.store.RemoveBoardMember(r.Context(), boardID, user.ID, types.ActivityMemberLeft); err != nil {
+		h.writeMemberError(w, err)
+		return
+	}
+
+	h.publish(r, events.MemberLeft, map[string]any{"userID": user.ID})
+
+	helper.OK(h.logger, w, "left the board successfully", nil)
+}
+
+func (h *handler) writeMemberError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		helper.NotFound(h.logger, w, "member not found on this board", nil)
+	case errors.Is(err, store.ErrLastAdmin):
+		helper.Conflict(h.logger, w, "the board must keep at least one admin; promote another member first", nil)
+	default:
+		helper.InternalServerError(h.logger, w, nil, err)
+	}
+}
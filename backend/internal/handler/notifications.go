@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+	"go.uber.org/zap"
+)
+
+func (h *handler) handleListNotifications(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+	paginate := helper.GetPaginateFromRequestContext(r)
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+
+	notifications, err := h.store.ListNotifications(r.Context(), user.ID, unreadOnly, paginate)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "notifications fetched successfully", map[string]any{"notifications": notifications})
+}
+
+func (h *handler) handleCountUnreadNotifications(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+
+	count, err := h.store.CountUnreadNotifications(r.Context(), user.ID)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "unread notifications counted successfully", map[string]any{"count": count})
+}
+
+func (h *handler) handleMarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+	notificationID := r.PathValue("notificationID")
+	if err := h.validator.Var(notificationID, "required,uuid"); err != nil {
+		helper.BadRequest(h.logger, w, "invalid notification id", nil)
+		return
+	}
+
+	if err := h.store.MarkNotificationRead(r.Context(), user.ID, notificationID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.NotFound(h.logger, w, "notification not found", nil)
+			return
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "notification marked as read", nil)
+}
+
+func (h *handler) handleMarkAllNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+
+	if err := h.store.MarkAllNotificationsRead(r.Context(), user.ID); err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "notifications marked as read", nil)
+}
+
+// notifyMentions notifies the board members mentioned in text. Failures are
+// logged rather than returned so they never fail the request that caused them.
+func (h *handler) notifyMentions(ctx context.Context, actorID, boardID, cardID string, usernames []string) {
+	if len(usernames) == 0 {
+		return
+	}
+
+	userIDs, err := h.store.FindBoardMemberIDsByUsername(ctx, boardID, usernames)
+	if err != nil {
+		h.logger.Error("failed to resolve mentions", zap.Error(err))
+		return
+	}
+
+	var notifications []*types.CreateNotification
+	for _, userID := range userIDs {
+		if userID == actorID {
+			continue
+		}
+		notifications = append(notifications, &types.CreateNotification{
+			UserID:  userID,
+			Type:    types.NotificationMentioned,
+			BoardID: boardID,
+			CardID:  cardID,
+			ActorID: actorID,
+		})
+	}
+
+	if err := h.store.CreateNotifications(ctx, notifications); err != nil {
+		h.logger.Error("failed to create mention notifications", zap.Error(err))
+	}
+}
+
+// RunDueDateReminders notifies card members about cards due within window,
+// checking every interval until ctx is cancelled.
+func (h *handler) RunDueDateReminders(ctx context.Context, interval, window time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		count, err := h.store.CreateDueDateNotifications(ctx, window)
+		if err != nil {
+			h.logger.Error("failed to create due date notifications", zap.Error(err))
+		} else if count > 0 {
+			h.logger.Info("due date notifications created", zap.Int("count", count))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+// startSession opens a session for the user on the requesting device and
+// returns its access and refresh tokens. It sets user.SessionID.
+func (h *handler) startSession(r *http.Request, user *types.User) (string, string, error) {
+	refreshToken, refreshTokenHash, err := helper.GenerateRefreshToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	session := &types.CreateSession{
+		UserID:    user.ID,
+		TokenHash: refreshTokenHash,
+		UserAgent: r.UserAgent(),
+		IP:        helper.ClientIP(r),
+		ExpiresAt: time.Now().Add(helper.RefreshTokenTTL),
+	}
+	if err := h.store.CreateSession(r.Context(), session); err != nil {
+		return "", "", err
+	}
+
+	user.SessionID = session.ID
+
+	accessToken, err := helper.GenerateAccessToken(user)
+	if err != nil {
+		return "", "", err
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+func (h *handler) handleListSessions(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+
+	sessions, err := h.store.ListSessions(r.Context(), user.ID)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	for _, session := range sessions {
+		session.Current = session.ID == user.SessionID
+	}
+
+	helper.OK(h.logger, w, "sessions fetched successfully", map[string]any{"sessions": sessions})
+}
+
+func (h *handler) handleRevokeSession(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+
+	sessionID := r.PathValue("sessionID")
+	if err := h.validator.Var(sessionID, "required,uuid"); err != nil {
+		helper.BadRequest(h.logger, w, "invalid session id", nil)
+		return
+	}
+
+	if err := h.store.RevokeSession(r.Context(), user.ID, sessionID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.NotFound(h.logger, w, "session not found", nil)
+			return
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "session revoked successfully", nil)
+}
+
+// handleRevokeAllSessions logs the user out everywhere, including the
+// device making the request.
+func (h *handler) handleRevokeAllSessions(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+
+	if err := h.store.RevokeAllSessions(r.Context(), user.ID); err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "logged out of all sessions", nil)
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func (h *handler) handleCreateShareToken(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+
+	var payload types.CreateShareToken
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.UnprocessableEntity(h.logger, w, "invalid request payload", nil)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "validation on request payload", nil)
+		return
+	}
+
+	token, tokenHash, prefix, err := helper.GenerateShareToken()
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	payload.BoardID = r.PathValue("boardID")
+	payload.CreatedBy = user.ID
+	payload.TokenHash = tokenHash
+	payload.Prefix = prefix
+
+	share, err := h.store.CreateShareToken(r.Context(), &payload)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.Created(h.logger, w, "share link created, copy it now as it will not be shown again", map[string]any{
+		"token": token,
+		"share": share,
+		"url":   shareURL(payload.BoardID, token),
+	})
+}
+
+func (h *handler) handleListShareTokens(w http.ResponseWriter, r *http.Request) {
+	shares, err := h.store.ListShareTokens(r.Context(), r.PathValue("boardID"))
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "share links fetched successfully", map[string]any{"shares": shares})
+}
+
+func (h *handler) handleRevokeShareToken(w http.ResponseWriter, r *http.Request) {
+	shareID := r.PathValue("shareID")
+	if err := h.validator.Var(shareID, "required,uuid"); err != nil {
+		helper.BadRequest(h.logger, w, "invalid share link id", nil)
+		return
+	}
+
+	if err := h.store.RevokeShareToken(r.Context(), r.PathValue("boardID"), shareID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.NotFound(h.logger, w, "no active share link with this id", nil)
+			return
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "share link revoked successfully", nil)
+}
+
+// handleGetSharedBoardDetails serves the board details to readers without
+// an account, with member emails stripped.
+func (h *handler) handleGetSharedBoardDetails(w http.ResponseWriter, r *http.Request) {
+	board, err := h.store.GetBoard(r.Context(), r.PathValue("boardID"))
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	for _, member := range board.Members {
+		member.Email = ""
+	}
+
+	helper.OK(h.logger, w, "board fetched successfully", map[string]any{"board": board})
+}
+
+func shareURL(boardID, token string) string {
+	return fmt.Sprintf("http://localhost:3000/shared/boards/%s?token=%s", boardID, url.QueryEscape(token))
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	m "github.com/vaidik-bajpai/Nexus/backend/internal/store/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func TestHandleCreateShareToken(t *testing.T) {
+	var stored *types.CreateShareToken
+	ms := new(m.MockStore)
+	ms.On("CreateShareToken", mock.Anything, mock.MatchedBy(func(s *types.CreateShareToken) bool {
+		stored = s
+		return s.BoardID == testBoardID && s.CreatedBy == testUserID
+	})).Return(&types.ShareToken{ID: "share", Prefix: "abcdef"}, nil)
+	h := createTestHandler(ms, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+	req.SetPathValue("boardID", testBoardID)
+	req = helper.SetUserInRequestContext(req, &types.User{ID: testUserID})
+	rr := httptest.NewRecorder()
+
+	h.handleCreateShareToken(rr, req)
+
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	ms.AssertExpectations(t)
+
+	var res struct {
+		Data struct {
+			Token string           `json:"token"`
+			URL   string           `json:"url"`
+			Share types.ShareToken `json:"share"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+
+	token := res.Data.Token
+	require.NotEmpty(t, token)
+	assert.Equal(t, helper.HashToken(token), stored.TokenHash, "only the hash is stored")
+	assert.Equal(t, token[:6], stored.Prefix)
+	assert.Contains(t, res.Data.URL, "token="+token)
+}
+
+func TestHandleListShareTokens(t *testing.T) {
+	ms := new(m.MockStore)
+	ms.On("ListShareTokens", mock.Anything, testBoardID).Return([]*types.ShareToken{
+		{ID: "share", BoardID: testBoardID, Prefix: "abcdef", CreatedAt: time.Now()},
+	}, nil)
+	h := createTestHandler(ms, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.SetPathValue("boardID", testBoardID)
+	rr := httptest.NewRecorder()
+
+	h.handleListShareTokens(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"prefix":"abcdef"`)
+	assert.NotContains(t, rr.Body.String(), `"token"`)
+}
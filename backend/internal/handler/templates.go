@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func (h *handler) handleSaveBoardAsTemplate(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+	var payload types.CreateBoardTemplate
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.UnprocessableEntity(h.logger, w, "invalid request payload", nil)
+		return
+	}
+
+	payload.BoardID = r.PathValue("boardID")
+	payload.CreatedBy = user.ID
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "failed validation on the request payload", err)
+		return
+	}
+
+	// a public template lets anyone read the board's structure, so only
+	// someone who can manage the board may publish it
+	member := helper.GetBoardMemberFromRequestContext(r)
+	if payload.Public && !member.Can(types.PermissionManage) {
+		helper.Forbidden(h.logger, w, "only a board admin can publish a public template", nil)
+		return
+	}
+
+	template, err := h.store.CreateBoardTemplate(r.Context(), &payload)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.Created(h.logger, w, "template created successfully", template)
+}
+
+func (h *handler) handleListBoardTemplates(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+	paginate := helper.GetPaginateFromRequestContext(r)
+
+	templates, err := h.store.ListBoardTemplates(r.Context(), user.ID, paginate)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "templates fetched successfully", map[string]any{"templates": templates})
+}
+
+func (h *handler) handleGetBoardTemplate(w http.ResponseWriter, r *http.Request) {
+	template, ok := h.getVisibleTemplate(w, r)
+	if !ok {
+		return
+	}
+
+	helper.OK(h.logger, w, "template fetched successfully", template)
+}
+
+func (h *handler) handleCreateBoardFromTemplate(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+	if !h.requireVerifiedEmail(w, user, VerificationPolicyCreateBoard) {
+		return
+	}
+
+	template, ok := h.getVisibleTemplate(w, r)
+	if !ok {
+		return
+	}
+
+	var payload types.CreateBoardFromTemplate
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.UnprocessableEntity(h.logger, w, "invalid request payload", nil)
+		return
+	}
+
+	payload.TemplateID = template.ID
+	payload.OwnerID = user.ID
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "failed validation on the request payload", err)
+		return
+	}
+
+	boardID, err := h.store.CreateBoardFromTemplate(r.Context(), template, &payload)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.Created(h.logger, w, "board created successfully", map[string]string{"boardID": boardID})
+}
+
+func (h *handler) handleDeleteBoardTemplate(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+	template, ok := h.getVisibleTemplate(w, r)
+	if !ok {
+		return
+	}
+
+	if template.CreatedBy != user.ID {
+		helper.Forbidden(h.logger, w, "only the creator can delete this template", nil)
+		return
+	}
+
+	if err := h.store.DeleteBoardTemplate(r.Context(), template.ID); err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "template deleted successfully", nil)
+}
+
+// getVisibleTemplate loads the template in the request path if it is public
+// or belongs to the caller. It writes the error response itself.
+func (h *handler) getVisibleTemplate(w http.ResponseWriter, r *http.Request) (*types.BoardTemplate, bool) {
+	user := helper.GetUserFromRequestContext(r)
+	templateID := r.PathValue("templateID")
+	if err := h.validator.Var(templateID, "required,uuid"); err != nil {
+		helper.BadRequest(h.logger, w, "invalid template id", nil)
+		return nil, false
+	}
+
+	template, err := h.store.GetBoardTemplate(r.Context(), templateID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.NotFound(h.logger, w, "template not found", nil)
+			return nil, false
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return nil, false
+	}
+
+	if !template.Public && template.CreatedBy != user.ID {
+		helper.NotFound(h.logger, w, "template not found", nil)
+		return nil, false
+	}
+
+	return template, true
+}
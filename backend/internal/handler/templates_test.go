@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	m "github.com/vaidik-bajpai/Nexus/backend/internal/store/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+const testTemplateID = "8192a3b4-c5d6-4f70-8a1b-2c3d4e5f6a7b"
+
+func TestHandleSaveBoardAsTemplate(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           string
+		body           string
+		expectedStatus int
+	}{
+		{"private template as a normal member", types.RoleNormal, `{"name":"Sprint"}`, http.StatusCreated},
+		{"public template as a normal member", types.RoleNormal, `{"name":"Sprint","public":true}`, http.StatusForbidden},
+		{"public template as an admin", types.RoleAdmin, `{"name":"Sprint","public":true}`, http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(m.MockStore)
+			ms.On("CreateBoardTemplate", mock.Anything, mock.Anything).Return(&types.BoardTemplate{ID: testTemplateID}, nil).Maybe()
+			h := createTestHandler(ms, nil)
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			req.SetPathValue("boardID", testBoardID)
+			req = helper.SetUserInRequestContext(req, &types.User{ID: testUserID})
+			req = helper.SetBoardMemberInRequestContext(req, &types.BoardMember{BoardID: testBoardID, UserID: testUserID, Role: tt.role})
+			rr := httptest.NewRecorder()
+
+			h.handleSaveBoardAsTemplate(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus != http.StatusCreated {
+				ms.AssertNotCalled(t, "CreateBoardTemplate", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestHandleGetBoardTemplate(t *testing.T) {
+	tests := []struct {
+		name           string
+		template       *types.BoardTemplate
+		err            error
+		expectedStatus int
+	}{
+		{"own private template", &types.BoardTemplate{ID: testTemplateID, CreatedBy: testUserID}, nil, http.StatusOK},
+		{"someone else's public template", &types.BoardTemplate{ID: testTemplateID, CreatedBy: "someone-else", Public: true}, nil, http.StatusOK},
+		{"someone else's private template", &types.BoardTemplate{ID: testTemplateID, CreatedBy: "someone-else"}, nil, http.StatusNotFound},
+		{"unknown template", nil, store.ErrNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(m.MockStore)
+			if tt.template != nil {
+				ms.On("GetBoardTemplate", mock.Anything, testTemplateID).Return(tt.template, nil)
+			} else {
+				ms.On("GetBoardTemplate", mock.Anything, testTemplateID).Return(nil, tt.err)
+			}
+			h := createTestHandler(ms, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.SetPathValue("templateID", testTemplateID)
+			req = helper.SetUserInRequestContext(req, &types.User{ID: testUserID})
+			rr := httptest.NewRecorder()
+
+			h.handleGetBoardTemplate(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
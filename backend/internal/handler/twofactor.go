@@ -0,0 +1,322 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+	"go.uber.org/zap"
+)
+
+const (
+	// twoFactorChallengeTTL is how long a user has to enter their code
+	// after the password step.
+	twoFactorChallengeTTL = 5 * time.Minute
+
+	totpIssuer = "Nexus"
+)
+
+// writeTwoFactorChallenge answers the password step of a login for a user
+// with two-factor authentication on. The challenge token is exchanged for
+// a session at /users/login/2fa.
+func (h *handler) writeTwoFactorChallenge(w http.ResponseWriter, user *types.User) {
+	challengeToken, err := helper.GenerateTwoFactorChallenge(user.ID, twoFactorChallengeTTL)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "two-factor authentication required", map[string]any{
+		"twoFactorRequired": true,
+		"challengeToken":    challengeToken,
+	})
+}
+
+func (h *handler) handleTwoFactorLogin(w http.ResponseWriter, r *http.Request) {
+	var payload types.TwoFactorLogin
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.BadRequest(h.logger, w, "failed to read request body", nil)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.UnprocessableEntity(h.logger, w, "failed to validate request body", nil)
+		return
+	}
+
+	userID, err := helper.VerifyTwoFactorChallenge(payload.ChallengeToken)
+	if err != nil {
+		helper.SendErrorResponse(h.logger, w, http.StatusUnauthorized, "invalid or expired challenge, log in again", nil, nil)
+		return
+	}
+
+	user, err := h.store.GetUserByID(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.SendErrorResponse(h.logger, w, http.StatusUnauthorized, "invalid or expired challenge, log in again", nil, nil)
+			return
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	if h.accountLocked(w, r, user.Email) {
+		return
+	}
+
+	ok, err := h.checkSecondFactor(r.Context(), user.ID, &payload.TwoFactorCode)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+	if !ok {
+		if h.recordFailedLogin(w, r, user.Email) {
+			return
+		}
+		helper.SendErrorResponse(h.logger, w, http.StatusUnauthorized, "invalid two-factor code", nil, nil)
+		return
+	}
+
+	h.completeLogin(w, r, user)
+}
+
+// checkSecondFactor accepts a TOTP code that was not used before or
+// consumes an unused recovery code. Users without two-factor
+// authentication enabled never pass.
+func (h *handler) checkSecondFactor(ctx context.Context, userID string, code *types.TwoFactorCode) (bool, error) {
+	twoFactor, err := h.store.GetTwoFactor(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if !twoFactor.Enabled() {
+		return false, nil
+	}
+
+	if code.RecoveryCode != "" {
+		return h.store.UseRecoveryCode(ctx, userID, helper.HashRecoveryCode(code.RecoveryCode))
+	}
+
+	secret, err := helper.DecryptSecret(h.totpKey, twoFactor.Secret)
+	if err != nil {
+		return false, err
+	}
+
+	step, ok := helper.ValidateTOTP(secret, code.Code, time.Now())
+	if !ok {
+		return false, nil
+	}
+	return h.store.ClaimTOTPStep(ctx, userID, step)
+}
+
+func (h *handler) handleGetTwoFactorStatus(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+
+	twoFactor, err := h.store.GetTwoFactor(r.Context(), user.ID)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	remaining := 0
+	if twoFactor.Enabled() {
+		remaining, err = h.store.CountUnusedRecoveryCodes(r.Context(), user.ID)
+		if err != nil {
+			helper.InternalServerError(h.logger, w, nil, err)
+			return
+		}
+	}
+
+	helper.OK(h.logger, w, "two-factor status fetched successfully", map[string]any{
+		"enabled":                twoFactor.Enabled(),
+		"recoveryCodesRemaining": remaining,
+	})
+}
+
+// handleEnrollTwoFactor issues a new TOTP secret. It only takes effect once
+// confirmed with a code from the authenticator app.
+func (h *handler) handleEnrollTwoFactor(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+	if !h.requireRecentAuth(w, r, user) {
+		return
+	}
+
+	secret, err := helper.GenerateTOTPSecret()
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	encrypted, err := helper.EncryptSecret(h.totpKey, secret)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	if err := h.store.SetPendingTwoFactor(r.Context(), user.ID, encrypted); err != nil {
+		if errors.Is(err, store.ErrTwoFactorEnabled) {
+			helper.Conflict(h.logger, w, "two-factor authentication is already enabled", nil)
+			return
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "scan the code with your authenticator app and confirm", &types.TwoFactorEnrollment{
+		Secret: secret,
+		URI:    helper.TOTPURI(totpIssuer, user.Email, secret),
+	})
+}
+
+// handleConfirmTwoFactor turns on the enrolled secret after checking a
+// first code and returns the recovery codes. They are shown only here.
+func (h *handler) handleConfirmTwoFactor(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+
+	var payload types.ConfirmTwoFactor
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.BadRequest(h.logger, w, "failed to read request body", nil)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.UnprocessableEntity(h.logger, w, "failed to validate request body", nil)
+		return
+	}
+
+	twoFactor, err := h.store.GetTwoFactor(r.Context(), user.ID)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	if twoFactor.Enabled() {
+		helper.Conflict(h.logger, w, "two-factor authentication is already enabled", nil)
+		return
+	}
+	if twoFactor.Secret == "" {
+		helper.BadRequest(h.logger, w, "start two-factor enrollment first", nil)
+		return
+	}
+
+	secret, err := helper.DecryptSecret(h.totpKey, twoFactor.Secret)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	step, ok := helper.ValidateTOTP(secret, payload.Code, time.Now())
+	if !ok {
+		helper.BadRequest(h.logger, w, "invalid two-factor code", nil)
+		return
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	if err := h.store.EnableTwoFactor(r.Context(), &types.EnableTwoFactor{
+		UserID:             user.ID,
+		Step:               step,
+		RecoveryCodeHashes: hashes,
+	}); err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	h.logger.Info("two-factor authentication enabled", zap.String("userID", user.ID))
+
+	helper.OK(h.logger, w, "two-factor authentication enabled", map[string]any{"recoveryCodes": codes})
+}
+
+func (h *handler) handleRegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+
+	if _, ok := h.readSecondFactor(w, r, user); !ok {
+		return
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	if err := h.store.ReplaceRecoveryCodes(r.Context(), user.ID, hashes); err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "recovery codes regenerated", map[string]any{"recoveryCodes": codes})
+}
+
+func (h *handler) handleDisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+
+	if _, ok := h.readSecondFactor(w, r, user); !ok {
+		return
+	}
+
+	if err := h.store.DisableTwoFactor(r.Context(), user.ID); err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	h.logger.Info("two-factor authentication disabled", zap.String("userID", user.ID))
+
+	helper.OK(h.logger, w, "two-factor authentication disabled", nil)
+}
+
+// readSecondFactor guards changes to an enabled second factor: the session
+// must be recently authenticated and the request must carry a valid code.
+// It writes the error response and returns false otherwise.
+func (h *handler) readSecondFactor(w http.ResponseWriter, r *http.Request, user *types.User) (*types.TwoFactorCode, bool) {
+	var payload types.TwoFactorCode
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.BadRequest(h.logger, w, "failed to read request body", nil)
+		return nil, false
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.UnprocessableEntity(h.logger, w, "failed to validate request body", nil)
+		return nil, false
+	}
+
+	if !h.requireRecentAuth(w, r, user) || h.accountLocked(w, r, user.Email) {
+		return nil, false
+	}
+
+	ok, err := h.checkSecondFactor(r.Context(), user.ID, &payload)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return nil, false
+	}
+	if !ok {
+		if h.recordFailedLogin(w, r, user.Email) {
+			return nil, false
+		}
+		helper.BadRequest(h.logger, w, "invalid two-factor code", nil)
+		return nil, false
+	}
+
+	return &payload, true
+}
+
+// generateRecoveryCodes returns new recovery codes and the hashes to store.
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes, err := helper.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = helper.HashRecoveryCode(code)
+	}
+	return codes, hashes, nil
+}
@@ -1,59 +1,139 @@
 package handler
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
-	"os"
 	"path/filepath"
-	"time"
+	"strings"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/storage"
+	"go.uber.org/zap"
 )
 
-func (h *handler) handleUpload(w http.ResponseWriter, r *http.Request) {
-	// Parse multipart form
-	if err := r.ParseMultipartForm(10 << 20); err != nil { // 10 MB limit
-		helper.BadRequest(h.logger, w, "failed to parse multipart form", err)
-		return
+const maxUploadSize = 10 << 20 // 10 MB
+
+var errFileTooLarge = errors.New("file is larger than 10 MB")
+
+// uploadedFile is a multipart file ready to be written to blob storage.
+type uploadedFile struct {
+	name        string
+	size        int64
+	contentType string
+	body        io.Reader
+	file        multipart.File
+}
+
+// readUploadedFile parses the "file" form field and sniffs its MIME type
+// from the first 512 bytes instead of trusting the client supplied header.
+// ParseMultipartForm only caps what is held in memory, so the body itself is
+// limited too; otherwise the rest spills to temp files and gets stored.
+func readUploadedFile(w http.ResponseWriter, r *http.Request) (*uploadedFile, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	if err := r.ParseMultipartForm(maxUploadSize); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, errFileTooLarge
+		}
+		return nil, err
 	}
 
-	file, handler, err := r.FormFile("file")
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+	if header.Size > maxUploadSize {
+		file.Close()
+		return nil, errFileTooLarge
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		file.Close()
+		return nil, err
+	}
+	head = head[:n]
+
+	return &uploadedFile{
+		name:        filepath.Base(header.Filename),
+		size:        header.Size,
+		contentType: http.DetectContentType(head),
+		body:        io.MultiReader(bytes.NewReader(head), file),
+		file:        file,
+	}, nil
+}
+
+func (h *handler) handleUpload(w http.ResponseWriter, r *http.Request) {
+	upload, err := readUploadedFile(w, r)
 	if err != nil {
+		if errors.Is(err, errFileTooLarge) {
+			helper.BadRequest(h.logger, w, errFileTooLarge.Error(), nil)
+			return
+		}
 		helper.BadRequest(h.logger, w, "failed to retrieve file from form", err)
 		return
 	}
-	defer file.Close()
+	defer upload.file.Close()
 
-	// Create uploads directory if it doesn't exist
-	uploadDir := "./uploads"
-	if err := os.MkdirAll(uploadDir, 0755); err != nil {
+	// uploads sit at the top of the blob store, next to the files saved
+	// before blob storage, so both are served the same way
+	key := uuid.New().String() + filepath.Ext(upload.name)
+	if err := h.blob.Put(r.Context(), key, upload.body, upload.size, upload.contentType); err != nil {
 		helper.InternalServerError(h.logger, w, nil, err)
 		return
 	}
 
-	// Generate unique filename
-	ext := filepath.Ext(handler.Filename)
-	filename := fmt.Sprintf("%d%s", time.Now().UnixNano(), ext)
-	filePath := filepath.Join(uploadDir, filename)
+	fileURL := fmt.Sprintf("%s/api/v1/uploads/%s", h.publicURL, key)
 
-	// Create destination file
-	dst, err := os.Create(filePath)
-	if err != nil {
-		helper.InternalServerError(h.logger, w, nil, err)
+	helper.Created(h.logger, w, "file uploaded successfully", map[string]string{"url": fileURL})
+}
+
+// handleServeUpload serves a file saved by handleUpload. Only top-level
+// names are served, so attachments and in-progress writes stay private.
+func (h *handler) handleServeUpload(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "*")
+	if name == "" || strings.Contains(name, "/") || strings.HasPrefix(name, ".") {
+		http.NotFound(w, r)
 		return
 	}
-	defer dst.Close()
 
-	// Copy file content
-	if _, err := io.Copy(dst, file); err != nil {
+	contentType := mime.TypeByExtension(filepath.Ext(name))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	h.serveBlob(w, r, name, contentType, "")
+}
+
+// serveBlob streams the blob stored under key to the client. A non-empty
+// fileName makes the browser download the file instead of displaying it.
+func (h *handler) serveBlob(w http.ResponseWriter, r *http.Request, key, contentType, fileName string) {
+	rc, err := h.blob.Get(r.Context(), key)
+	if errors.Is(err, storage.ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
 		helper.InternalServerError(h.logger, w, nil, err)
 		return
 	}
+	defer rc.Close()
 
-	// Construct file URL
-	// Assuming the server serves static files from /uploads
-	fileURL := fmt.Sprintf("http://localhost:8080/uploads/%s", filename)
+	if fileName != "" {
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
 
-	helper.Created(h.logger, w, "file uploaded successfully", map[string]string{"url": fileURL})
+	if _, err := io.Copy(w, rc); err != nil {
+		h.logger.Error("failed to stream blob", zap.String("key", key), zap.Error(err))
+	}
 }
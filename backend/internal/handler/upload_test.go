@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	m "github.com/vaidik-bajpai/Nexus/backend/internal/store/mock"
+)
+
+func TestUploads(t *testing.T) {
+	h, dir := createAttachmentTestHandler(t, new(m.MockStore))
+	h.publicURL = "http://localhost:8080"
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/upload", h.handleUpload)
+	r.Get("/api/v1/uploads/*", h.handleServeUpload)
+	r.Get("/uploads/*", h.handleServeUpload)
+
+	t.Run("upload is stored at the top of the blob store", func(t *testing.T) {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		fw, err := mw.CreateFormFile("file", "cover.png")
+		require.NoError(t, err)
+		_, err = fw.Write([]byte("cover"))
+		require.NoError(t, err)
+		require.NoError(t, mw.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/upload", &body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+		var resp struct {
+			Data map[string]string `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		url := resp.Data["url"]
+		require.True(t, strings.HasPrefix(url, "http://localhost:8080/api/v1/uploads/"), url)
+
+		name := strings.TrimPrefix(url, "http://localhost:8080/api/v1/uploads/")
+		assert.Equal(t, ".png", filepath.Ext(name))
+		assert.FileExists(t, filepath.Join(dir, name))
+
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/uploads/"+name, nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "cover", rr.Body.String())
+	})
+
+	t.Run("covers saved before blob storage", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "1700000000000000000.jpg"), []byte("old cover"), 0644))
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/uploads/1700000000000000000.jpg", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "image/jpeg", rr.Header().Get("Content-Type"))
+		assert.Equal(t, "old cover", rr.Body.String())
+	})
+
+	t.Run("attachments and temp files are not served", func(t *testing.T) {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "cards", "c1"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "cards", "c1", "secret.txt"), []byte("secret"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".upload-123"), []byte("partial"), 0644))
+
+		for _, path := range []string{"/uploads/cards/c1/secret.txt", "/api/v1/uploads/cards/c1/secret.txt", "/uploads/.upload-123", "/uploads/missing.png"} {
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+			assert.Equal(t, http.StatusNotFound, rr.Code, path)
+		}
+	})
+}
@@ -1,16 +1,15 @@
 package handler
 
 import (
-	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
-	"strconv"
 	"time"
 
 	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/oauth"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
 	"go.uber.org/zap"
 	"golang.org/x/oauth2"
@@ -58,9 +57,13 @@ func (h *handler) handleUserRegistration(w http.ResponseWriter, r *http.Request)
 	usr.PasswordHash = hashedPassword
 
 	// create token
-	usr.Token = strconv.Itoa(helper.CreateRandomToken())
-	usr.TokenTTL = time.Now().Add(24 * time.Hour)
-	usr.TokenScope = "email_verification"
+	usr.Token, err = helper.GenerateEmailToken()
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+	usr.TokenTTL = time.Now().Add(emailVerificationTTL)
+	usr.TokenScope = types.TokenScopeEmailVerification
 
 	// create user
 	err = h.store.CreateCredentialsUser(r.Context(), &usr)
@@ -77,7 +80,7 @@ func (h *handler) handleUserRegistration(w http.ResponseWriter, r *http.Request)
 	if err := h.mailer.SendEmailVerificationEmail(
 		[]string{usr.Email},
 		"Email Verification",
-		fmt.Sprintf("http://localhost:3000/verify-email?token=%s", usr.Token),
+		emailVerificationURL(usr.Token),
 	); err != nil {
 		helper.WriteJSON(w, http.StatusInternalServerError, &types.Response{
 			Status:  http.StatusInternalServerError,
@@ -136,6 +139,9 @@ func (h *handler) handleUserLogin(w http.ResponseWriter, r *http.Request) {
 
 	// check if password is correct
 	if err := helper.ComparePassword(user.Password, usr.Password); err != nil {
+		if h.recordFailedLogin(w, r, usr.Email) {
+			return
+		}
 		helper.WriteJSON(w, http.StatusUnauthorized, &types.Response{
 			Status:  http.StatusUnauthorized,
 			Message: "invalid user credentials",
@@ -146,35 +152,40 @@ func (h *handler) handleUserLogin(w http.ResponseWriter, r *http.Request) {
 
 	h.logger.Debug("password is correct")
 
-	// generate token
-	accessToken, refreshToken, err := helper.GenerateAccessAndRefreshTokens(&types.User{
-		ID:       user.ID,
-		Email:    user.Email,
-		Username: user.Username,
-	})
-	if err != nil {
-		helper.WriteJSON(w, http.StatusInternalServerError, &types.Response{
-			Status:  http.StatusInternalServerError,
-			Message: "failed to generate token",
-			Data:    nil,
-		})
+	if !h.requireVerifiedEmail(w, user, VerificationPolicyLogin) {
 		return
 	}
 
-	h.logger.Debug("access token", zap.String("accessToken", accessToken))
-	h.logger.Debug("refresh token", zap.String("refreshToken", refreshToken))
+	if user.TwoFactorEnabled {
+		h.writeTwoFactorChallenge(w, user)
+		return
+	}
+
+	h.completeLogin(w, r, user)
+}
 
-	err = h.store.UpdateUserRefreshToken(r.Context(), user.ID, refreshToken)
+// completeLogin starts a session for an authenticated user and responds
+// with its tokens.
+func (h *handler) completeLogin(w http.ResponseWriter, r *http.Request, user *types.User) {
+	h.recordLogin(r, user.Email)
+
+	accessToken, refreshToken, err := h.startSession(r, &types.User{
+		ID:            user.ID,
+		Email:         user.Email,
+		Username:      user.Username,
+		EmailVerified: user.EmailVerified,
+	})
 	if err != nil {
+		h.logger.Error("failed to start session", zap.Error(err))
 		helper.WriteJSON(w, http.StatusInternalServerError, &types.Response{
 			Status:  http.StatusInternalServerError,
-			Message: "failed to update user refresh token",
+			Message: "failed to start session",
 			Data:    nil,
 		})
 		return
 	}
 
-	h.logger.Debug("user refresh token updated")
+	h.logger.Debug("session started")
 
 	helper.WriteJSON(w, http.StatusOK, &types.Response{
 		Status:  http.StatusOK,
@@ -194,8 +205,8 @@ func (h *handler) handleUserLogin(w http.ResponseWriter, r *http.Request) {
 func (h *handler) handleUserOAuthFlow(w http.ResponseWriter, r *http.Request) {
 	h.logger.Debug("handling user oauth flow")
 
-	provider := r.PathValue("provider")
-	if provider != "google" {
+	provider, ok := h.providers.Get(r.PathValue("provider"))
+	if !ok {
 		helper.WriteJSON(w, http.StatusBadRequest, &types.Response{
 			Status:  http.StatusBadRequest,
 			Message: "provider not supported",
@@ -204,50 +215,67 @@ func (h *handler) handleUserOAuthFlow(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	h.logger.Debug("provider", zap.String("provider", provider))
+	h.logger.Debug("provider", zap.String("provider", provider.Name()))
 
-	oAuthCfg := h.oauth2[provider]
-	if oAuthCfg == nil {
-		helper.WriteJSON(w, http.StatusBadRequest, &types.Response{
-			Status:  http.StatusBadRequest,
-			Message: "provider not supported",
-		})
+	authURL, ok := h.beginOAuth(w, r, provider)
+	if !ok {
 		return
 	}
 
+	// a plain sign-in must not complete a link left over from an earlier flow
+	clearOAuthCookie(w, "oauth_intent")
+
+	h.logger.Info("redirecting to authURL", zap.String("authURL", authURL))
+
+	http.Redirect(w, r, authURL, http.StatusTemporaryRedirect)
+}
+
+// beginOAuth sets the state, nonce and PKCE verifier cookies for a provider
+// flow and returns the provider's authorization URL. It writes the error
+// response and returns false on failure.
+func (h *handler) beginOAuth(w http.ResponseWriter, r *http.Request, provider oauth.Provider) (string, bool) {
 	state, err := helper.GenerateOAuthState()
 	if err != nil {
 		helper.WriteJSON(w, http.StatusInternalServerError, &types.Response{
 			Status:  http.StatusInternalServerError,
 			Message: "failed to generate state token",
 		})
-		return
+		return "", false
 	}
 
-	http.SetCookie(w, &http.Cookie{
-		Name:     "oauth_state",
-		Value:    state,
-		Path:     "/",
-		MaxAge:   600,
-		HttpOnly: true,
-		SameSite: http.SameSiteLaxMode,
-	})
+	nonce, err := helper.GenerateOAuthState()
+	if err != nil {
+		helper.WriteJSON(w, http.StatusInternalServerError, &types.Response{
+			Status:  http.StatusInternalServerError,
+			Message: "failed to generate nonce",
+		})
+		return "", false
+	}
 
-	h.logger.Debug("oAuthCfg", zap.Any("oAuthCfg", oAuthCfg))
+	verifier := oauth2.GenerateVerifier()
 
-	authURL := oAuthCfg.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.ApprovalForce)
+	authURL, err := provider.AuthCodeURL(r.Context(), state, nonce, verifier)
+	if err != nil {
+		h.logger.Error("failed to build auth url", zap.String("provider", provider.Name()), zap.Error(err))
+		helper.WriteJSON(w, http.StatusBadGateway, &types.Response{
+			Status:  http.StatusBadGateway,
+			Message: "identity provider is unavailable",
+		})
+		return "", false
+	}
 
-	h.logger.Debug("authURL", zap.String("authURL", authURL))
-	h.logger.Info("redirecting to authURL", zap.String("authURL", authURL))
+	setOAuthCookie(w, "oauth_state", state)
+	setOAuthCookie(w, "oauth_nonce", nonce)
+	setOAuthCookie(w, "oauth_verifier", verifier)
 
-	http.Redirect(w, r, authURL, http.StatusTemporaryRedirect)
+	return authURL, true
 }
 
 func (h *handler) handleUserOAuthCallback(w http.ResponseWriter, r *http.Request) {
 	h.logger.Debug("handling user oauth callback")
 
-	provider := r.PathValue("provider")
-	if provider != "google" {
+	provider, ok := h.providers.Get(r.PathValue("provider"))
+	if !ok {
 		helper.WriteJSON(w, http.StatusBadRequest, &types.Response{
 			Status:  http.StatusBadRequest,
 			Message: "provider not supported",
@@ -274,84 +302,70 @@ func (h *handler) handleUserOAuthCallback(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	http.SetCookie(w, &http.Cookie{
-		Name:     "oauth_state",
-		Value:    "",
-		Path:     "/",
-		MaxAge:   -1,
-		HttpOnly: true,
-	})
-
-	code := r.URL.Query().Get("code")
-	if code == "" {
+	nonceCookie, err := r.Cookie("oauth_nonce")
+	if err != nil {
 		helper.WriteJSON(w, http.StatusBadRequest, &types.Response{
 			Status:  http.StatusBadRequest,
-			Message: "code is required",
+			Message: "nonce cookie not found",
 		})
 		return
 	}
 
-	oAuthCfg := h.oauth2[provider]
-	if oAuthCfg == nil {
+	verifierCookie, err := r.Cookie("oauth_verifier")
+	if err != nil {
 		helper.WriteJSON(w, http.StatusBadRequest, &types.Response{
 			Status:  http.StatusBadRequest,
-			Message: "provider not supported",
-		})
-		return
-	}
-
-	token, err := oAuthCfg.Exchange(context.Background(), code)
-	if err != nil {
-		helper.WriteJSON(w, http.StatusInternalServerError, &types.Response{
-			Status:  http.StatusInternalServerError,
-			Message: "failed to exchange code for token",
+			Message: "verifier cookie not found",
 		})
 		return
 	}
 
-	h.logger.Debug("token exchanged successfully", zap.Any("token", token))
+	// set when a signed-in user started the flow to link or re-authenticate
+	intentCookie, _ := r.Cookie("oauth_intent")
 
-	client := h.oauth2[provider].Client(context.Background(), token)
+	clearOAuthCookie(w, "oauth_state")
+	clearOAuthCookie(w, "oauth_nonce")
+	clearOAuthCookie(w, "oauth_verifier")
+	clearOAuthCookie(w, "oauth_intent")
 
-	resp, err := client.Get("https://www.googleapis.com/oauth2/v3/userinfo")
-	if err != nil {
-		helper.WriteJSON(w, http.StatusInternalServerError, &types.Response{
-			Status:  http.StatusInternalServerError,
-			Message: "failed to get user info",
+	code := r.URL.Query().Get("code")
+	if code == "" {
+		helper.WriteJSON(w, http.StatusBadRequest, &types.Response{
+			Status:  http.StatusBadRequest,
+			Message: "code is required",
 		})
 		return
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	profile, err := provider.Exchange(r.Context(), code, nonceCookie.Value, verifierCookie.Value)
 	if err != nil {
-		helper.WriteJSON(w, http.StatusInternalServerError, &types.Response{
-			Status:  http.StatusInternalServerError,
-			Message: "failed to read user info",
-			Data:    nil,
+		h.logger.Error("failed to exchange code", zap.String("provider", provider.Name()), zap.Error(err))
+		helper.WriteJSON(w, http.StatusUnauthorized, &types.Response{
+			Status:  http.StatusUnauthorized,
+			Message: "failed to sign in with provider",
 		})
 		return
 	}
 
-	var userInfo types.GoogleOAuthUserInfo
-	if err := json.Unmarshal(body, &userInfo); err != nil {
-		helper.WriteJSON(w, http.StatusInternalServerError, &types.Response{
-			Status:  http.StatusInternalServerError,
-			Message: "failed to unmarshal user info",
-			Data:    nil,
+	createOAuthUser := profile.User(provider.Name())
+	if err := h.validator.Struct(createOAuthUser); err != nil {
+		helper.WriteJSON(w, http.StatusBadRequest, &types.Response{
+			Status:  http.StatusBadRequest,
+			Message: "provider returned an incomplete profile",
 		})
 		return
 	}
 
-	h.logger.Debug("user info", zap.Any("userInfo", userInfo))
-
-	createOAuthUser := &types.CreateOAuthUser{
-		Email:             userInfo.Email,
-		Provider:          provider,
-		ProviderAccountID: userInfo.ID,
+	if intentCookie != nil {
+		h.completeAccountIntent(w, r, intentCookie.Value, createOAuthUser)
+		return
 	}
 
 	if err := h.store.CreateOAuthUser(r.Context(), createOAuthUser); err != nil {
+		if errors.Is(err, store.ErrAccountExists) {
+			h.offerAccountLink(w, r, createOAuthUser)
+			return
+		}
 		h.logger.Error("failed to create oauth user", zap.Error(err))
 		helper.WriteJSON(w, http.StatusInternalServerError, &types.Response{
 			Status:  http.StatusInternalServerError,
@@ -360,69 +374,111 @@ func (h *handler) handleUserOAuthCallback(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	h.logger.Debug("oauth user created", zap.Any("oauthUser", createOAuthUser))
+	h.logger.Debug("oauth user resolved", zap.String("userID", createOAuthUser.ID))
 
-	accessToken, refreshToken, err := helper.GenerateAccessAndRefreshTokens(&types.User{
-		ID:    createOAuthUser.ID,
-		Email: createOAuthUser.Email,
-	})
+	// a linked provider's email may differ from the user's own, so the
+	// session is started for the user as stored
+	user, err := h.store.GetUserByID(r.Context(), createOAuthUser.ID)
 	if err != nil {
+		h.logger.Error("failed to get oauth user", zap.Error(err))
 		helper.WriteJSON(w, http.StatusInternalServerError, &types.Response{
 			Status:  http.StatusInternalServerError,
-			Message: "failed to generate access and refresh tokens",
+			Message: "failed to get user",
 		})
 		return
 	}
 
-	h.logger.Debug("access token", zap.String("accessToken", accessToken))
-	h.logger.Debug("refresh token", zap.String("refreshToken", refreshToken))
+	if user.TwoFactorEnabled {
+		challengeToken, err := helper.GenerateTwoFactorChallenge(user.ID, twoFactorChallengeTTL)
+		if err != nil {
+			h.logger.Error("failed to generate two-factor challenge", zap.Error(err))
+			helper.WriteJSON(w, http.StatusInternalServerError, &types.Response{
+				Status:  http.StatusInternalServerError,
+				Message: "failed to generate two-factor challenge",
+			})
+			return
+		}
 
-	err = h.store.UpdateUserRefreshToken(r.Context(), createOAuthUser.ID, refreshToken)
-	if err != nil {
-		h.logger.Error("failed to update user refresh token", zap.Error(err))
-		helper.WriteJSON(w, http.StatusInternalServerError, &types.Response{
-			Status:  http.StatusInternalServerError,
-			Message: "failed to update user refresh token",
-			Data:    nil,
-		})
+		redirectToFrontend(w, r, "/oauth/2fa", url.Values{"challengeToken": {challengeToken}})
 		return
 	}
 
-	frontendURL := "http://localhost:3000/oauth/callback"
-	redirectURL, err := url.Parse(frontendURL)
+	accessToken, refreshToken, err := h.startSession(r, &types.User{
+		ID:            user.ID,
+		Email:         user.Email,
+		Username:      user.Username,
+		EmailVerified: user.EmailVerified,
+	})
 	if err != nil {
-		h.logger.Error("failed to parse frontend url", zap.Error(err))
+		h.logger.Error("failed to start session", zap.Error(err))
 		helper.WriteJSON(w, http.StatusInternalServerError, &types.Response{
 			Status:  http.StatusInternalServerError,
-			Message: "failed to parse frontend url",
+			Message: "failed to start session",
 		})
 		return
 	}
 
-	q := redirectURL.Query()
-	q.Set("accessToken", accessToken)
-	q.Set("refreshToken", refreshToken)
-	redirectURL.RawQuery = q.Encode()
+	redirectToFrontend(w, r, "/oauth/callback", url.Values{
+		"accessToken":  {accessToken},
+		"refreshToken": {refreshToken},
+	})
+}
+
+// redirectToFrontend sends the browser back to a frontend page at the end
+// of a provider flow.
+func redirectToFrontend(w http.ResponseWriter, r *http.Request, path string, query url.Values) {
+	redirectURL := url.URL{
+		Scheme:   "http",
+		Host:     "localhost:3000",
+		Path:     path,
+		RawQuery: query.Encode(),
+	}
 
 	http.Redirect(w, r, redirectURL.String(), http.StatusTemporaryRedirect)
 }
 
+func (h *handler) handleListOAuthProviders(w http.ResponseWriter, r *http.Request) {
+	helper.OK(h.logger, w, "providers fetched successfully", map[string]any{"providers": h.providers.Names()})
+}
+
+func setOAuthCookie(w http.ResponseWriter, name, value string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   600,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func clearOAuthCookie(w http.ResponseWriter, name string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+	})
+}
+
 func (h *handler) handleUserLogout(w http.ResponseWriter, r *http.Request) {
 	h.logger.Debug("handling user logout")
 
 	user := r.Context().Value(types.UserCtxKey).(*types.User)
 	h.logger.Debug("user", zap.Any("user", user))
 
-	err := h.store.UpdateUserRefreshToken(r.Context(), user.ID, "")
-	if err != nil {
-		h.logger.Error("failed to update user refresh token", zap.Error(err))
+	err := h.store.RevokeSession(r.Context(), user.ID, user.SessionID)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		h.logger.Error("failed to revoke session", zap.Error(err))
 		helper.WriteJSON(w, http.StatusInternalServerError, &types.Response{
 			Status:  http.StatusInternalServerError,
-			Message: "failed to update user refresh token",
+			Message: "failed to revoke session",
 		})
+		return
 	}
 
-	h.logger.Debug("user refresh token updated")
+	h.logger.Debug("session revoked")
 
 	helper.WriteJSON(w, http.StatusOK, &types.Response{
 		Status:  http.StatusOK,
@@ -472,11 +528,17 @@ func (h *handler) handlePasswordResetFlow(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	resetToken, err := helper.GenerateEmailToken()
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
 	token := &types.Token{
 		UserID: user.ID,
-		Token:  strconv.Itoa(helper.CreateRandomToken()),
+		Token:  resetToken,
 		TTL:    time.Now().Add(1 * time.Hour),
-		Scope:  "reset_password",
+		Scope:  types.TokenScopeResetPassword,
 	}
 
 	err = h.store.CreateToken(r.Context(), token)
@@ -533,7 +595,7 @@ func (h *handler) handlePasswordReset(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if usr.Password == "" || usr.Token.Scope != "reset_password" || time.Now().After(usr.Token.TTL) {
+	if usr.Password == "" || usr.Token.Scope != types.TokenScopeResetPassword || time.Now().After(usr.Token.TTL) {
 		helper.WriteJSON(w, http.StatusBadRequest, &types.Response{
 			Status:  http.StatusBadRequest,
 			Message: "invalid or expired token",
@@ -592,7 +654,7 @@ func (h *handler) handlePasswordReset(w http.ResponseWriter, r *http.Request) {
 
 func (h *handler) handleRefreshToken(w http.ResponseWriter, r *http.Request) {
 	var refreshToken types.RefreshToken
-	if err := helper.ReadJSON(r, refreshToken); err != nil {
+	if err := helper.ReadJSON(r, &refreshToken); err != nil {
 		helper.WriteJSON(w, http.StatusBadRequest, &types.Response{
 			Status:  http.StatusBadRequest,
 			Message: "invalid refresh token credentials",
@@ -610,18 +672,34 @@ func (h *handler) handleRefreshToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	usr, err := helper.VerifyToken(refreshToken.RefreshToken, helper.GetStrEnvOrPanic("REFRESH_TOKEN_SECRET"))
+	nextToken, nextTokenHash, err := helper.GenerateRefreshToken()
 	if err != nil {
-		helper.WriteJSON(w, http.StatusBadRequest, &types.Response{
-			Status:  http.StatusBadRequest,
-			Message: "invalid refresh token credentials",
+		helper.WriteJSON(w, http.StatusInternalServerError, &types.Response{
+			Status:  http.StatusInternalServerError,
+			Message: "could not issue new refresh token",
 			Data:    nil,
 		})
 		return
 	}
 
-	dbUsr, err := h.store.GetUserByEmail(context.Background(), usr.Email)
+	usr, err := h.store.RotateSession(r.Context(), &types.RotateSession{
+		TokenHash:     helper.HashToken(refreshToken.RefreshToken),
+		NextTokenHash: nextTokenHash,
+		IP:            helper.ClientIP(r),
+		ExpiresAt:     time.Now().Add(helper.RefreshTokenTTL),
+	})
 	if err != nil {
+		if errors.Is(err, store.ErrRefreshTokenReused) {
+			h.logger.Warn("refresh token reuse detected, session revoked", zap.String("ip", helper.ClientIP(r)))
+		}
+		if errors.Is(err, store.ErrNotFound) || errors.Is(err, store.ErrSessionRevoked) || errors.Is(err, store.ErrRefreshTokenReused) {
+			helper.WriteJSON(w, http.StatusUnauthorized, &types.Response{
+				Status:  http.StatusUnauthorized,
+				Message: "invalid refresh token credentials",
+				Data:    nil,
+			})
+			return
+		}
 		helper.WriteJSON(w, http.StatusInternalServerError, &types.Response{
 			Status:  http.StatusInternalServerError,
 			Message: "something went wrong with our server",
@@ -630,16 +708,7 @@ func (h *handler) handleRefreshToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if dbUsr.RefereshToken != refreshToken.RefreshToken {
-		helper.WriteJSON(w, http.StatusBadRequest, &types.Response{
-			Status:  http.StatusBadRequest,
-			Message: "invalid refresh token credentials",
-			Data:    nil,
-		})
-		return
-	}
-
-	accessToken, _, err := helper.GenerateAccessAndRefreshTokens(usr)
+	accessToken, err := helper.GenerateAccessToken(usr)
 	if err != nil {
 		helper.WriteJSON(w, http.StatusInternalServerError, &types.Response{
 			Status:  http.StatusInternalServerError,
@@ -653,7 +722,8 @@ func (h *handler) handleRefreshToken(w http.ResponseWriter, r *http.Request) {
 		Status:  http.StatusOK,
 		Message: "access token refresh successfully",
 		Data: map[string]any{
-			"access_token": accessToken,
+			"access_token":  accessToken,
+			"refresh_token": nextToken,
 		},
 	})
 }
@@ -2,21 +2,25 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	_ "github.com/joho/godotenv/autoload"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
 	mailerMock "github.com/vaidik-bajpai/Nexus/backend/internal/mailer/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/oauth"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
 	m "github.com/vaidik-bajpai/Nexus/backend/internal/store/mock"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
 	"go.uber.org/zap"
-	"golang.org/x/oauth2"
 )
 
 func createTestHandler(mockStore *m.MockStore, mockMailer *mailerMock.MockMailer) *handler {
@@ -28,7 +32,7 @@ func createTestHandler(mockStore *m.MockStore, mockMailer *mailerMock.MockMailer
 		validator: validator,
 		store:     mockStore,
 		mailer:    mockMailer,
-		oauth2:    make(map[string]*oauth2.Config),
+		providers: &oauth.Registry{},
 	}
 }
 
@@ -146,3 +150,186 @@ func TestHandleUserRegistration(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleVerifyEmail(t *testing.T) {
+	tests := []struct {
+		name           string
+		token          string
+		setupMock      func(*m.MockStore)
+		expectedStatus int
+		expectedMsg    string
+	}{
+		{
+			name:  "successful verification",
+			token: "123456",
+			setupMock: func(ms *m.MockStore) {
+				ms.On("VerifyEmail", mock.Anything, "123456").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedMsg:    "email verified successfully",
+		},
+		{
+			name:           "missing token",
+			token:          "",
+			setupMock:      func(ms *m.MockStore) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "token is required",
+		},
+		{
+			name:  "expired token",
+			token: "123456",
+			setupMock: func(ms *m.MockStore) {
+				ms.On("VerifyEmail", mock.Anything, "123456").Return(store.ErrTokenExpired)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "invalid or expired token",
+		},
+		{
+			name:  "token already used",
+			token: "123456",
+			setupMock: func(ms *m.MockStore) {
+				ms.On("VerifyEmail", mock.Anything, "123456").Return(store.ErrNotFound)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "invalid or expired token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := new(m.MockStore)
+			tt.setupMock(mockStore)
+			handler := createTestHandler(mockStore, new(mailerMock.MockMailer))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/users/verify-email?token="+tt.token, nil)
+			rr := httptest.NewRecorder()
+
+			handler.handleVerifyEmail(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+
+			var response types.Response
+			err := json.Unmarshal(rr.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedMsg, response.Message)
+
+			mockStore.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRequireVerifiedEmail(t *testing.T) {
+	handler := createTestHandler(new(m.MockStore), new(mailerMock.MockMailer))
+	handler.verificationPolicy = VerificationPolicyLogin
+
+	rr := httptest.NewRecorder()
+	assert.False(t, handler.requireVerifiedEmail(rr, &types.User{}, VerificationPolicyLogin))
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	rr = httptest.NewRecorder()
+	assert.True(t, handler.requireVerifiedEmail(rr, &types.User{EmailVerified: true}, VerificationPolicyLogin))
+	assert.True(t, handler.requireVerifiedEmail(rr, &types.User{}, VerificationPolicyCreateBoard))
+}
+
+func TestHandleRefreshTokenRejectsInvalidSessions(t *testing.T) {
+	for _, storeErr := range []error{store.ErrNotFound, store.ErrSessionRevoked, store.ErrRefreshTokenReused} {
+		t.Run(storeErr.Error(), func(t *testing.T) {
+			mockStore := new(m.MockStore)
+			mockStore.On("RotateSession", mock.Anything, mock.AnythingOfType("*types.RotateSession")).
+				Return(nil, storeErr)
+			handler := createTestHandler(mockStore, new(mailerMock.MockMailer))
+
+			body := bytes.NewReader([]byte(`{"refreshToken":"old-token"}`))
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/users/refresh-token", body)
+			rr := httptest.NewRecorder()
+
+			handler.handleRefreshToken(rr, req)
+
+			assert.Equal(t, http.StatusUnauthorized, rr.Code)
+			mockStore.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleUnlinkAccount(t *testing.T) {
+	const accountID = "0b0b5a3e-8f5e-4a44-9d1c-6f7c1f3f5a10"
+
+	tests := []struct {
+		name            string
+		authenticatedAt time.Time
+		unlinkErr       error
+		expectedStatus  int
+	}{
+		{"stale session needs re-authentication", time.Now().Add(-time.Hour), nil, http.StatusForbidden},
+		{"last login method", time.Now(), store.ErrLastLoginMethod, http.StatusConflict},
+		{"unlinked", time.Now(), nil, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := new(m.MockStore)
+			mockStore.On("GetSession", mock.Anything, "user-1", "session-1").
+				Return(&types.Session{ID: "session-1", AuthenticatedAt: tt.authenticatedAt}, nil)
+			if tt.expectedStatus != http.StatusForbidden {
+				mockStore.On("UnlinkAccount", mock.Anything, "user-1", accountID).Return(tt.unlinkErr)
+			}
+			handler := createTestHandler(mockStore, new(mailerMock.MockMailer))
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/users/me/accounts/"+accountID+"/unlink", nil)
+			req.SetPathValue("accountID", accountID)
+			req = req.WithContext(context.WithValue(req.Context(), types.UserCtxKey, &types.User{ID: "user-1", SessionID: "session-1"}))
+			rr := httptest.NewRecorder()
+
+			handler.handleUnlinkAccount(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockStore.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandleTwoFactorLogin(t *testing.T) {
+	t.Setenv("ACCESS_TOKEN_SECRET", "test-secret")
+
+	challenge, err := helper.GenerateTwoFactorChallenge("user-1", time.Minute)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		challenge      string
+		codeUnused     bool
+		expectedStatus int
+	}{
+		{"invalid challenge", "not-a-token", false, http.StatusUnauthorized},
+		{"used recovery code", challenge, false, http.StatusUnauthorized},
+		{"unused recovery code", challenge, true, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := new(m.MockStore)
+			if tt.challenge == challenge {
+				enabledAt := time.Now()
+				mockStore.On("GetUserByID", mock.Anything, "user-1").
+					Return(&types.User{ID: "user-1", Email: "ada@example.com", TwoFactorEnabled: true}, nil)
+				mockStore.On("GetTwoFactor", mock.Anything, "user-1").
+					Return(&types.TwoFactor{EnabledAt: &enabledAt}, nil)
+				mockStore.On("UseRecoveryCode", mock.Anything, "user-1", helper.HashRecoveryCode("abcde-fghij")).
+					Return(tt.codeUnused, nil)
+			}
+			if tt.codeUnused {
+				mockStore.On("CreateSession", mock.Anything, mock.AnythingOfType("*types.CreateSession")).Return(nil)
+			}
+			handler := createTestHandler(mockStore, new(mailerMock.MockMailer))
+
+			body, _ := json.Marshal(map[string]string{"challengeToken": tt.challenge, "recoveryCode": "abcde-fghij"})
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/users/login/2fa", bytes.NewReader(body))
+			rr := httptest.NewRecorder()
+
+			handler.handleTwoFactorLogin(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			mockStore.AssertExpectations(t)
+		})
+	}
+}
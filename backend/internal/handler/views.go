@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/views"
+)
+
+// maxViewRange caps calendar and timeline queries at roughly a year.
+const maxViewRange = 366 * 24 * time.Hour
+
+func (h *handler) handleCreateView(w http.ResponseWriter, r *http.Request) {
+	var payload types.CreateView
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.UnprocessableEntity(h.logger, w, "invalid request payload", nil)
+		return
+	}
+
+	payload.BoardID = r.PathValue("boardID")
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "failed validation on the request payload", err)
+		return
+	}
+
+	view, err := h.store.CreateView(r.Context(), &payload)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.Created(h.logger, w, "view created successfully", view)
+}
+
+func (h *handler) handleListViews(w http.ResponseWriter, r *http.Request) {
+	boardID := r.PathValue("boardID")
+
+	boardViews, err := h.store.ListBoardViews(r.Context(), boardID)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "views fetched successfully", map[string]any{"views": boardViews})
+}
+
+func (h *handler) handleUpdateView(w http.ResponseWriter, r *http.Request) {
+	view, ok := h.getBoardView(w, r)
+	if !ok {
+		return
+	}
+
+	var payload types.UpdateView
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.UnprocessableEntity(h.logger, w, "invalid request payload", nil)
+		return
+	}
+
+	payload.ViewID = view.ID
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "failed validation on the request payload", err)
+		return
+	}
+
+	updated, err := h.store.UpdateView(r.Context(), &payload)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "view updated successfully", updated)
+}
+
+func (h *handler) handleDeleteView(w http.ResponseWriter, r *http.Request) {
+	view, ok := h.getBoardView(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.store.DeleteView(r.Context(), view.ID); err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "view deleted successfully", nil)
+}
+
+// handleQueryView returns the board's cards shaped for the view's type.
+// Query parameters:
+//   - sort_by, sort_order: override the saved table sort
+//   - group_by: override the saved timeline grouping
+//   - from, to: calendar and timeline range as YYYY-MM-DD (default: this month)
+//   - tz: IANA time zone used to bucket calendar days (default: UTC)
+func (h *handler) handleQueryView(w http.ResponseWriter, r *http.Request) {
+	view, ok := h.getBoardView(w, r)
+	if !ok {
+		return
+	}
+
+	query := &types.ViewCardQuery{
+		BoardID: view.BoardID,
+		Filters: &view.Settings.Filters,
+	}
+
+	switch view.Type {
+	case types.ViewTable:
+		settings := view.Settings
+		if sortBy := r.URL.Query().Get("sort_by"); sortBy != "" {
+			settings.SortBy = sortBy
+		}
+		if sortOrder := r.URL.Query().Get("sort_order"); sortOrder != "" {
+			settings.SortOrder = sortOrder
+		}
+		if err := h.validator.Struct(settings); err != nil {
+			helper.BadRequest(h.logger, w, "invalid sort parameters", err)
+			return
+		}
+
+		cards, err := h.store.ListViewCards(r.Context(), query)
+		if err != nil {
+			helper.InternalServerError(h.logger, w, nil, err)
+			return
+		}
+
+		helper.OK(h.logger, w, "view fetched successfully", map[string]any{
+			"view": view,
+			"rows": views.Table(cards, settings.SortBy, settings.SortOrder),
+		})
+
+	case types.ViewCalendar, types.ViewTimeline:
+		from, to, loc, err := parseViewRange(r)
+		if err != nil {
+			helper.BadRequest(h.logger, w, err.Error(), nil)
+			return
+		}
+		query.From, query.To = &from, &to
+
+		groupBy := view.Settings.GroupBy
+		if g := r.URL.Query().Get("group_by"); g != "" {
+			groupBy = g
+		}
+		if err := h.validator.Var(groupBy, "omitempty,oneof=list member"); err != nil {
+			helper.BadRequest(h.logger, w, "invalid group_by", nil)
+			return
+		}
+
+		cards, err := h.store.ListViewCards(r.Context(), query)
+		if err != nil {
+			helper.InternalServerError(h.logger, w, nil, err)
+			return
+		}
+
+		res := map[string]any{
+			"view": view,
+			"from": from,
+			"to":   to,
+		}
+		if view.Type == types.ViewCalendar {
+			res["days"] = views.Calendar(cards, from, to, loc)
+		} else {
+			res["groups"] = views.Timeline(cards, groupBy)
+		}
+
+		helper.OK(h.logger, w, "view fetched successfully", res)
+
+	default:
+		helper.BadRequest(h.logger, w, "view type cannot be queried", nil)
+	}
+}
+
+// parseViewRange reads the from/to/tz query parameters. to is exclusive and
+// defaults to one month after from, which defaults to the start of the
+// current month.
+func parseViewRange(r *http.Request) (time.Time, time.Time, *time.Location, error) {
+	q := r.URL.Query()
+
+	loc := time.UTC
+	if tz := q.Get("tz"); tz != "" {
+		l, err := time.LoadLocation(tz)
+		if err != nil {
+			return time.Time{}, time.Time{}, nil, errors.New("invalid tz")
+		}
+		loc = l
+	}
+
+	now := time.Now().In(loc)
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+	if s := q.Get("from"); s != "" {
+		t, err := time.ParseInLocation("2006-01-02", s, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, nil, errors.New("invalid from date")
+		}
+		from = t
+	}
+
+	to := from.AddDate(0, 1, 0)
+	if s := q.Get("to"); s != "" {
+		t, err := time.ParseInLocation("2006-01-02", s, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, nil, errors.New("invalid to date")
+		}
+		to = t
+	}
+
+	if !to.After(from) || to.Sub(from) > maxViewRange {
+		return time.Time{}, time.Time{}, nil, errors.New("date range must be positive and at most a year")
+	}
+
+	return from, to, loc, nil
+}
+
+// getBoardView loads the view in the request path and makes sure it belongs
+// to the board in the path. It writes the error response itself.
+func (h *handler) getBoardView(w http.ResponseWriter, r *http.Request) (*types.View, bool) {
+	viewID := r.PathValue("viewID")
+	if err := h.validator.Var(viewID, "required,uuid"); err != nil {
+		helper.BadRequest(h.logger, w, "invalid view id", nil)
+		return nil, false
+	}
+
+	view, err := h.store.GetView(r.Context(), viewID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.NotFound(h.logger, w, "view not found", nil)
+			return nil, false
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return nil, false
+	}
+
+	if view.BoardID != r.PathValue("boardID") {
+		helper.NotFound(h.logger, w, "view not found", nil)
+		return nil, false
+	}
+
+	return view, true
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/events"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func (h *handler) handleToggleCardVote(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+
+	payload := types.ToggleCardVote{
+		BoardID: r.PathValue("boardID"),
+		CardID:  r.PathValue("cardID"),
+		UserID:  user.ID,
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "failed validation on the request payload", err)
+		return
+	}
+
+	settings, err := h.store.GetVotingSettings(r.Context(), payload.BoardID)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	if !settings.Enabled {
+		helper.Forbidden(h.logger, w, "voting is disabled on this board", nil)
+		return
+	}
+
+	if settings.RestrictObservers {
+		member := helper.GetBoardMemberFromRequestContext(r)
+		if types.NormalizeRole(member.Role) == types.RoleObserver {
+			helper.Forbidden(h.logger, w, "observers cannot vote on this board", nil)
+			return
+		}
+	}
+
+	voted, err := h.store.ToggleCardVote(r.Context(), &payload)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.NotFound(h.logger, w, "card not found on this board", nil)
+			return
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	h.publish(r, events.CardVoteToggled, map[string]any{"cardID": payload.CardID, "userID": user.ID, "voted": voted})
+
+	helper.OK(h.logger, w, "vote toggled successfully", map[string]any{"voted": voted})
+}
+
+func (h *handler) handleListCardVoters(w http.ResponseWriter, r *http.Request) {
+	boardID := r.PathValue("boardID")
+	cardID := r.PathValue("cardID")
+	if err := h.validator.Var(cardID, "required,uuid"); err != nil {
+		helper.BadRequest(h.logger, w, "invalid card id", nil)
+		return
+	}
+
+	voters, err := h.store.ListCardVoters(r.Context(), boardID, cardID)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "voters fetched successfully", map[string]any{"voters": voters, "count": len(voters)})
+}
+
+func (h *handler) handleGetVotingSettings(w http.ResponseWriter, r *http.Request) {
+	settings, err := h.store.GetVotingSettings(r.Context(), r.PathValue("boardID"))
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "voting settings fetched successfully", settings)
+}
+
+func (h *handler) handleUpdateVotingSettings(w http.ResponseWriter, r *http.Request) {
+	var payload types.UpdateVotingSettings
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.UnprocessableEntity(h.logger, w, "invalid request payload", nil)
+		return
+	}
+
+	payload.BoardID = r.PathValue("boardID")
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "failed validation on the request payload", err)
+		return
+	}
+
+	settings, err := h.store.UpdateVotingSettings(r.Context(), &payload)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	h.publish(r, events.BoardUpdated, map[string]any{"voting": settings})
+
+	helper.OK(h.logger, w, "voting settings updated successfully", settings)
+}
+
+// sortCardsByVotes orders the cards of each list by vote count, most votes
+// first, falling back to the card position. Lists keep their board order.
+func sortCardsByVotes(board *types.BoardDetail) {
+	listIndex := make(map[string]int, len(board.Lists))
+	for i, list := range board.Lists {
+		listIndex[list.ID] = i
+	}
+
+	slices.SortStableFunc(board.Cards, func(a, b *types.MinimalCard) int {
+		if d := listIndex[a.ListID] - listIndex[b.ListID]; d != 0 {
+			return d
+		}
+		if d := b.VoteCount - a.VoteCount; d != 0 {
+			return d
+		}
+		switch {
+		case a.Position < b.Position:
+			return -1
+		case a.Position > b.Position:
+			return 1
+		}
+		return 0
+	})
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/events"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	m "github.com/vaidik-bajpai/Nexus/backend/internal/store/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func TestHandleToggleCardVote(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           string
+		settings       *types.VotingSettings
+		toggleErr      error
+		expectedStatus int
+	}{
+		{"voting disabled", types.RoleNormal, &types.VotingSettings{}, nil, http.StatusForbidden},
+		{"normal member", types.RoleNormal, &types.VotingSettings{Enabled: true, RestrictObservers: true}, nil, http.StatusOK},
+		{"observer when observers are restricted", types.RoleObserver, &types.VotingSettings{Enabled: true, RestrictObservers: true}, nil, http.StatusForbidden},
+		{"observer when observers may vote", types.RoleObserver, &types.VotingSettings{Enabled: true}, nil, http.StatusOK},
+		{"card on another board", types.RoleNormal, &types.VotingSettings{Enabled: true}, store.ErrNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(m.MockStore)
+			ms.On("GetVotingSettings", mock.Anything, testBoardID).Return(tt.settings, nil)
+			ms.On("ToggleCardVote", mock.Anything, &types.ToggleCardVote{BoardID: testBoardID, CardID: testCardID, UserID: testUserID}).
+				Return(true, tt.toggleErr).Maybe()
+			h := createTestHandler(ms, nil)
+			h.events = events.NewMemoryHub()
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.SetPathValue("boardID", testBoardID)
+			req.SetPathValue("cardID", testCardID)
+			req = helper.SetUserInRequestContext(req, &types.User{ID: testUserID})
+			req = helper.SetBoardMemberInRequestContext(req, &types.BoardMember{BoardID: testBoardID, UserID: testUserID, Role: tt.role})
+			rr := httptest.NewRecorder()
+
+			h.handleToggleCardVote(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusForbidden {
+				ms.AssertNotCalled(t, "ToggleCardVote", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestSortCardsByVotes(t *testing.T) {
+	board := &types.BoardDetail{
+		Lists: []*types.List{{ID: "todo"}, {ID: "done"}},
+		Cards: []*types.MinimalCard{
+			{ID: "d1", ListID: "done", VoteCount: 5, Position: 1},
+			{ID: "t1", ListID: "todo", VoteCount: 0, Position: 1},
+			{ID: "t2", ListID: "todo", VoteCount: 3, Position: 2},
+			{ID: "t3", ListID: "todo", VoteCount: 3, Position: 0.5},
+		},
+	}
+
+	sortCardsByVotes(board)
+
+	var ids []string
+	for _, card := range board.Cards {
+		ids = append(ids, card.ID)
+	}
+	assert.Equal(t, []string{"t3", "t2", "t1", "d1"}, ids)
+}
@@ -0,0 +1,322 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+// workspaceInvitationTTL matches the expiry promised in the invitation email.
+const workspaceInvitationTTL = 7 * 24 * time.Hour
+
+func (h *handler) handleCreateWorkspace(w http.ResponseWriter, r *http.Request) {
+	var payload types.CreateWorkspace
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.UnprocessableEntity(h.logger, w, "invalid request payload", nil)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "validation on request payload", nil)
+		return
+	}
+
+	payload.CreatorID = helper.GetUserFromRequestContext(r).ID
+
+	workspace, err := h.store.CreateWorkspace(r.Context(), &payload)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.Created(h.logger, w, "workspace created successfully", workspace)
+}
+
+func (h *handler) handleListWorkspaces(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+
+	workspaces, err := h.store.ListWorkspaces(r.Context(), user.ID)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "workspaces fetched successfully", map[string]any{"workspaces": workspaces})
+}
+
+func (h *handler) handleGetWorkspace(w http.ResponseWriter, r *http.Request) {
+	member := helper.GetWorkspaceMemberFromRequestContext(r)
+
+	workspace, err := h.store.GetWorkspace(r.Context(), member.WorkspaceID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.NotFound(h.logger, w, "workspace not found", nil)
+			return
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+	workspace.Role = member.Role
+
+	helper.OK(h.logger, w, "workspace fetched successfully", workspace)
+}
+
+func (h *handler) handleUpdateWorkspace(w http.ResponseWriter, r *http.Request) {
+	var payload types.UpdateWorkspace
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.UnprocessableEntity(h.logger, w, "invalid request payload", nil)
+		return
+	}
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "validation on request payload", nil)
+		return
+	}
+
+	payload.WorkspaceID = r.PathValue("workspaceID")
+
+	if err := h.store.UpdateWorkspace(r.Context(), &payload); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.NotFound(h.logger, w, "workspace not found", nil)
+			return
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "workspace updated successfully", nil)
+}
+
+func (h *handler) handleDeleteWorkspace(w http.ResponseWriter, r *http.Request) {
+	if err := h.store.DeleteWorkspace(r.Context(), r.PathValue("workspaceID")); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.NotFound(h.logger, w, "workspace not found", nil)
+			return
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "workspace deleted successfully", nil)
+}
+
+func (h *handler) handleListWorkspaceBoards(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+	paginate := helper.GetPaginateFromRequestContext(r)
+
+	boards, err := h.store.ListWorkspaceBoards(r.Context(), r.PathValue("workspaceID"), user.ID, paginate)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "boards fetched successfully", map[string]any{"boards": boards})
+}
+
+func (h *handler) handleInviteToWorkspace(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+	workspaceID := r.PathValue("workspaceID")
+
+	var payload types.WorkspaceInvitation
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.UnprocessableEntity(h.logger, w, "invalid request payload", nil)
+		return
+	}
+
+	token, err := helper.GenerateInvitationToken()
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	payload.WorkspaceID = workspaceID
+	payload.InvitedBy = user.ID
+	payload.Token = token
+	payload.ExpiredAt = time.Now().Add(workspaceInvitationTTL)
+
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "validation on request payload", nil)
+		return
+	}
+
+	workspace, err := h.store.GetWorkspace(r.Context(), workspaceID)
+	if err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	if err := h.store.CreateWorkspaceInvitation(r.Context(), &payload); err != nil {
+		if errors.Is(err, store.ErrAlreadyMember) {
+			helper.Conflict(h.logger, w, "member with this email already exists", nil)
+			return
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	if err := h.mailer.SendWorkspaceInvitationEmail(
+		[]string{payload.Email},
+		"Invitation to join nexus",
+		user.Username,
+		workspace.Name,
+		fmt.Sprintf("http://localhost:3000/workspaces/join?workspaceID=%s&token=%s", workspaceID, url.QueryEscape(token)),
+	); err != nil {
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "invitation email sent successfully", nil)
+}
+
+func (h *handler) handleAcceptWorkspaceInvite(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		helper.BadRequest(h.logger, w, "invalid request token", nil)
+		return
+	}
+
+	invitation, err := h.store.GetWorkspaceInvitationByToken(r.Context(), token)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.NotFound(h.logger, w, "invitation not found", nil)
+			return
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	if invitation.WorkspaceID != r.PathValue("workspaceID") || !strings.EqualFold(invitation.Email, user.Email) {
+		helper.Forbidden(h.logger, w, "this invitation was sent to another account", nil)
+		return
+	}
+
+	if err := h.store.AcceptWorkspaceInvitation(r.Context(), token, user.ID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			helper.NotFound(h.logger, w, "invitation not found", nil)
+		case errors.Is(err, store.ErrTokenExpired):
+			helper.BadRequest(h.logger, w, "invitation expired", nil)
+		case errors.Is(err, store.ErrAlreadyMember):
+			helper.Conflict(h.logger, w, "you are already a member of this workspace", nil)
+		default:
+			helper.InternalServerError(h.logger, w, nil, err)
+		}
+		return
+	}
+
+	helper.OK(h.logger, w, "invitation accepted successfully", nil)
+}
+
+func (h *handler) handleUpdateWorkspaceMemberRole(w http.ResponseWriter, r *http.Request) {
+	var payload types.UpdateWorkspaceMemberRole
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.UnprocessableEntity(h.logger, w, "invalid request payload", nil)
+		return
+	}
+
+	payload.WorkspaceID = r.PathValue("workspaceID")
+	payload.UserID = r.PathValue("userID")
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "validation on request payload", nil)
+		return
+	}
+
+	if err := h.store.UpdateWorkspaceMemberRole(r.Context(), &payload); err != nil {
+		h.writeWorkspaceMemberError(w, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "member role updated successfully", nil)
+}
+
+func (h *handler) handleRemoveWorkspaceMember(w http.ResponseWriter, r *http.Request) {
+	userID := r.PathValue("userID")
+	if err := h.validator.Var(userID, "required,uuid"); err != nil {
+		helper.BadRequest(h.logger, w, "invalid user id", nil)
+		return
+	}
+
+	if err := h.store.RemoveWorkspaceMember(r.Context(), r.PathValue("workspaceID"), userID); err != nil {
+		h.writeWorkspaceMemberError(w, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "member removed successfully", nil)
+}
+
+func (h *handler) handleLeaveWorkspace(w http.ResponseWriter, r *http.Request) {
+	member := helper.GetWorkspaceMemberFromRequestContext(r)
+
+	if err := h.store.RemoveWorkspaceMember(r.Context(), member.WorkspaceID, member.UserID); err != nil {
+		h.writeWorkspaceMemberError(w, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "left the workspace successfully", nil)
+}
+
+func (h *handler) writeWorkspaceMemberError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		helper.NotFound(h.logger, w, "member not found in this workspace", nil)
+	case errors.Is(err, store.ErrLastAdmin):
+		helper.Conflict(h.logger, w, "the workspace must keep at least one admin; promote another member first", nil)
+	default:
+		helper.InternalServerError(h.logger, w, nil, err)
+	}
+}
+
+// handleMoveBoard moves a board into a workspace. The caller must admin the
+// board and belong to the target workspace.
+func (h *handler) handleMoveBoard(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserFromRequestContext(r)
+
+	var payload types.MoveBoard
+	if err := helper.ReadJSON(r, &payload); err != nil {
+		helper.UnprocessableEntity(h.logger, w, "invalid request payload", nil)
+		return
+	}
+
+	payload.BoardID = r.PathValue("boardID")
+	if err := h.validator.Struct(payload); err != nil {
+		helper.BadRequest(h.logger, w, "validation on request payload", nil)
+		return
+	}
+
+	if !h.requireWorkspaceMember(w, r, payload.WorkspaceID, user.ID) {
+		return
+	}
+
+	if err := h.store.MoveBoardToWorkspace(r.Context(), &payload); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.NotFound(h.logger, w, "board not found", nil)
+			return
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return
+	}
+
+	helper.OK(h.logger, w, "board moved successfully", nil)
+}
+
+// requireWorkspaceMember writes a 403 and returns false unless the user
+// belongs to the workspace.
+func (h *handler) requireWorkspaceMember(w http.ResponseWriter, r *http.Request, workspaceID, userID string) bool {
+	if _, err := h.store.GetWorkspaceMember(r.Context(), workspaceID, userID); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.Forbidden(h.logger, w, "you are not a member of this workspace", nil)
+			return false
+		}
+		helper.InternalServerError(h.logger, w, nil, err)
+		return false
+	}
+	return true
+}
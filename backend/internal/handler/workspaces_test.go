@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	m "github.com/vaidik-bajpai/Nexus/backend/internal/store/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+const testWorkspaceID = "7a8b9c0d-1e2f-4a3b-8c4d-5e6f7a8b9c0d"
+
+func TestHandleAcceptWorkspaceInvite(t *testing.T) {
+	tests := []struct {
+		name           string
+		invitedEmail   string
+		workspaceID    string
+		expectedStatus int
+	}{
+		{
+			name:           "same email",
+			invitedEmail:   "jane@example.com",
+			workspaceID:    testWorkspaceID,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "email in another case",
+			invitedEmail:   "Jane@Example.com",
+			workspaceID:    testWorkspaceID,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "another email",
+			invitedEmail:   "john@example.com",
+			workspaceID:    testWorkspaceID,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "another workspace",
+			invitedEmail:   "jane@example.com",
+			workspaceID:    "another-workspace",
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(m.MockStore)
+			ms.On("GetWorkspaceInvitationByToken", mock.Anything, "invite-token").Return(&types.WorkspaceInvitation{
+				WorkspaceID: tt.workspaceID,
+				Email:       tt.invitedEmail,
+			}, nil)
+			ms.On("AcceptWorkspaceInvitation", mock.Anything, "invite-token", testUserID).Return(nil)
+			h := createTestHandler(ms, nil)
+
+			req := httptest.NewRequest(http.MethodPost, "/?token=invite-token", nil)
+			req.SetPathValue("workspaceID", testWorkspaceID)
+			req = helper.SetUserInRequestContext(req, &types.User{ID: testUserID, Email: "jane@example.com"})
+			rr := httptest.NewRecorder()
+
+			h.handleAcceptWorkspaceInvite(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus != http.StatusOK {
+				ms.AssertNotCalled(t, "AcceptWorkspaceInvitation", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
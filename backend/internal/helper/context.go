@@ -48,3 +48,31 @@ func GetBoardFromRequestContext(r *http.Request) *types.Board {
 
 	return board
 }
+
+func SetBoardMemberInRequestContext(r *http.Request, member *types.BoardMember) *http.Request {
+	ctx := context.WithValue(r.Context(), types.BoardMemberCtxKey, member)
+	return r.WithContext(ctx)
+}
+
+func GetBoardMemberFromRequestContext(r *http.Request) *types.BoardMember {
+	member, ok := r.Context().Value(types.BoardMemberCtxKey).(*types.BoardMember)
+	if !ok || member == nil {
+		panic("board member not found in the context")
+	}
+
+	return member
+}
+
+func SetWorkspaceMemberInRequestContext(r *http.Request, member *types.WorkspaceMember) *http.Request {
+	ctx := context.WithValue(r.Context(), types.WorkspaceMemberCtxKey, member)
+	return r.WithContext(ctx)
+}
+
+func GetWorkspaceMemberFromRequestContext(r *http.Request) *types.WorkspaceMember {
+	member, ok := r.Context().Value(types.WorkspaceMemberCtxKey).(*types.WorkspaceMember)
+	if !ok || member == nil {
+		panic("workspace member not found in the context")
+	}
+
+	return member
+}
@@ -25,3 +25,12 @@ func GetIntEnvOrPanic(key string) int {
 
 	return intEnv
 }
+
+func GetStrEnvOrDefault(key, fallback string) string {
+	val, ok := os.LookupEnv(key)
+	if !ok || val == "" {
+		return fallback
+	}
+
+	return val
+}
@@ -0,0 +1,42 @@
+package helper
+
+import (
+	"regexp"
+	"strings"
+)
+
+// mentionPattern matches @username where usernames follow the 3-32
+// character rule enforced at registration.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([A-Za-z0-9_.-]{3,32})`)
+
+// ParseMentions returns the distinct usernames mentioned in text.
+func ParseMentions(text string) []string {
+	seen := make(map[string]bool)
+	var usernames []string
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		username := strings.TrimRight(match[1], ".")
+		if len(username) < 3 || seen[username] {
+			continue
+		}
+		seen[username] = true
+		usernames = append(usernames, username)
+	}
+	return usernames
+}
+
+// NewMentions returns the usernames mentioned in updated but not in previous,
+// so editing a text does not notify the same people twice.
+func NewMentions(previous, updated string) []string {
+	old := make(map[string]bool)
+	for _, username := range ParseMentions(previous) {
+		old[username] = true
+	}
+
+	var res []string
+	for _, username := range ParseMentions(updated) {
+		if !old[username] {
+			res = append(res, username)
+		}
+	}
+	return res
+}
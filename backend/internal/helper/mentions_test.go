@@ -0,0 +1,33 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"no mentions", "just a comment", nil},
+		{"single mention", "@alice can you look?", []string{"alice"}},
+		{"duplicates", "@alice and @alice and @bob_1", []string{"alice", "bob_1"}},
+		{"trailing period", "thanks @carol.", []string{"carol"}},
+		{"email is not a mention", "mail dave@example.com", nil},
+		{"too short", "hi @al", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseMentions(tt.text))
+		})
+	}
+}
+
+func TestNewMentions(t *testing.T) {
+	assert.Equal(t, []string{"bob"}, NewMentions("@alice", "@alice @bob"))
+	assert.Nil(t, NewMentions("@alice @bob", "@bob"))
+}
@@ -0,0 +1,16 @@
+package helper
+
+import (
+	"net"
+	"net/http"
+)
+
+// ClientIP returns the host part of the request's remote address. Put
+// chi's RealIP middleware in front of the router to honour proxy headers.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -3,6 +3,8 @@ package helper
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
 	"go.uber.org/zap"
@@ -63,7 +65,11 @@ func InternalServerError(logger *zap.Logger, w http.ResponseWriter, data any, er
 }
 
 func Forbidden(logger *zap.Logger, w http.ResponseWriter, message string, data any) {
-	SendErrorResponse(logger, w, http.StatusInternalServerError, message, data, nil)
+	SendErrorResponse(logger, w, http.StatusForbidden, message, data, nil)
+}
+
+func NotFound(logger *zap.Logger, w http.ResponseWriter, message string, data any) {
+	SendErrorResponse(logger, w, http.StatusNotFound, message, data, nil)
 }
 
 func Created(logger *zap.Logger, w http.ResponseWriter, message string, data any) {
@@ -77,3 +83,15 @@ func OK(logger *zap.Logger, w http.ResponseWriter, message string, data any) {
 func Conflict(logger *zap.Logger, w http.ResponseWriter, message string, data any) {
 	SendErrorResponse(logger, w, http.StatusConflict, message, data, nil)
 }
+
+// TooManyRequests tells the client how long to back off in a Retry-After
+// header, rounded up to whole seconds.
+func TooManyRequests(logger *zap.Logger, w http.ResponseWriter, message string, retryAfter time.Duration) {
+	seconds := int64((retryAfter + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	w.Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+	SendErrorResponse(logger, w, http.StatusTooManyRequests, message, nil, nil)
+}
@@ -2,43 +2,96 @@ package helper
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"io"
-	mathrand "math/rand"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
 )
 
-func CreateRandomToken() int {
-	r := mathrand.New(mathrand.NewSource(time.Now().UnixNano()))
-	return r.Intn(1000000)
+// GenerateEmailToken returns an opaque, URL-safe token for the links in
+// verification and password reset emails. Verifying an address can let its
+// owner into boards they were invited to, so the token must not be guessable.
+func GenerateEmailToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
 }
 
-func GenerateAccessAndRefreshTokens(user *types.User) (string, string, error) {
+// RefreshTokenTTL is how long a session survives without being refreshed.
+const RefreshTokenTTL = 7 * 24 * time.Hour
+
+// GenerateAccessToken signs a short-lived JWT for the user. The user's
+// SessionID travels in the claims so revoking the session also invalidates
+// its access tokens.
+func GenerateAccessToken(user *types.User) (string, error) {
 	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"user": *user,
 		"exp":  time.Now().Add(24 * time.Hour).Unix(),
 	})
 
-	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user": *user,
-		"exp":  time.Now().Add(7 * 24 * time.Hour).Unix(),
-	})
+	return accessToken.SignedString([]byte(GetStrEnvOrPanic("ACCESS_TOKEN_SECRET")))
+}
 
-	accessTokenString, err := accessToken.SignedString([]byte(GetStrEnvOrPanic("ACCESS_TOKEN_SECRET")))
-	if err != nil {
+// GenerateRefreshToken returns an opaque refresh token and the hash under
+// which it is stored. Only the hash is persisted.
+func GenerateRefreshToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
 		return "", "", err
 	}
-	refreshTokenString, err := refreshToken.SignedString([]byte(GetStrEnvOrPanic("REFRESH_TOKEN_SECRET")))
+
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, HashToken(token), nil
+}
+
+// GenerateAPIToken returns a new personal access token, the hash under
+// which it is stored and the prefix shown to tell tokens apart.
+func GenerateAPIToken() (string, string, string, error) {
+	b := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", "", "", err
+	}
+
+	token := types.APITokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return token, HashToken(token), token[:len(types.APITokenPrefix)+6], nil
+}
+
+// GenerateShareToken returns a new board share token, the hash under which
+// it is stored and the prefix shown to tell share links apart.
+func GenerateShareToken() (string, string, string, error) {
+	token, err := GenerateInvitationToken()
 	if err != nil {
-		return "", "", err
+		return "", "", "", err
+	}
+
+	return token, HashToken(token), token[:6], nil
+}
+
+// GenerateInvitationToken returns an opaque, URL-safe token for an
+// invitation link.
+func GenerateInvitationToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", err
 	}
 
-	return accessTokenString, refreshTokenString, nil
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken returns the hex SHA-256 of a bearer token for storage and
+// lookup.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
 
 func VerifyToken(tokenString string, tokenSecret string) (*types.User, error) {
@@ -74,6 +127,87 @@ func VerifyToken(tokenString string, tokenSecret string) (*types.User, error) {
 	return nil, errors.New("invalid token")
 }
 
+// accountLinkAudience keeps account link tokens from being accepted as
+// access tokens and the other way around.
+const accountLinkAudience = "account_link"
+
+// GenerateAccountLinkToken signs claims that carry an account link or
+// re-authentication across an identity provider redirect.
+func GenerateAccountLinkToken(claims *types.AccountLinkClaims, ttl time.Duration) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"link": *claims,
+		"aud":  accountLinkAudience,
+		"exp":  time.Now().Add(ttl).Unix(),
+	})
+
+	return token.SignedString([]byte(GetStrEnvOrPanic("ACCESS_TOKEN_SECRET")))
+}
+
+// VerifyAccountLinkToken checks a token from GenerateAccountLinkToken and
+// that it was issued for the given purpose.
+func VerifyAccountLinkToken(tokenString, purpose string) (*types.AccountLinkClaims, error) {
+	var claims struct {
+		jwt.RegisteredClaims
+		Link *types.AccountLinkClaims `json:"link"`
+	}
+
+	_, err := jwt.ParseWithClaims(tokenString, &claims,
+		func(token *jwt.Token) (any, error) {
+			return []byte(GetStrEnvOrPanic("ACCESS_TOKEN_SECRET")), nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithAudience(accountLinkAudience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Link == nil || claims.Link.Purpose != purpose || claims.Link.UserID == "" {
+		return nil, errors.New("invalid token: wrong account link purpose")
+	}
+
+	return claims.Link, nil
+}
+
+// twoFactorChallengeAudience marks tokens that only prove the password step
+// of a two-step login.
+const twoFactorChallengeAudience = "2fa_challenge"
+
+// GenerateTwoFactorChallenge signs a short-lived token for a user who passed
+// the first login step and still owes a second factor.
+func GenerateTwoFactorChallenge(userID string, ttl time.Duration) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   userID,
+		Audience:  jwt.ClaimStrings{twoFactorChallengeAudience},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+	})
+
+	return token.SignedString([]byte(GetStrEnvOrPanic("ACCESS_TOKEN_SECRET")))
+}
+
+// VerifyTwoFactorChallenge checks a token from GenerateTwoFactorChallenge
+// and returns the user it was issued to.
+func VerifyTwoFactorChallenge(tokenString string) (string, error) {
+	var claims jwt.RegisteredClaims
+	_, err := jwt.ParseWithClaims(tokenString, &claims,
+		func(token *jwt.Token) (any, error) {
+			return []byte(GetStrEnvOrPanic("ACCESS_TOKEN_SECRET")), nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithAudience(twoFactorChallengeAudience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	if claims.Subject == "" {
+		return "", errors.New("invalid token: challenge has no subject")
+	}
+	return claims.Subject, nil
+}
+
 func GenerateOAuthState() (string, error) {
 	nonceBytes := make([]byte, 64)
 	_, err := io.ReadFull(rand.Reader, nonceBytes)
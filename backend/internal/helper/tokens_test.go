@@ -0,0 +1,33 @@
+package helper
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateEmailToken(t *testing.T) {
+	seen := map[string]bool{}
+	for range 100 {
+		token, err := GenerateEmailToken()
+		require.NoError(t, err)
+
+		raw, err := base64.RawURLEncoding.DecodeString(token)
+		require.NoError(t, err)
+		assert.Len(t, raw, 32)
+
+		assert.False(t, seen[token], "tokens must not repeat")
+		seen[token] = true
+	}
+}
+
+func TestGenerateShareToken(t *testing.T) {
+	token, hash, prefix, err := GenerateShareToken()
+	require.NoError(t, err)
+
+	assert.Equal(t, HashToken(token), hash)
+	assert.Equal(t, token[:6], prefix)
+	assert.NotEqual(t, token, hash)
+}
@@ -0,0 +1,84 @@
+package helper
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfc6238Secret is the SHA-1 key from the RFC 6238 test vectors.
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestValidateTOTP(t *testing.T) {
+	// RFC 6238 appendix B, truncated to six digits
+	vectors := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, v := range vectors {
+		step, ok := ValidateTOTP(rfc6238Secret, v.code, time.Unix(v.unix, 0))
+		assert.True(t, ok, "code %s at %d", v.code, v.unix)
+		assert.Equal(t, v.unix/totpPeriod, step)
+	}
+}
+
+func TestValidateTOTPSkew(t *testing.T) {
+	at := time.Unix(1111111109, 0)
+
+	// a step late is accepted and reports the step it matched
+	step, ok := ValidateTOTP(rfc6238Secret, "081804", at.Add(totpPeriod*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, at.Unix()/totpPeriod, step)
+
+	_, ok = ValidateTOTP(rfc6238Secret, "081804", at.Add(3*totpPeriod*time.Second))
+	assert.False(t, ok)
+
+	_, ok = ValidateTOTP(rfc6238Secret, "000000", at)
+	assert.False(t, ok)
+
+	_, ok = ValidateTOTP(rfc6238Secret, "81804", at)
+	assert.False(t, ok)
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := TOTPURI("Nexus", "ada@example.com", "JBSWY3DPEHPK3PXP")
+	assert.Equal(t, "otpauth://totp/Nexus:ada@example.com?algorithm=SHA1&digits=6&issuer=Nexus&period=30&secret=JBSWY3DPEHPK3PXP", uri)
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes()
+	require.NoError(t, err)
+	require.Len(t, codes, recoveryCodeCount)
+
+	seen := map[string]bool{}
+	for _, code := range codes {
+		assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, code)
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+
+	assert.Equal(t, HashRecoveryCode("abcde-fghij"), HashRecoveryCode(" ABCDE FGHIJ"))
+}
+
+func TestEncryptSecret(t *testing.T) {
+	sealed, err := EncryptSecret("passphrase", "JBSWY3DPEHPK3PXP")
+	require.NoError(t, err)
+	assert.NotContains(t, sealed, "JBSWY3DPEHPK3PXP")
+
+	secret, err := DecryptSecret("passphrase", sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", secret)
+
+	_, err = DecryptSecret("another passphrase", sealed)
+	assert.Error(t, err)
+}
@@ -20,9 +20,13 @@ var passwordResetTemplate string
 //go:embed templates/board-invitation.tmpl
 var boardInvitationTemplateString string
 
+//go:embed templates/workspace-invitation.tmpl
+var workspaceInvitationTemplateString string
+
 var emailTemplate *template.Template
 var passwordResetEmailTemplate *template.Template
 var boardInvitationTemplate *template.Template
+var workspaceInvitationTemplate *template.Template
 
 func init() {
 	var err error
@@ -40,6 +44,11 @@ func init() {
 	if err != nil {
 		panic("failed to parse board invitation template: " + err.Error())
 	}
+
+	workspaceInvitationTemplate, err = template.New("workspace-invitation").Parse(workspaceInvitationTemplateString)
+	if err != nil {
+		panic("failed to parse workspace invitation template: " + err.Error())
+	}
 }
 
 // Mailer defines the interface for sending emails
@@ -47,6 +56,7 @@ type Mailer interface {
 	SendEmailVerificationEmail(to []string, subject string, verificationURL string) error
 	SendPasswordResetEmail(to []string, subject string, passwordResetURL string) error
 	SendBoardInvitationEmail(to []string, subject, inviterName, boardName, invitationURL string) error
+	SendWorkspaceInvitationEmail(to []string, subject, inviterName, workspaceName, invitationURL string) error
 }
 
 // SMTPMailer implements the Mailer interface using SMTP
@@ -73,6 +83,13 @@ type BoardInvitationData struct {
 	Year          int
 }
 
+type WorkspaceInvitationData struct {
+	InviterName   string
+	WorkspaceName string
+	InvitationURL string
+	Year          int
+}
+
 // SendEmailVerificationEmail sends an email verification email
 func (m *SMTPMailer) SendEmailVerificationEmail(to []string, subject string, verificationURL string) error {
 	data := EmailData{
@@ -197,6 +214,48 @@ func (m *SMTPMailer) SendBoardInvitationEmail(to []string, subject, inviterName,
 	)
 }
 
+func (m *SMTPMailer) SendWorkspaceInvitationEmail(to []string, subject, inviterName, workspaceName, invitationURL string) error {
+	data := WorkspaceInvitationData{
+		InviterName:   inviterName,
+		WorkspaceName: workspaceName,
+		InvitationURL: invitationURL,
+		Year:          time.Now().Year(),
+	}
+
+	var buf bytes.Buffer
+	if err := workspaceInvitationTemplate.Execute(&buf, data); err != nil {
+		return err
+	}
+
+	htmlBody := buf.String()
+
+	fromEmail := helper.GetStrEnvOrPanic("FROM_EMAIL")
+
+	// Format email message with proper headers
+	msg := fmt.Sprintf("From: %s\r\n", fromEmail)
+	msg += fmt.Sprintf("To: %s\r\n", to[0])
+	msg += fmt.Sprintf("Subject: %s\r\n", subject)
+	msg += "MIME-Version: 1.0\r\n"
+	msg += "Content-Type: text/html; charset=UTF-8\r\n"
+	msg += "\r\n"
+	msg += htmlBody
+
+	auth := smtp.PlainAuth(
+		"",
+		fromEmail,
+		helper.GetStrEnvOrPanic("FROM_EMAIL_PASSWORD"),
+		helper.GetStrEnvOrPanic("FROM_EMAIL_SMTP"),
+	)
+
+	return smtp.SendMail(
+		helper.GetStrEnvOrPanic("SMTP_ADDR"),
+		auth,
+		fromEmail,
+		to,
+		[]byte(msg),
+	)
+}
+
 // SendEmailVerificationEmail is a convenience function that uses the default SMTP mailer
 // Deprecated: Use Mailer interface instead
 func SendEmailVerificationEmail(to []string, subject string, verificationURL string) error {
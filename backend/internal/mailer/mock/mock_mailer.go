@@ -22,3 +22,8 @@ func (m *MockMailer) SendBoardInvitationEmail(to []string, subject, inviterName,
 	args := m.Called(to, subject, inviterName, boardName, invitationURL)
 	return args.Error(0)
 }
+
+func (m *MockMailer) SendWorkspaceInvitationEmail(to []string, subject, inviterName, workspaceName, invitationURL string) error {
+	args := m.Called(to, subject, inviterName, workspaceName, invitationURL)
+	return args.Error(0)
+}
@@ -24,8 +24,6 @@ func (m *Middleware) VerifyAccessToken(next http.Handler) http.Handler {
 			return
 		}
 
-		m.logger.Info("token", zap.String("token", token))
-
 		token = strings.TrimPrefix(token, "Bearer ")
 		if token == "" {
 			helper.WriteJSON(w, http.StatusUnauthorized, &types.Response{
@@ -35,6 +33,23 @@ func (m *Middleware) VerifyAccessToken(next http.Handler) http.Handler {
 			return
 		}
 
+		// personal access tokens are opaque and looked up by hash; routes
+		// check their scopes with RequireScope
+		if strings.HasPrefix(token, types.APITokenPrefix) {
+			usr, err := m.store.GetUserByAPIToken(r.Context(), helper.HashToken(token))
+			if err != nil {
+				m.logger.Info("failed to verify api token", zap.Error(err))
+				helper.WriteJSON(w, http.StatusUnauthorized, &types.Response{
+					Status:  http.StatusUnauthorized,
+					Message: "unauthorized",
+				})
+				return
+			}
+
+			next.ServeHTTP(w, helper.SetUserInRequestContext(r, usr))
+			return
+		}
+
 		user, err := helper.VerifyToken(token, helper.GetStrEnvOrPanic("ACCESS_TOKEN_SECRET"))
 		if err != nil {
 			log.Println("error verifying access token", err)
@@ -55,6 +70,19 @@ func (m *Middleware) VerifyAccessToken(next http.Handler) http.Handler {
 			return
 		}
 
+		// access tokens carry the ID of the session that issued them; reject
+		// tokens whose session was revoked or expired since
+		active, err := m.store.IsSessionActive(r.Context(), usr.ID, user.SessionID)
+		if err != nil || !active {
+			m.logger.Info("session is not active", zap.String("sessionID", user.SessionID), zap.Error(err))
+			helper.WriteJSON(w, http.StatusUnauthorized, &types.Response{
+				Status:  http.StatusUnauthorized,
+				Message: "unauthorized",
+			})
+			return
+		}
+		usr.SessionID = user.SessionID
+
 		log.Println("user", usr)
 
 		next.ServeHTTP(w, helper.SetUserInRequestContext(r, usr))
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	m "github.com/vaidik-bajpai/Nexus/backend/internal/store/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestVerifyAccessTokenWithAPIToken(t *testing.T) {
+	const token = types.APITokenPrefix + "secretsecretsecret"
+
+	tests := []struct {
+		name           string
+		user           *types.User
+		err            error
+		expectedStatus int
+	}{
+		{"known token", &types.User{ID: "u1"}, nil, http.StatusOK},
+		{"unknown token", nil, store.ErrNotFound, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(m.MockStore)
+			ms.On("GetUserByAPIToken", mock.Anything, helper.HashToken(token)).Return(tt.user, tt.err)
+
+			core, logs := observer.New(zapcore.DebugLevel)
+			mw := &Middleware{store: ms, validator: validator.New(), logger: zap.New(core)}
+
+			var got *types.User
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = helper.GetUserFromRequestContext(r)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			rr := httptest.NewRecorder()
+
+			mw.VerifyAccessToken(next).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, tt.user, got)
+			for _, entry := range logs.All() {
+				assert.NotContains(t, entry.Message, token)
+				for _, field := range entry.Context {
+					assert.False(t, strings.Contains(field.String, token), "token logged in field %q", field.Key)
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyAccessTokenSession(t *testing.T) {
+	t.Setenv("ACCESS_TOKEN_SECRET", "test-secret")
+
+	user := &types.User{ID: "u1", Email: "jane@example.com", SessionID: "s1"}
+	token, err := helper.GenerateAccessToken(user)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		active         bool
+		err            error
+		expectedStatus int
+	}{
+		{"active session", true, nil, http.StatusOK},
+		{"revoked session", false, nil, http.StatusUnauthorized},
+		{"session lookup fails", false, errors.New("db down"), http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(m.MockStore)
+			ms.On("GetUserByEmail", mock.Anything, user.Email).Return(&types.User{ID: user.ID, Email: user.Email}, nil)
+			ms.On("IsSessionActive", mock.Anything, user.ID, user.SessionID).Return(tt.active, tt.err)
+
+			core, logs := observer.New(zapcore.DebugLevel)
+			mw := &Middleware{store: ms, validator: validator.New(), logger: zap.New(core)}
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			rr := httptest.NewRecorder()
+
+			mw.VerifyAccessToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus == http.StatusUnauthorized {
+				entries := logs.FilterMessage("session is not active").All()
+				if assert.Len(t, entries, 1) && tt.err != nil {
+					assert.Equal(t, tt.err.Error(), entries[0].ContextMap()["error"])
+				}
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"github.com/go-playground/validator/v10"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/ratelimit"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
 	"go.uber.org/zap"
 )
@@ -10,8 +11,9 @@ type Middleware struct {
 	store     store.Storer
 	validator *validator.Validate
 	logger    *zap.Logger
+	limiter   *ratelimit.Limiter
 }
 
-func NewMiddleware(store *store.Store, l *zap.Logger, v *validator.Validate) *Middleware {
-	return &Middleware{store: store, validator: v, logger: l}
+func NewMiddleware(store *store.Store, l *zap.Logger, v *validator.Validate, limiter *ratelimit.Limiter) *Middleware {
+	return &Middleware{store: store, validator: v, logger: l, limiter: limiter}
 }
@@ -1,51 +1,77 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
 )
 
+// IsMember lets any member of the board in the path through, observers
+// included.
+func (m *Middleware) IsMember(next http.Handler) http.Handler {
+	return m.RequireBoardPermission(types.PermissionView)(next)
+}
+
+// CanEdit lets admins and normal members through; observers are read-only.
+func (m *Middleware) CanEdit(next http.Handler) http.Handler {
+	return m.RequireBoardPermission(types.PermissionEdit)(next)
+}
+
+// IsAdmin lets only board admins through.
 func (m *Middleware) IsAdmin(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		user := helper.GetUserFromRequestContext(r)
-		boardID := r.PathValue("boardID")
-		if err := m.validator.Var(boardID, "uuid"); err != nil || boardID == "" {
-			helper.BadRequest(m.logger, w, "invalid boardID", nil)
-			return
-		}
+	return m.RequireBoardPermission(types.PermissionManage)(next)
+}
 
-		boardMember, err := m.store.GetBoardMember(r.Context(), boardID, user.ID)
-		if err != nil {
-			helper.InternalServerError(m.logger, w, nil, err)
-			return
-		}
+// RequireBoardPermission checks the caller's role on the board in the path
+// against the permission matrix in types. Workspace members reading a team
+// board act as observers. The membership is stored in the
+// request context, so stacking these middlewares costs one lookup.
+func (m *Middleware) RequireBoardPermission(permission types.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			boardMember, ok := m.boardMember(w, r)
+			if !ok {
+				return
+			}
 
-		if boardMember.Role != "admin" {
-			helper.Forbidden(m.logger, w, "you are forbidden to make this action", nil)
-			return
-		}
+			if !boardMember.Can(permission) {
+				helper.Forbidden(m.logger, w, "you are forbidden to make this action", nil)
+				return
+			}
 
-		next.ServeHTTP(w, helper.SetBoardInRequestContext(r, &types.Board{Name: boardMember.BoardName}))
-	})
+			r = helper.SetBoardMemberInRequestContext(r, boardMember)
+			next.ServeHTTP(w, helper.SetBoardInRequestContext(r, &types.Board{ID: boardMember.BoardID, Name: boardMember.BoardName}))
+		})
+	}
 }
 
-func (m *Middleware) IsMember(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		user := helper.GetUserFromRequestContext(r)
-		boardID := r.PathValue("boardID")
-		if err := m.validator.Var(boardID, "uuid"); err != nil || boardID == "" {
-			helper.BadRequest(m.logger, w, "invalid boardID", nil)
-			return
-		}
+// boardMember returns the caller's membership of the board in the path,
+// reusing one already resolved earlier in the chain.
+func (m *Middleware) boardMember(w http.ResponseWriter, r *http.Request) (*types.BoardMember, bool) {
+	user := helper.GetUserFromRequestContext(r)
+	boardID := r.PathValue("boardID")
+	if err := m.validator.Var(boardID, "uuid"); err != nil || boardID == "" {
+		helper.BadRequest(m.logger, w, "invalid boardID", nil)
+		return nil, false
+	}
+
+	if member, ok := r.Context().Value(types.BoardMemberCtxKey).(*types.BoardMember); ok && member != nil &&
+		member.BoardID == boardID && member.UserID == user.ID {
+		return member, true
+	}
 
-		boardMember, err := m.store.GetBoardMember(r.Context(), boardID, user.ID)
-		if err != nil {
-			helper.InternalServerError(m.logger, w, nil, err)
-			return
+	boardMember, err := m.store.GetBoardAccess(r.Context(), boardID, user.ID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			helper.Forbidden(m.logger, w, "you are not a member of this board", nil)
+			return nil, false
 		}
+		helper.InternalServerError(m.logger, w, nil, err)
+		return nil, false
+	}
 
-		next.ServeHTTP(w, helper.SetBoardInRequestContext(r, &types.Board{Name: boardMember.BoardName}))
-	})
+	return boardMember, true
 }
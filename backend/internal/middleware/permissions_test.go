@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	m "github.com/vaidik-bajpai/Nexus/backend/internal/store/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+	"go.uber.org/zap"
+)
+
+func TestRequireBoardPermission(t *testing.T) {
+	boardID := uuid.NewString()
+
+	tests := []struct {
+		name       string
+		role       string
+		permission types.Permission
+		want       int
+	}{
+		{"observer views", types.RoleObserver, types.PermissionView, http.StatusOK},
+		{"observer cannot edit", types.RoleObserver, types.PermissionEdit, http.StatusForbidden},
+		{"normal edits", types.RoleNormal, types.PermissionEdit, http.StatusOK},
+		{"legacy member edits", types.RoleMemberLegacy, types.PermissionEdit, http.StatusOK},
+		{"normal cannot manage", types.RoleNormal, types.PermissionManage, http.StatusForbidden},
+		{"admin manages", types.RoleAdmin, types.PermissionManage, http.StatusOK},
+		{"non-member", "", types.PermissionView, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(m.MockStore)
+			if tt.role == "" {
+				ms.On("GetBoardAccess", mock.Anything, boardID, "u").Return(nil, store.ErrNotFound)
+			} else {
+				ms.On("GetBoardAccess", mock.Anything, boardID, "u").
+					Return(&types.BoardMember{BoardID: boardID, UserID: "u", Role: tt.role}, nil)
+			}
+
+			mw := &Middleware{store: ms, validator: validator.New(), logger: zap.NewNop()}
+			handler := mw.RequireBoardPermission(tt.permission)(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+			)
+
+			req := helper.SetUserInRequestContext(httptest.NewRequest(http.MethodPost, "/", nil), &types.User{ID: "u"})
+			req.SetPathValue("boardID", boardID)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.want, rr.Code)
+		})
+	}
+}
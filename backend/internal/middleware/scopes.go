@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+)
+
+// RequireScope admits personal access tokens that hold the read scope for
+// GET and HEAD requests and the write scope for anything else. Session users
+// pass. It runs after VerifyAccessToken.
+func (m *Middleware) RequireScope(read, write string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scope := write
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				scope = read
+			}
+
+			if !helper.GetUserFromRequestContext(r).HasScope(scope) {
+				helper.Forbidden(m.logger, w, "token is missing the "+scope+" scope", nil)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireSession refuses personal access tokens, for account and security
+// routes that no scope grants. It runs after VerifyAccessToken.
+func (m *Middleware) RequireSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if helper.GetUserFromRequestContext(r).APITokenID != "" {
+			helper.Forbidden(m.logger, w, "this endpoint requires signing in, api tokens are not accepted", nil)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+	"go.uber.org/zap"
+)
+
+func TestRequireScope(t *testing.T) {
+	m := &Middleware{logger: zap.NewNop()}
+	handler := m.RequireScope(types.ScopeCardsRead, types.ScopeCardsWrite)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+	)
+
+	tests := []struct {
+		name   string
+		method string
+		user   *types.User
+		want   int
+	}{
+		{"session user", http.MethodPost, &types.User{ID: "u"}, http.StatusOK},
+		{"read scope reads", http.MethodGet, &types.User{ID: "u", APITokenID: "t", Scopes: []string{types.ScopeCardsRead}}, http.StatusOK},
+		{"read scope cannot write", http.MethodPost, &types.User{ID: "u", APITokenID: "t", Scopes: []string{types.ScopeCardsRead}}, http.StatusForbidden},
+		{"write scope implies read", http.MethodGet, &types.User{ID: "u", APITokenID: "t", Scopes: []string{types.ScopeCardsWrite}}, http.StatusOK},
+		{"other resource", http.MethodGet, &types.User{ID: "u", APITokenID: "t", Scopes: []string{types.ScopeBoardsWrite}}, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := helper.SetUserInRequestContext(httptest.NewRequest(tt.method, "/", nil), tt.user)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.want, rr.Code)
+		})
+	}
+}
+
+func TestRequireSession(t *testing.T) {
+	m := &Middleware{logger: zap.NewNop()}
+	handler := m.RequireSession(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, helper.SetUserInRequestContext(httptest.NewRequest(http.MethodGet, "/", nil), &types.User{ID: "u", APITokenID: "t"}))
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, helper.SetUserInRequestContext(httptest.NewRequest(http.MethodGet, "/", nil), &types.User{ID: "u"}))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+)
+
+// IsSharedBoard lets anyone read the board in the path if it is public, or
+// if the request carries a live share token for it in the token query
+// parameter. No user is required.
+func (m *Middleware) IsSharedBoard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		boardID := r.PathValue("boardID")
+		if err := m.validator.Var(boardID, "required,uuid"); err != nil {
+			helper.BadRequest(m.logger, w, "invalid boardID", nil)
+			return
+		}
+
+		// share tokens are stored hashed
+		var tokenHash string
+		if token := r.URL.Query().Get("token"); token != "" {
+			tokenHash = helper.HashToken(token)
+		}
+
+		board, err := m.store.GetSharedBoard(r.Context(), boardID, tokenHash)
+		if err != nil {
+			switch {
+			case errors.Is(err, store.ErrNotFound):
+				helper.NotFound(m.logger, w, "board not found", nil)
+			case errors.Is(err, store.ErrTokenExpired):
+				helper.Forbidden(m.logger, w, "share link expired", nil)
+			default:
+				helper.InternalServerError(m.logger, w, nil, err)
+			}
+			return
+		}
+
+		next.ServeHTTP(w, helper.SetBoardInRequestContext(r, board))
+	})
+}
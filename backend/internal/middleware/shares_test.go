@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	m "github.com/vaidik-bajpai/Nexus/backend/internal/store/mock"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+	"go.uber.org/zap"
+)
+
+func TestIsSharedBoard(t *testing.T) {
+	boardID := uuid.NewString()
+
+	tests := []struct {
+		name      string
+		token     string
+		tokenHash string
+		err       error
+		want      int
+	}{
+		{"public or shared", "abc", helper.HashToken("abc"), nil, http.StatusOK},
+		{"private without token", "", "", store.ErrNotFound, http.StatusNotFound},
+		{"expired token", "abc", helper.HashToken("abc"), store.ErrTokenExpired, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := new(m.MockStore)
+			if tt.err != nil {
+				ms.On("GetSharedBoard", mock.Anything, boardID, tt.tokenHash).Return(nil, tt.err)
+			} else {
+				ms.On("GetSharedBoard", mock.Anything, boardID, tt.tokenHash).
+					Return(&types.Board{ID: boardID, Visibility: types.BoardVisibilityPublic}, nil)
+			}
+
+			mw := &Middleware{store: ms, validator: validator.New(), logger: zap.NewNop()}
+			handler := mw.IsSharedBoard(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+			)
+
+			req := httptest.NewRequest(http.MethodGet, "/?token="+tt.token, nil)
+			req.SetPathValue("boardID", boardID)
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.want, rr.Code)
+			ms.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/helper"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/store"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+// IsWorkspaceMember lets any member of the workspace in the path through.
+func (m *Middleware) IsWorkspaceMember(next http.Handler) http.Handler {
+	return m.requireWorkspaceRole(false)(next)
+}
+
+// IsWorkspaceAdmin lets only admins of the workspace in the path through.
+func (m *Middleware) IsWorkspaceAdmin(next http.Handler) http.Handler {
+	return m.requireWorkspaceRole(true)(next)
+}
+
+func (m *Middleware) requireWorkspaceRole(admin bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := helper.GetUserFromRequestContext(r)
+			workspaceID := r.PathValue("workspaceID")
+			if err := m.validator.Var(workspaceID, "required,uuid"); err != nil {
+				helper.BadRequest(m.logger, w, "invalid workspaceID", nil)
+				return
+			}
+
+			member, err := m.store.GetWorkspaceMember(r.Context(), workspaceID, user.ID)
+			if err != nil {
+				if errors.Is(err, store.ErrNotFound) {
+					helper.Forbidden(m.logger, w, "you are not a member of this workspace", nil)
+					return
+				}
+				helper.InternalServerError(m.logger, w, nil, err)
+				return
+			}
+
+			if admin && member.Role != types.WorkspaceRoleAdmin {
+				helper.Forbidden(m.logger, w, "you are forbidden to make this action", nil)
+				return
+			}
+
+			next.ServeHTTP(w, helper.SetWorkspaceMemberInRequestContext(r, member))
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package oauth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+
+// reservedNames would collide with static routes under /users.
+var reservedNames = map[string]bool{"me": true, "oauth": true}
+
+// LoadConfig reads the provider list. When OAUTH_CONFIG_FILE is set it
+// names a JSON array of Config. Otherwise providers come from the
+// environment:
+//
+//	OAUTH_PROVIDERS=github,acme
+//	OAUTH_ACME_TYPE=oidc
+//	OAUTH_ACME_ISSUER=https://sso.acme.com
+//	OAUTH_ACME_CLIENT_ID=...
+//	OAUTH_ACME_CLIENT_SECRET=...
+//	OAUTH_ACME_REDIRECT_URL=...   (optional)
+//	OAUTH_ACME_SCOPES=openid,email (optional)
+//
+// The type defaults to the provider name for github and google. For
+// backwards compatibility GOOGLE_CLIENT_ID, GOOGLE_CLIENT_SECRET and
+// GOOGLE_REDIRECT_URL still configure google when it is not listed.
+//
+// Redirect URLs default to the provider's callback route under publicURL.
+func LoadConfig(publicURL string) ([]Config, error) {
+	var cfgs []Config
+
+	if path := os.Getenv("OAUTH_CONFIG_FILE"); path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(raw, &cfgs); err != nil {
+			return nil, fmt.Errorf("oauth: parse %s: %w", path, err)
+		}
+	} else {
+		for _, name := range strings.Split(os.Getenv("OAUTH_PROVIDERS"), ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			cfgs = append(cfgs, configFromEnv(name))
+		}
+	}
+
+	if os.Getenv("GOOGLE_CLIENT_ID") != "" && !hasProvider(cfgs, TypeGoogle) {
+		cfgs = append(cfgs, Config{
+			Name:         TypeGoogle,
+			Type:         TypeGoogle,
+			ClientID:     os.Getenv("GOOGLE_CLIENT_ID"),
+			ClientSecret: os.Getenv("GOOGLE_CLIENT_SECRET"),
+			RedirectURL:  os.Getenv("GOOGLE_REDIRECT_URL"),
+		})
+	}
+
+	for i := range cfgs {
+		cfg := &cfgs[i]
+		if !validName.MatchString(cfg.Name) || reservedNames[cfg.Name] {
+			return nil, fmt.Errorf("oauth: invalid provider name %q", cfg.Name)
+		}
+		if cfg.Type == "" && (cfg.Name == TypeGitHub || cfg.Name == TypeGoogle) {
+			cfg.Type = cfg.Name
+		}
+		if cfg.RedirectURL == "" {
+			cfg.RedirectURL = strings.TrimSuffix(publicURL, "/") + "/api/v1/users/" + cfg.Name + "/callback"
+		}
+	}
+
+	return cfgs, nil
+}
+
+func configFromEnv(name string) Config {
+	prefix := "OAUTH_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+
+	cfg := Config{
+		Name:         name,
+		Type:         os.Getenv(prefix + "TYPE"),
+		ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+		ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+		RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+		Issuer:       os.Getenv(prefix + "ISSUER"),
+		AuthURL:      os.Getenv(prefix + "AUTH_URL"),
+		TokenURL:     os.Getenv(prefix + "TOKEN_URL"),
+		APIURL:       os.Getenv(prefix + "API_URL"),
+	}
+	if scopes := os.Getenv(prefix + "SCOPES"); scopes != "" {
+		cfg.Scopes = strings.Split(scopes, ",")
+	}
+	return cfg
+}
+
+func hasProvider(cfgs []Config, name string) bool {
+	for _, cfg := range cfgs {
+		if cfg.Name == name {
+			return true
+		}
+	}
+	return false
+}
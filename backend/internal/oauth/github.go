@@ -0,0 +1,142 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/github"
+)
+
+// githubProvider signs in with GitHub's OAuth apps, which predate OIDC:
+// the profile comes from the REST API instead of an ID token.
+type githubProvider struct {
+	name   string
+	cfg    oauth2.Config
+	apiURL string
+	client *http.Client
+}
+
+func newGitHubProvider(cfg Config, client *http.Client) *githubProvider {
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"read:user", "user:email"}
+	}
+
+	endpoint := github.Endpoint
+	if cfg.AuthURL != "" {
+		endpoint.AuthURL = cfg.AuthURL
+	}
+	if cfg.TokenURL != "" {
+		endpoint.TokenURL = cfg.TokenURL
+	}
+
+	apiURL := cfg.APIURL
+	if apiURL == "" {
+		apiURL = "https://api.github.com"
+	}
+
+	return &githubProvider{
+		name: cfg.Name,
+		cfg: oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Scopes:       scopes,
+			Endpoint:     endpoint,
+		},
+		apiURL: strings.TrimSuffix(apiURL, "/"),
+		client: client,
+	}
+}
+
+func (p *githubProvider) Name() string {
+	return p.name
+}
+
+func (p *githubProvider) AuthCodeURL(_ context.Context, state, _, verifier string) (string, error) {
+	return p.cfg.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier)), nil
+}
+
+type githubUser struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+type githubEmail struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+func (p *githubProvider) Exchange(ctx context.Context, code, _, verifier string) (*Profile, error) {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.client)
+	token, err := p.cfg.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, err
+	}
+
+	client := p.cfg.Client(ctx, token)
+
+	var user githubUser
+	if err := p.get(ctx, client, "/user", &user); err != nil {
+		return nil, err
+	}
+
+	// /user only shows the public email, which may be unset or
+	// unverified; the emails endpoint tells us which one GitHub verified
+	var emails []githubEmail
+	if err := p.get(ctx, client, "/user/emails", &emails); err != nil {
+		return nil, err
+	}
+
+	profile := &Profile{
+		Subject:  strconv.FormatInt(user.ID, 10),
+		Email:    user.Email,
+		Name:     user.Name,
+		Username: user.Login,
+		Picture:  user.AvatarURL,
+	}
+
+	for _, e := range emails {
+		if e.Primary && e.Verified {
+			profile.Email = e.Email
+			profile.EmailVerified = true
+			break
+		}
+	}
+
+	if profile.Email == "" {
+		return nil, fmt.Errorf("oauth: %s account has no email address", p.name)
+	}
+
+	return profile, nil
+}
+
+func (p *githubProvider) get(ctx context.Context, client *http.Client, path string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.apiURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("oauth: GET %s returned %s", path, res.Status)
+	}
+
+	return json.NewDecoder(io.LimitReader(res.Body, 1<<20)).Decode(v)
+}
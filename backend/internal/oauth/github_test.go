@@ -0,0 +1,89 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubLogin(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"access_token": "gho_token", "token_type": "bearer"})
+	})
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer gho_token", r.Header.Get("Authorization"))
+		json.NewEncoder(w).Encode(map[string]any{
+			"id":         42,
+			"login":      "octocat",
+			"name":       "Mona Octocat",
+			"email":      "public@example.com",
+			"avatar_url": "https://avatars.example.com/42",
+		})
+	})
+	mux.HandleFunc("/user/emails", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]map[string]any{
+			{"email": "public@example.com", "primary": false, "verified": false},
+			{"email": "mona@example.com", "primary": true, "verified": true},
+		})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	registry, err := NewRegistry([]Config{{
+		Name:        "github",
+		Type:        TypeGitHub,
+		ClientID:    "client-id",
+		RedirectURL: "http://localhost:8080/api/v1/users/github/callback",
+		AuthURL:     srv.URL + "/login/oauth/authorize",
+		TokenURL:    srv.URL + "/login/oauth/access_token",
+		APIURL:      srv.URL,
+	}}, srv.Client())
+	require.NoError(t, err)
+
+	p, ok := registry.Get("github")
+	require.True(t, ok)
+
+	profile, err := p.Exchange(context.Background(), "the-code", "", "the-verifier")
+	require.NoError(t, err)
+
+	user := profile.User("github")
+	assert.Equal(t, "42", user.ProviderAccountID)
+	assert.Equal(t, "mona@example.com", user.Email)
+	assert.True(t, user.EmailVerified)
+	assert.Equal(t, "octocat", user.Username)
+	assert.Equal(t, "Mona", user.FirstName)
+	assert.Equal(t, "Octocat", user.LastName)
+}
+
+func TestLoadConfigFromEnv(t *testing.T) {
+	t.Setenv("OAUTH_CONFIG_FILE", "")
+	t.Setenv("GOOGLE_CLIENT_ID", "")
+	t.Setenv("OAUTH_PROVIDERS", "github, acme-sso")
+	t.Setenv("OAUTH_GITHUB_CLIENT_ID", "gh-id")
+	t.Setenv("OAUTH_ACME_SSO_TYPE", "oidc")
+	t.Setenv("OAUTH_ACME_SSO_ISSUER", "https://sso.acme.com")
+	t.Setenv("OAUTH_ACME_SSO_CLIENT_ID", "acme-id")
+
+	cfgs, err := LoadConfig("http://localhost:8080/")
+	require.NoError(t, err)
+	require.Len(t, cfgs, 2)
+
+	assert.Equal(t, TypeGitHub, cfgs[0].Type)
+	assert.Equal(t, "gh-id", cfgs[0].ClientID)
+	assert.Equal(t, "http://localhost:8080/api/v1/users/github/callback", cfgs[0].RedirectURL)
+
+	assert.Equal(t, "acme-sso", cfgs[1].Name)
+	assert.Equal(t, TypeOIDC, cfgs[1].Type)
+	assert.Equal(t, "https://sso.acme.com", cfgs[1].Issuer)
+
+	t.Setenv("OAUTH_PROVIDERS", "me")
+	_, err = LoadConfig("http://localhost:8080")
+	assert.Error(t, err)
+}
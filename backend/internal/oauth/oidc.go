@@ -0,0 +1,329 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+// keysRefreshInterval limits how often an unknown key ID triggers a JWKS
+// refetch, so forged tokens cannot make us hammer the issuer.
+const keysRefreshInterval = time.Minute
+
+var errNoIDToken = errors.New("oauth: token response has no id_token")
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// oidcProvider speaks OpenID Connect. The discovery document is fetched on
+// first use and the issuer's signing keys are cached until a token names a
+// key we have not seen.
+type oidcProvider struct {
+	name   string
+	issuer string
+	cfg    oauth2.Config
+	client *http.Client
+
+	mu          sync.Mutex
+	doc         *discovery
+	keys        map[string]any
+	keysFetched time.Time
+}
+
+func newOIDCProvider(cfg Config, client *http.Client) *oidcProvider {
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email", "profile"}
+	}
+
+	return &oidcProvider{
+		name:   cfg.Name,
+		issuer: strings.TrimSuffix(cfg.Issuer, "/"),
+		cfg: oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Scopes:       scopes,
+		},
+		client: client,
+	}
+}
+
+func (p *oidcProvider) Name() string {
+	return p.name
+}
+
+func (p *oidcProvider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	cfg, _, err := p.config(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return cfg.AuthCodeURL(state,
+		oauth2.SetAuthURLParam("nonce", nonce),
+		oauth2.S256ChallengeOption(verifier),
+	), nil
+}
+
+func (p *oidcProvider) Exchange(ctx context.Context, code, nonce, verifier string) (*Profile, error) {
+	cfg, doc, err := p.config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.client)
+	token, err := cfg.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, err
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, errNoIDToken
+	}
+
+	claims, err := p.verify(ctx, doc, rawIDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	// some issuers keep the email out of the ID token and only serve it
+	// from userinfo
+	if claims.Email == "" && doc.UserinfoEndpoint != "" {
+		if err := p.userinfo(ctx, cfg, token, doc.UserinfoEndpoint, claims); err != nil {
+			return nil, err
+		}
+	}
+
+	if claims.Email == "" {
+		return nil, fmt.Errorf("oauth: %s did not return an email address", p.name)
+	}
+
+	return claims.profile(), nil
+}
+
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce             string   `json:"nonce"`
+	Email             string   `json:"email"`
+	EmailVerified     flexBool `json:"email_verified"`
+	Name              string   `json:"name"`
+	GivenName         string   `json:"given_name"`
+	FamilyName        string   `json:"family_name"`
+	PreferredUsername string   `json:"preferred_username"`
+	Picture           string   `json:"picture"`
+}
+
+func (c *idTokenClaims) profile() *Profile {
+	return &Profile{
+		Subject:       c.Subject,
+		Email:         c.Email,
+		EmailVerified: bool(c.EmailVerified),
+		Name:          c.Name,
+		GivenName:     c.GivenName,
+		FamilyName:    c.FamilyName,
+		Username:      c.PreferredUsername,
+		Picture:       c.Picture,
+	}
+}
+
+// verify checks the ID token's signature against the issuer's keys along
+// with its issuer, audience, expiry and nonce.
+func (p *oidcProvider) verify(ctx context.Context, doc *discovery, raw, nonce string) (*idTokenClaims, error) {
+	var claims idTokenClaims
+	_, err := jwt.ParseWithClaims(raw, &claims,
+		func(t *jwt.Token) (any, error) {
+			kid, _ := t.Header["kid"].(string)
+			return p.key(ctx, doc, kid)
+		},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(doc.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("oauth: invalid id token: %w", err)
+	}
+
+	if claims.Nonce != nonce {
+		return nil, errors.New("oauth: id token nonce mismatch")
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("oauth: id token has no subject")
+	}
+
+	return &claims, nil
+}
+
+func (p *oidcProvider) userinfo(ctx context.Context, cfg *oauth2.Config, token *oauth2.Token, endpoint string, claims *idTokenClaims) error {
+	res, err := cfg.Client(ctx, token).Get(endpoint)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("oauth: userinfo returned %s", res.Status)
+	}
+
+	var info idTokenClaims
+	if err := json.NewDecoder(io.LimitReader(res.Body, 1<<20)).Decode(&info); err != nil {
+		return err
+	}
+
+	if info.Subject != claims.Subject {
+		return errors.New("oauth: userinfo subject does not match id token")
+	}
+
+	claims.Email = info.Email
+	claims.EmailVerified = info.EmailVerified
+	if claims.Name == "" {
+		claims.Name = info.Name
+	}
+	if claims.GivenName == "" {
+		claims.GivenName = info.GivenName
+	}
+	if claims.FamilyName == "" {
+		claims.FamilyName = info.FamilyName
+	}
+	if claims.PreferredUsername == "" {
+		claims.PreferredUsername = info.PreferredUsername
+	}
+	if claims.Picture == "" {
+		claims.Picture = info.Picture
+	}
+	return nil
+}
+
+// config returns the oauth2 config with the discovered endpoints.
+func (p *oidcProvider) config(ctx context.Context) (*oauth2.Config, *discovery, error) {
+	doc, err := p.discover(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg := p.cfg
+	cfg.Endpoint = oauth2.Endpoint{
+		AuthURL:  doc.AuthorizationEndpoint,
+		TokenURL: doc.TokenEndpoint,
+	}
+	return &cfg, doc, nil
+}
+
+func (p *oidcProvider) discover(ctx context.Context) (*discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.doc != nil {
+		return p.doc, nil
+	}
+
+	var doc discovery
+	if err := p.getJSON(ctx, p.issuer+"/.well-known/openid-configuration", &doc); err != nil {
+		return nil, fmt.Errorf("oauth: discover %s: %w", p.issuer, err)
+	}
+
+	if strings.TrimSuffix(doc.Issuer, "/") != p.issuer {
+		return nil, fmt.Errorf("oauth: discovery issuer %q does not match %q", doc.Issuer, p.issuer)
+	}
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.JWKSURI == "" {
+		return nil, fmt.Errorf("oauth: discovery document for %s is incomplete", p.issuer)
+	}
+
+	p.doc = &doc
+	return p.doc, nil
+}
+
+// key returns the issuer key with the given ID, refetching the key set when
+// the ID is unknown. Tokens without a key ID are checked against every key.
+func (p *oidcProvider) key(ctx context.Context, doc *discovery, kid string) (any, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if k, ok := p.lookup(kid); ok {
+		return k, nil
+	}
+
+	if time.Since(p.keysFetched) < keysRefreshInterval {
+		return nil, fmt.Errorf("oauth: unknown signing key %q", kid)
+	}
+
+	var set jwks
+	if err := p.getJSON(ctx, doc.JWKSURI, &set); err != nil {
+		return nil, fmt.Errorf("oauth: fetch jwks: %w", err)
+	}
+
+	p.keys = set.publicKeys()
+	p.keysFetched = time.Now()
+
+	if k, ok := p.lookup(kid); ok {
+		return k, nil
+	}
+	return nil, fmt.Errorf("oauth: unknown signing key %q", kid)
+}
+
+func (p *oidcProvider) lookup(kid string) (any, bool) {
+	if kid != "" {
+		k, ok := p.keys[kid]
+		return k, ok
+	}
+
+	if len(p.keys) == 0 {
+		return nil, false
+	}
+
+	set := jwt.VerificationKeySet{}
+	for _, k := range p.keys {
+		set.Keys = append(set.Keys, k)
+	}
+	return set, true
+}
+
+func (p *oidcProvider) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned %s", url, res.Status)
+	}
+
+	return json.NewDecoder(io.LimitReader(res.Body, 1<<20)).Decode(v)
+}
+
+// flexBool accepts both true and "true"; some issuers send email_verified
+// as a string.
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	switch strings.Trim(string(data), `"`) {
+	case "true":
+		*b = true
+	default:
+		*b = false
+	}
+	return nil
+}
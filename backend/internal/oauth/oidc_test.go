@@ -0,0 +1,222 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockOIDC is a minimal OpenID Connect issuer: discovery, JWKS, token and
+// userinfo endpoints backed by an in-memory RSA key.
+type mockOIDC struct {
+	*httptest.Server
+	key    *rsa.PrivateKey
+	kid    string
+	claims jwt.MapClaims
+
+	// challenge is the PKCE challenge from the last authorization request
+	challenge string
+	// userinfo is served from /userinfo when set
+	userinfo map[string]any
+}
+
+func newMockOIDC(t *testing.T) *mockOIDC {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	m := &mockOIDC{key: key, kid: "key-1"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 m.URL,
+			"authorization_endpoint": m.URL + "/authorize",
+			"token_endpoint":         m.URL + "/token",
+			"userinfo_endpoint":      m.URL + "/userinfo",
+			"jwks_uri":               m.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": m.kid,
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(m.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(m.key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "the-code", r.PostForm.Get("code"))
+
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if m.challenge != "" {
+			assert.Equal(t, m.challenge, base64.RawURLEncoding.EncodeToString(sum[:]))
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, m.claims)
+		token.Header["kid"] = m.kid
+		idToken, err := token.SignedString(m.key)
+		require.NoError(t, err)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     idToken,
+		})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer access", r.Header.Get("Authorization"))
+		json.NewEncoder(w).Encode(m.userinfo)
+	})
+
+	m.Server = httptest.NewServer(mux)
+	t.Cleanup(m.Close)
+
+	m.claims = jwt.MapClaims{
+		"iss":            m.URL,
+		"aud":            "client-id",
+		"sub":            "user-123",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          "the-nonce",
+		"email":          "Ada@Example.com",
+		"email_verified": true,
+		"name":           "Ada Lovelace",
+	}
+	return m
+}
+
+func (m *mockOIDC) provider(t *testing.T) Provider {
+	registry, err := NewRegistry([]Config{{
+		Name:        "sso",
+		Type:        TypeOIDC,
+		Issuer:      m.URL,
+		ClientID:    "client-id",
+		RedirectURL: "http://localhost:8080/api/v1/users/sso/callback",
+	}}, m.Client())
+	require.NoError(t, err)
+
+	p, ok := registry.Get("sso")
+	require.True(t, ok)
+	return p
+}
+
+func TestOIDCLogin(t *testing.T) {
+	m := newMockOIDC(t)
+	p := m.provider(t)
+	ctx := context.Background()
+
+	authURL, err := p.AuthCodeURL(ctx, "the-state", "the-nonce", "the-verifier")
+	require.NoError(t, err)
+
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(authURL, m.URL+"/authorize"))
+	assert.Equal(t, "the-state", u.Query().Get("state"))
+	assert.Equal(t, "the-nonce", u.Query().Get("nonce"))
+	assert.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+	m.challenge = u.Query().Get("code_challenge")
+
+	profile, err := p.Exchange(ctx, "the-code", "the-nonce", "the-verifier")
+	require.NoError(t, err)
+
+	user := profile.User("sso")
+	assert.Equal(t, "sso", user.Provider)
+	assert.Equal(t, "user-123", user.ProviderAccountID)
+	assert.Equal(t, "ada@example.com", user.Email)
+	assert.True(t, user.EmailVerified)
+	assert.Equal(t, "Ada", user.FirstName)
+	assert.Equal(t, "Lovelace", user.LastName)
+}
+
+func TestOIDCRejectsInvalidIDTokens(t *testing.T) {
+	tests := []struct {
+		name  string
+		claim string
+		value any
+	}{
+		{"wrong nonce", "nonce", "another-nonce"},
+		{"wrong audience", "aud", "someone-else"},
+		{"wrong issuer", "iss", "https://evil.example.com"},
+		{"expired", "exp", time.Now().Add(-time.Hour).Unix()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMockOIDC(t)
+			m.claims[tt.claim] = tt.value
+
+			_, err := m.provider(t).Exchange(context.Background(), "the-code", "the-nonce", "the-verifier")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestOIDCRejectsForeignSignature(t *testing.T) {
+	m := newMockOIDC(t)
+	p := m.provider(t)
+
+	// the first login caches the issuer's key
+	_, err := p.Exchange(context.Background(), "the-code", "the-nonce", "the-verifier")
+	require.NoError(t, err)
+
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	m.key = other
+
+	// a token signed by another key under the same kid must not verify
+	_, err = p.Exchange(context.Background(), "the-code", "the-nonce", "the-verifier")
+	assert.Error(t, err)
+}
+
+func TestOIDCFallsBackToUserinfo(t *testing.T) {
+	m := newMockOIDC(t)
+	delete(m.claims, "email")
+	delete(m.claims, "email_verified")
+	m.userinfo = map[string]any{
+		"sub":            "user-123",
+		"email":          "ada@example.com",
+		"email_verified": "true",
+	}
+
+	profile, err := m.provider(t).Exchange(context.Background(), "the-code", "the-nonce", "the-verifier")
+	require.NoError(t, err)
+	assert.Equal(t, "ada@example.com", profile.Email)
+	assert.True(t, profile.EmailVerified)
+}
+
+func TestOIDCDiscoveryIssuerMismatch(t *testing.T) {
+	m := newMockOIDC(t)
+
+	registry, err := NewRegistry([]Config{{
+		Name:        "sso",
+		Type:        TypeOIDC,
+		Issuer:      m.URL + "/tenant",
+		ClientID:    "client-id",
+		RedirectURL: "http://localhost/callback",
+	}}, m.Client())
+	require.NoError(t, err)
+
+	p, _ := registry.Get("sso")
+	_, err = p.AuthCodeURL(context.Background(), "s", "n", "v")
+	assert.Error(t, err)
+}
@@ -0,0 +1,103 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often expired entries are dropped from memory.
+const sweepInterval = time.Minute
+
+type memoryEntry struct {
+	count   int64
+	expires time.Time
+}
+
+// MemoryBackend keeps counters in process. Limits are per instance, so use
+// it only when a single node serves the API.
+type MemoryBackend struct {
+	mu        sync.Mutex
+	entries   map[string]*memoryEntry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{
+		entries: make(map[string]*memoryEntry),
+		now:     time.Now,
+	}
+}
+
+func (b *MemoryBackend) Incr(_ context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.sweep(now)
+
+	e := b.live(key, now)
+	if e == nil {
+		e = &memoryEntry{expires: now.Add(window)}
+		b.entries[key] = e
+	}
+	e.count++
+
+	return e.count, e.expires.Sub(now), nil
+}
+
+func (b *MemoryBackend) Block(_ context.Context, key string, d time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.entries[key] = &memoryEntry{count: 1, expires: b.now().Add(d)}
+	return nil
+}
+
+func (b *MemoryBackend) Blocked(_ context.Context, key string) (time.Duration, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if e := b.live(key, now); e != nil {
+		return e.expires.Sub(now), nil
+	}
+	return 0, nil
+}
+
+func (b *MemoryBackend) Reset(_ context.Context, key string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.entries, key)
+	return nil
+}
+
+func (b *MemoryBackend) Close() error {
+	return nil
+}
+
+// live returns the unexpired entry for key. Callers hold b.mu.
+func (b *MemoryBackend) live(key string, now time.Time) *memoryEntry {
+	e, ok := b.entries[key]
+	if !ok || !now.Before(e.expires) {
+		return nil
+	}
+	return e
+}
+
+// sweep drops expired entries at most once per sweepInterval. Callers hold
+// b.mu.
+func (b *MemoryBackend) sweep(now time.Time) {
+	if now.Sub(b.lastSweep) < sweepInterval {
+		return
+	}
+	b.lastSweep = now
+
+	for key, e := range b.entries {
+		if !now.Before(e.expires) {
+			delete(b.entries, key)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLimiter(lockout Lockout) (*Limiter, *time.Time) {
+	now := time.Unix(1700000000, 0)
+	backend := NewMemoryBackend()
+	backend.now = func() time.Time { return now }
+	return NewLimiter(backend, lockout), &now
+}
+
+func TestLimiterAllow(t *testing.T) {
+	ctx := context.Background()
+	limiter, now := newTestLimiter(DefaultLockout)
+	rule := Rule{Limit: 2, Window: time.Minute}
+
+	for i := 0; i < 2; i++ {
+		ok, _, err := limiter.Allow(ctx, "login:ip:1.2.3.4", rule)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+
+	*now = now.Add(20 * time.Second)
+	ok, retryAfter, err := limiter.Allow(ctx, "login:ip:1.2.3.4", rule)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 40*time.Second, retryAfter)
+
+	// other keys have their own bucket
+	ok, _, err = limiter.Allow(ctx, "login:ip:5.6.7.8", rule)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// a new window starts once the old one expires
+	*now = now.Add(time.Minute)
+	ok, _, err = limiter.Allow(ctx, "login:ip:1.2.3.4", rule)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, _, err = limiter.Allow(ctx, "anything", Rule{})
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestLimiterProgressiveLockout(t *testing.T) {
+	ctx := context.Background()
+	limiter, now := newTestLimiter(Lockout{
+		Threshold: 3,
+		Window:    time.Hour,
+		Base:      time.Minute,
+		Max:       5 * time.Minute,
+	})
+
+	for i := 0; i < 2; i++ {
+		d, err := limiter.RecordFailure(ctx, "ada@example.com")
+		require.NoError(t, err)
+		assert.Zero(t, d)
+	}
+
+	locked, err := limiter.Locked(ctx, "ada@example.com")
+	require.NoError(t, err)
+	assert.Zero(t, locked)
+
+	// each failure past the threshold doubles the lockout, up to the max
+	for _, want := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute} {
+		d, err := limiter.RecordFailure(ctx, "ada@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, want, d)
+
+		locked, err := limiter.Locked(ctx, "ada@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, want, locked)
+	}
+
+	*now = now.Add(5 * time.Minute)
+	locked, err = limiter.Locked(ctx, "ada@example.com")
+	require.NoError(t, err)
+	assert.Zero(t, locked)
+
+	// a successful login forgets earlier failures
+	require.NoError(t, limiter.RecordSuccess(ctx, "ada@example.com"))
+	d, err := limiter.RecordFailure(ctx, "ada@example.com")
+	require.NoError(t, err)
+	assert.Zero(t, d)
+}
+
+func TestParseRule(t *testing.T) {
+	rule, err := ParseRule("10/1m")
+	require.NoError(t, err)
+	assert.Equal(t, Rule{Limit: 10, Window: time.Minute}, rule)
+
+	rule, err = ParseRule("off")
+	require.NoError(t, err)
+	assert.False(t, rule.enabled())
+
+	for _, bad := range []string{"10", "0/1m", "ten/1m", "10/soon"} {
+		_, err := ParseRule(bad)
+		assert.Error(t, err, bad)
+	}
+}
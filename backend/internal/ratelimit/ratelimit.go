@@ -0,0 +1,207 @@
+// Package ratelimit counts requests in fixed windows and locks accounts out
+// after repeated failed logins. Counters live in a Backend: in memory for a
+// single node or in Redis when several instances share the limits.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Backend stores counters and blocks that expire on their own.
+type Backend interface {
+	// Incr adds one to key, starting a window of the given length when the
+	// key is new, and returns the count and the time left in the window.
+	Incr(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error)
+	// Block marks key as blocked for d.
+	Block(ctx context.Context, key string, d time.Duration) error
+	// Blocked returns how long key stays blocked, or zero.
+	Blocked(ctx context.Context, key string) (time.Duration, error)
+	Reset(ctx context.Context, key string) error
+	Close() error
+}
+
+type Config struct {
+	Driver        string
+	RedisAddr     string
+	RedisPassword string
+	RedisDB       int
+	Lockout       Lockout
+}
+
+// Rule allows Limit requests per Window. A zero rule allows everything.
+type Rule struct {
+	Limit  int
+	Window time.Duration
+}
+
+// ParseRule reads a rule written as "<limit>/<window>", e.g. "10/1m".
+// An empty string or "off" disables the rule.
+func ParseRule(s string) (Rule, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "off" {
+		return Rule{}, nil
+	}
+
+	limit, window, ok := strings.Cut(s, "/")
+	if !ok {
+		return Rule{}, fmt.Errorf("ratelimit: rule %q is not <limit>/<window>", s)
+	}
+
+	n, err := strconv.Atoi(limit)
+	if err != nil || n <= 0 {
+		return Rule{}, fmt.Errorf("ratelimit: rule %q has an invalid limit", s)
+	}
+
+	d, err := time.ParseDuration(window)
+	if err != nil || d <= 0 {
+		return Rule{}, fmt.Errorf("ratelimit: rule %q has an invalid window", s)
+	}
+
+	return Rule{Limit: n, Window: d}, nil
+}
+
+func (r Rule) enabled() bool {
+	return r.Limit > 0 && r.Window > 0
+}
+
+// Policy is the limits for one group of routes. Account limits apply to
+// requests that name an account, such as a login email.
+type Policy struct {
+	Name       string
+	PerIP      Rule
+	PerAccount Rule
+}
+
+// Lockout blocks an account after Threshold failed logins within Window.
+// The block starts at Base and doubles with every further failure, up to
+// Max.
+type Lockout struct {
+	Threshold int
+	Window    time.Duration
+	Base      time.Duration
+	Max       time.Duration
+}
+
+// DefaultLockout locks an account for a minute after five failures and for
+// at most an hour.
+var DefaultLockout = Lockout{
+	Threshold: 5,
+	Window:    time.Hour,
+	Base:      time.Minute,
+	Max:       time.Hour,
+}
+
+// duration returns how long to block after the given number of failures.
+func (l Lockout) duration(failures int64) time.Duration {
+	if failures < int64(l.Threshold) {
+		return 0
+	}
+
+	exp := failures - int64(l.Threshold)
+	if exp > 30 {
+		return l.Max
+	}
+
+	d := time.Duration(float64(l.Base) * math.Pow(2, float64(exp)))
+	if d > l.Max {
+		return l.Max
+	}
+	return d
+}
+
+const keyPrefix = "nexus:ratelimit:"
+
+// Account normalizes a login email into the key its per-account limits and
+// lockout are kept under.
+func Account(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+type Limiter struct {
+	backend Backend
+	lockout Lockout
+}
+
+func New(cfg Config) (*Limiter, error) {
+	var (
+		backend Backend
+		err     error
+	)
+
+	switch cfg.Driver {
+	case "", "memory":
+		backend = NewMemoryBackend()
+	case "redis":
+		backend, err = NewRedisBackend(cfg)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("ratelimit: unknown backend driver %q", cfg.Driver)
+	}
+
+	return NewLimiter(backend, cfg.Lockout), nil
+}
+
+func NewLimiter(backend Backend, lockout Lockout) *Limiter {
+	if lockout.Threshold <= 0 {
+		lockout = DefaultLockout
+	}
+	return &Limiter{backend: backend, lockout: lockout}
+}
+
+// Allow counts a request against the rule for key. When the limit is
+// exceeded it returns false and how long until the window resets.
+func (l *Limiter) Allow(ctx context.Context, key string, rule Rule) (bool, time.Duration, error) {
+	if !rule.enabled() {
+		return true, 0, nil
+	}
+
+	count, resetIn, err := l.backend.Incr(ctx, keyPrefix+"hits:"+key, rule.Window)
+	if err != nil {
+		return false, 0, err
+	}
+
+	if count > int64(rule.Limit) {
+		return false, resetIn, nil
+	}
+	return true, 0, nil
+}
+
+// Locked returns how long the account stays locked out, or zero.
+func (l *Limiter) Locked(ctx context.Context, account string) (time.Duration, error) {
+	return l.backend.Blocked(ctx, keyPrefix+"lock:"+account)
+}
+
+// RecordFailure counts a failed login for the account and returns how long
+// it is now locked out, or zero while under the threshold.
+func (l *Limiter) RecordFailure(ctx context.Context, account string) (time.Duration, error) {
+	failures, _, err := l.backend.Incr(ctx, keyPrefix+"failures:"+account, l.lockout.Window)
+	if err != nil {
+		return 0, err
+	}
+
+	d := l.lockout.duration(failures)
+	if d == 0 {
+		return 0, nil
+	}
+
+	if err := l.backend.Block(ctx, keyPrefix+"lock:"+account, d); err != nil {
+		return 0, err
+	}
+	return d, nil
+}
+
+// RecordSuccess clears the account's failed logins.
+func (l *Limiter) RecordSuccess(ctx context.Context, account string) error {
+	return l.backend.Reset(ctx, keyPrefix+"failures:"+account)
+}
+
+func (l *Limiter) Close() error {
+	return l.backend.Close()
+}
@@ -0,0 +1,87 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// incrScript increments a counter and starts its window on first use in one
+// round trip, so concurrent requests cannot leave a counter without expiry.
+var incrScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if count == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return {count, redis.call('PTTL', KEYS[1])}
+`)
+
+// RedisBackend keeps counters in Redis so every instance shares the same
+// limits.
+type RedisBackend struct {
+	client *redis.Client
+}
+
+func NewRedisBackend(cfg Config) (*RedisBackend, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.RedisAddr,
+		Password: cfg.RedisPassword,
+		DB:       cfg.RedisDB,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	return &RedisBackend{client: client}, nil
+}
+
+func (b *RedisBackend) Incr(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	values, err := incrScript.Run(ctx, b.client, []string{key}, window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(values) != 2 {
+		return 0, 0, fmt.Errorf("ratelimit: unexpected reply %v", values)
+	}
+
+	return values[0], pttl(values[1]), nil
+}
+
+func (b *RedisBackend) Block(ctx context.Context, key string, d time.Duration) error {
+	return b.client.Set(ctx, key, "1", d).Err()
+}
+
+func (b *RedisBackend) Blocked(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := b.client.PTTL(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	// missing keys and keys without expiry come back negative
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+func (b *RedisBackend) Reset(ctx context.Context, key string) error {
+	return b.client.Del(ctx, key).Err()
+}
+
+func (b *RedisBackend) Close() error {
+	return b.client.Close()
+}
+
+// pttl converts a PTTL reply to a duration. Redis answers -2 for a missing
+// key and -1 for one without expiry.
+func pttl(ms int64) time.Duration {
+	if ms < 0 {
+		return 0
+	}
+	return time.Duration(ms) * time.Millisecond
+}
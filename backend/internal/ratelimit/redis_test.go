@@ -0,0 +1,87 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRedisBackend(t *testing.T) (*RedisBackend, *miniredis.Miniredis) {
+	t.Helper()
+
+	srv := miniredis.RunT(t)
+	backend, err := NewRedisBackend(Config{RedisAddr: srv.Addr()})
+	require.NoError(t, err)
+	t.Cleanup(func() { backend.Close() })
+	return backend, srv
+}
+
+func TestRedisBackendIncr(t *testing.T) {
+	ctx := context.Background()
+	backend, srv := newTestRedisBackend(t)
+
+	count, ttl, err := backend.Incr(ctx, "login:ip:1.2.3.4", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	assert.Equal(t, time.Minute, ttl)
+
+	// the window is not pushed back by later hits
+	srv.FastForward(20 * time.Second)
+	count, ttl, err = backend.Incr(ctx, "login:ip:1.2.3.4", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.Equal(t, 40*time.Second, ttl)
+
+	// a new window starts once the old one expires
+	srv.FastForward(time.Minute)
+	count, _, err = backend.Incr(ctx, "login:ip:1.2.3.4", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestRedisBackendBlock(t *testing.T) {
+	ctx := context.Background()
+	backend, srv := newTestRedisBackend(t)
+
+	blocked, err := backend.Blocked(ctx, "lockout:jane@example.com")
+	require.NoError(t, err)
+	assert.Zero(t, blocked, "missing keys are not blocked")
+
+	require.NoError(t, backend.Block(ctx, "lockout:jane@example.com", 15*time.Minute))
+	blocked, err = backend.Blocked(ctx, "lockout:jane@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, blocked)
+
+	srv.FastForward(15 * time.Minute)
+	blocked, err = backend.Blocked(ctx, "lockout:jane@example.com")
+	require.NoError(t, err)
+	assert.Zero(t, blocked)
+
+	require.NoError(t, backend.Block(ctx, "lockout:jane@example.com", time.Minute))
+	require.NoError(t, backend.Reset(ctx, "lockout:jane@example.com"))
+	blocked, err = backend.Blocked(ctx, "lockout:jane@example.com")
+	require.NoError(t, err)
+	assert.Zero(t, blocked)
+}
+
+func TestRedisBackendLimiter(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := newTestRedisBackend(t)
+	limiter := NewLimiter(backend, DefaultLockout)
+	rule := Rule{Limit: 2, Window: time.Minute}
+
+	for i := 0; i < 2; i++ {
+		ok, _, err := limiter.Allow(ctx, "login:ip:1.2.3.4", rule)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+
+	ok, retryAfter, err := limiter.Allow(ctx, "login:ip:1.2.3.4", rule)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, time.Minute, retryAfter)
+}
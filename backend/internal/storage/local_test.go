@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	blob, err := NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	err = blob.Put(ctx, "cards/abc/file.txt", strings.NewReader("hello"), 5, "text/plain")
+	require.NoError(t, err)
+
+	rc, err := blob.Get(ctx, "cards/abc/file.txt")
+	require.NoError(t, err)
+	body, err := io.ReadAll(rc)
+	rc.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(body))
+
+	require.NoError(t, blob.Delete(ctx, "cards/abc/file.txt"))
+
+	_, err = blob.Get(ctx, "cards/abc/file.txt")
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	// deleting a missing blob is not an error
+	assert.NoError(t, blob.Delete(ctx, "cards/abc/file.txt"))
+}
+
+func TestLocalRejectsTraversal(t *testing.T) {
+	blob, err := NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	err = blob.Put(context.Background(), "../escape.txt", strings.NewReader("x"), 1, "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 is a tiny in-memory stand-in that understands path-style object requests.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	auth    []string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.auth = append(f.auth, r.Header.Get("Authorization"))
+
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = body
+	case http.MethodGet:
+		body, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(body)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3AgainstFakeServer(t *testing.T) {
+	fake := &fakeS3{objects: map[string][]byte{}}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	blob, err := NewS3(S3Config{
+		Endpoint:  srv.URL,
+		Bucket:    "nexus",
+		AccessKey: "access",
+		SecretKey: "secret",
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, blob.Put(ctx, "cards/1/report final.pdf", strings.NewReader("pdf"), 3, "application/pdf"))
+	assert.Contains(t, fake.objects, "/nexus/cards/1/report final.pdf")
+	assert.True(t, strings.HasPrefix(fake.auth[0], "AWS4-HMAC-SHA256 Credential=access/"))
+
+	rc, err := blob.Get(ctx, "cards/1/report final.pdf")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(rc)
+	rc.Close()
+	assert.Equal(t, "pdf", string(body))
+
+	require.NoError(t, blob.Delete(ctx, "cards/1/report final.pdf"))
+
+	_, err = blob.Get(ctx, "cards/1/report final.pdf")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+// TestS3AgainstMinIO runs against a real S3 compatible server, e.g.
+//
+//	docker run -p 9000:9000 minio/minio server /data
+//
+// with S3_TEST_ENDPOINT, S3_TEST_BUCKET, S3_TEST_ACCESS_KEY and
+// S3_TEST_SECRET_KEY set. The bucket must already exist.
+func TestS3AgainstMinIO(t *testing.T) {
+	endpoint := os.Getenv("S3_TEST_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("S3_TEST_ENDPOINT not set")
+	}
+
+	blob, err := NewS3(S3Config{
+		Endpoint:  endpoint,
+		Bucket:    os.Getenv("S3_TEST_BUCKET"),
+		AccessKey: os.Getenv("S3_TEST_ACCESS_KEY"),
+		SecretKey: os.Getenv("S3_TEST_SECRET_KEY"),
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	key := "test/" + uuid.New().String() + ".txt"
+
+	require.NoError(t, blob.Put(ctx, key, strings.NewReader("nexus"), 5, "text/plain"))
+
+	rc, err := blob.Get(ctx, key)
+	require.NoError(t, err)
+	body, _ := io.ReadAll(rc)
+	rc.Close()
+	assert.Equal(t, "nexus", string(body))
+
+	require.NoError(t, blob.Delete(ctx, key))
+}
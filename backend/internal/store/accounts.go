@@ -0,0 +1,132 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/db/db"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+var (
+	// ErrAccountLinked is returned when linking a provider identity that
+	// already belongs to another user.
+	ErrAccountLinked = errors.New("store: provider account is linked to another user")
+
+	// ErrLastLoginMethod is returned when unlinking the only way the user
+	// has left to sign in.
+	ErrLastLoginMethod = errors.New("store: cannot remove the last login method")
+)
+
+// ListLoginMethods reports whether the user has a password along with every
+// provider account linked to them, oldest first.
+func (s *Store) ListLoginMethods(ctx context.Context, userID string) (*types.LoginMethods, error) {
+	user, err := s.db.User.FindUnique(
+		db.User.ID.Equals(userID),
+	).With(
+		db.User.Accounts.Fetch().OrderBy(
+			db.Account.CreatedAt.Order(db.SortOrderAsc),
+		),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	password, _ := user.Password()
+	methods := &types.LoginMethods{
+		Password: password != "",
+		Accounts: make([]*types.LinkedAccount, 0, len(user.Accounts())),
+	}
+
+	for _, account := range user.Accounts() {
+		methods.Accounts = append(methods.Accounts, &types.LinkedAccount{
+			ID:        account.ID,
+			Type:      account.Type,
+			Provider:  account.Provider,
+			CreatedAt: account.CreatedAt,
+		})
+	}
+	return methods, nil
+}
+
+// GetAccountUserID returns the user a provider identity is linked to.
+func (s *Store) GetAccountUserID(ctx context.Context, provider, providerAccountID string) (string, error) {
+	account, err := s.db.Account.FindUnique(
+		db.Account.ProviderProviderAccountID(
+			db.Account.Provider.Equals(provider),
+			db.Account.ProviderAccountID.Equals(providerAccountID),
+		),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	return account.UserID, nil
+}
+
+// LinkAccount attaches a provider identity to the user. Linking an identity
+// the user already owns is a no-op.
+func (s *Store) LinkAccount(ctx context.Context, link *types.LinkAccount) error {
+	ownerID, err := s.GetAccountUserID(ctx, link.Provider, link.ProviderAccountID)
+	if err == nil {
+		if ownerID != link.UserID {
+			return ErrAccountLinked
+		}
+		return nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	_, err = s.db.Account.CreateOne(
+		db.Account.Type.Set("oauth"),
+		db.Account.Provider.Set(link.Provider),
+		db.Account.ProviderAccountID.Set(link.ProviderAccountID),
+		db.Account.User.Link(
+			db.User.ID.Equals(link.UserID),
+		),
+	).Exec(ctx)
+	return err
+}
+
+// UnlinkAccount removes one of the user's provider accounts, refusing when
+// the user would be left without a password or another linked account.
+func (s *Store) UnlinkAccount(ctx context.Context, userID, accountID string) error {
+	methods, err := s.ListLoginMethods(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for _, account := range methods.Accounts {
+		if account.ID == accountID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return ErrNotFound
+	}
+
+	if !methods.Password && len(methods.Accounts) == 1 {
+		return ErrLastLoginMethod
+	}
+
+	res, err := s.db.Account.FindMany(
+		db.Account.ID.Equals(accountID),
+		db.Account.UserID.Equals(userID),
+	).Delete().Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	if res.Count == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/db/db"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+// activity describes the audit row written alongside a store mutation.
+// cardID links the row to a card so it shows up in the card history; leave
+// it empty when the card is being deleted in the same transaction.
+type activity struct {
+	boardID string
+	cardID  string
+	listID  string
+	kind    string
+	before  map[string]any
+	after   map[string]any
+	// actorID overrides the user from the context, for writes made outside
+	// an authenticated request such as signup.
+	actorID string
+}
+
+// actorID returns the ID of the user performing the request. The auth
+// middleware stores the user in the request context, which handlers pass
+// down to the store unchanged.
+func actorID(ctx context.Context) string {
+	user, ok := ctx.Value(types.UserCtxKey).(*types.User)
+	if !ok || user == nil {
+		return ""
+	}
+	return user.ID
+}
+
+func (s *Store) activityTx(ctx context.Context, a activity) db.ActivityUniqueTxResult {
+	params := []db.ActivitySetParam{}
+	if a.cardID != "" {
+		params = append(params, db.Activity.Card.Link(
+			db.Card.ID.Equals(a.cardID),
+		))
+	}
+	if a.listID != "" {
+		params = append(params, db.Activity.ListID.Set(a.listID))
+	}
+	if a.before != nil || a.after != nil {
+		metadata, err := json.Marshal(&types.ActivityMetadata{Before: a.before, After: a.after})
+		if err == nil {
+			params = append(params, db.Activity.Metadata.Set(string(metadata)))
+		}
+	}
+
+	actor := a.actorID
+	if actor == "" {
+		actor = actorID(ctx)
+	}
+
+	return s.db.Activity.CreateOne(
+		db.Activity.UserID.Set(actor),
+		db.Activity.Type.Set(a.kind),
+		db.Activity.Board.Link(
+			db.Board.ID.Equals(a.boardID),
+		),
+		params...,
+	).Tx()
+}
+
+// trackChange records field in before/after when the update sets it to a new value.
+func trackChange[T comparable](before, after map[string]any, field string, oldVal T, newVal *T) {
+	if newVal == nil || *newVal == oldVal {
+		return
+	}
+	before[field] = oldVal
+	after[field] = *newVal
+}
+
+func (s *Store) ListBoardActivity(ctx context.Context, boardID string, paginate *types.Paginate) ([]*types.Activity, error) {
+	activities, err := s.db.Activity.FindMany(
+		db.Activity.BoardID.Equals(boardID),
+	).OrderBy(
+		db.Activity.CreatedAt.Order(db.SortOrder(paginate.SortOrder)),
+	).Skip(paginate.Offset).Take(paginate.Size).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toActivities(activities), nil
+}
+
+func (s *Store) ListCardActivity(ctx context.Context, cardID string, paginate *types.Paginate) ([]*types.Activity, error) {
+	activities, err := s.db.Activity.FindMany(
+		db.Activity.CardID.Equals(cardID),
+	).OrderBy(
+		db.Activity.CreatedAt.Order(db.SortOrder(paginate.SortOrder)),
+	).Skip(paginate.Offset).Take(paginate.Size).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toActivities(activities), nil
+}
+
+func toActivities(activities []db.ActivityModel) []*types.Activity {
+	res := make([]*types.Activity, 0, len(activities))
+	for _, a := range activities {
+		cardID, ok := a.CardID()
+		if !ok {
+			cardID = ""
+		}
+
+		listID, ok := a.ListID()
+		if !ok {
+			listID = ""
+		}
+
+		var metadata json.RawMessage
+		if m, ok := a.Metadata(); ok {
+			metadata = json.RawMessage(m)
+		}
+
+		res = append(res, &types.Activity{
+			ID:        a.ID,
+			BoardID:   a.BoardID,
+			CardID:    cardID,
+			ListID:    listID,
+			UserID:    a.UserID,
+			Type:      a.Type,
+			Metadata:  metadata,
+			CreatedAt: a.CreatedAt,
+		})
+	}
+	return res
+}
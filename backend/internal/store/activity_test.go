@@ -0,0 +1,29 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func TestTrackChange(t *testing.T) {
+	title := "new title"
+	same := "old title"
+
+	before, after := map[string]any{}, map[string]any{}
+	trackChange(before, after, "title", "old title", &title)
+	trackChange(before, after, "description", "old title", &same)
+	trackChange[string](before, after, "cover", "", nil)
+
+	assert.Equal(t, map[string]any{"title": "old title"}, before)
+	assert.Equal(t, map[string]any{"title": "new title"}, after)
+}
+
+func TestActorID(t *testing.T) {
+	assert.Equal(t, "", actorID(context.Background()))
+
+	ctx := context.WithValue(context.Background(), types.UserCtxKey, &types.User{ID: "u1"})
+	assert.Equal(t, "u1", actorID(ctx))
+}
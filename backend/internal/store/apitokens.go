@@ -0,0 +1,132 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/db/db"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+// apiTokenUseResolution limits how often a token's last use is written, so
+// a busy CI job does not turn every request into a write.
+const apiTokenUseResolution = time.Minute
+
+func (s *Store) CreateAPIToken(ctx context.Context, token *types.CreateAPIToken) error {
+	token.ID = uuid.New().String()
+
+	params := []db.APITokenSetParam{
+		db.APIToken.ID.Set(token.ID),
+		db.APIToken.Scopes.Set(token.Scopes),
+	}
+	if token.ExpiresAt != nil {
+		params = append(params, db.APIToken.ExpiresAt.Set(*token.ExpiresAt))
+	}
+
+	_, err := s.db.APIToken.CreateOne(
+		db.APIToken.Name.Set(token.Name),
+		db.APIToken.TokenHash.Set(token.TokenHash),
+		db.APIToken.Prefix.Set(token.Prefix),
+		db.APIToken.User.Link(
+			db.User.ID.Equals(token.UserID),
+		),
+		params...,
+	).Exec(ctx)
+	return err
+}
+
+// ListAPITokens returns the user's tokens that were not revoked, newest
+// first. Expired tokens are included so the user can see why a job broke.
+func (s *Store) ListAPITokens(ctx context.Context, userID string) ([]*types.APIToken, error) {
+	tokens, err := s.db.APIToken.FindMany(
+		db.APIToken.UserID.Equals(userID),
+		db.APIToken.RevokedAt.IsNull(),
+	).OrderBy(
+		db.APIToken.CreatedAt.Order(db.SortOrderDesc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.APIToken, 0, len(tokens))
+	for _, token := range tokens {
+		res = append(res, toAPIToken(&token))
+	}
+	return res, nil
+}
+
+func (s *Store) RevokeAPIToken(ctx context.Context, userID, tokenID string) error {
+	res, err := s.db.APIToken.FindMany(
+		db.APIToken.ID.Equals(tokenID),
+		db.APIToken.UserID.Equals(userID),
+		db.APIToken.RevokedAt.IsNull(),
+	).Update(
+		db.APIToken.RevokedAt.Set(time.Now()),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	if res.Count == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// GetUserByAPIToken returns the owner of a personal access token with the
+// token's ID and scopes set. It returns ErrNotFound for unknown or revoked
+// tokens and ErrTokenExpired once the token's expiry has passed.
+func (s *Store) GetUserByAPIToken(ctx context.Context, tokenHash string) (*types.User, error) {
+	token, err := s.db.APIToken.FindUnique(
+		db.APIToken.TokenHash.Equals(tokenHash),
+	).With(
+		db.APIToken.User.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	if _, revoked := token.RevokedAt(); revoked {
+		return nil, ErrNotFound
+	}
+	if expiresAt, ok := token.ExpiresAt(); ok && time.Now().After(expiresAt) {
+		return nil, ErrTokenExpired
+	}
+
+	if lastUsedAt, ok := token.LastUsedAt(); !ok || time.Since(lastUsedAt) > apiTokenUseResolution {
+		if _, err := s.db.APIToken.FindUnique(
+			db.APIToken.ID.Equals(token.ID),
+		).Update(
+			db.APIToken.LastUsedAt.Set(time.Now()),
+		).Exec(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	user := toUser(token.User())
+	user.APITokenID = token.ID
+	user.Scopes = token.Scopes
+	return user, nil
+}
+
+func toAPIToken(token *db.APITokenModel) *types.APIToken {
+	res := &types.APIToken{
+		ID:        token.ID,
+		Name:      token.Name,
+		Prefix:    token.Prefix,
+		Scopes:    token.Scopes,
+		CreatedAt: token.CreatedAt,
+	}
+
+	if expiresAt, ok := token.ExpiresAt(); ok {
+		res.ExpiresAt = &expiresAt
+	}
+	if lastUsedAt, ok := token.LastUsedAt(); ok {
+		res.LastUsedAt = &lastUsedAt
+	}
+	return res
+}
@@ -0,0 +1,127 @@
+package store
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/db/db"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func (s *Store) CreateAttachment(ctx context.Context, attachment *types.CreateAttachment) (*types.Attachment, error) {
+	card, err := s.db.Card.FindUnique(
+		db.Card.ID.Equals(attachment.CardID),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	attachmentID := uuid.New().String()
+	attachmentTxn := s.db.Attachment.CreateOne(
+		db.Attachment.FileName.Set(attachment.FileName),
+		db.Attachment.FileURL.Set(attachment.FileKey),
+		db.Attachment.Card.Link(
+			db.Card.ID.Equals(attachment.CardID),
+		),
+		db.Attachment.User.Link(
+			db.User.ID.Equals(attachment.UserID),
+		),
+		db.Attachment.ID.Set(attachmentID),
+		db.Attachment.FileType.Set(attachment.FileType),
+		db.Attachment.FileSize.Set(attachment.FileSize),
+	).Tx()
+
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: card.BoardID,
+		cardID:  card.ID,
+		listID:  card.ListID,
+		kind:    types.ActivityAttachmentAdded,
+		after:   map[string]any{"attachmentID": attachmentID, "fileName": attachment.FileName},
+	})
+
+	if err := s.db.Prisma.Transaction(attachmentTxn, activityTxn).Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	return toAttachment(attachmentTxn.Result()), nil
+}
+
+func (s *Store) ListCardAttachments(ctx context.Context, cardID string) ([]*types.Attachment, error) {
+	attachments, err := s.db.Attachment.FindMany(
+		db.Attachment.CardID.Equals(cardID),
+	).OrderBy(
+		db.Attachment.CreatedAt.Order(db.SortOrder("desc")),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.Attachment, 0, len(attachments))
+	for _, attachment := range attachments {
+		res = append(res, toAttachment(&attachment))
+	}
+	return res, nil
+}
+
+func (s *Store) GetAttachment(ctx context.Context, attachmentID string) (*types.Attachment, error) {
+	attachment, err := s.db.Attachment.FindUnique(
+		db.Attachment.ID.Equals(attachmentID),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return toAttachment(attachment), nil
+}
+
+func (s *Store) DeleteAttachment(ctx context.Context, attachmentID string) error {
+	attachment, err := s.db.Attachment.FindUnique(
+		db.Attachment.ID.Equals(attachmentID),
+	).With(
+		db.Attachment.Card.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	attachmentTxn := s.db.Attachment.FindUnique(
+		db.Attachment.ID.Equals(attachmentID),
+	).Delete().Tx()
+
+	card := attachment.Card()
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: card.BoardID,
+		cardID:  card.ID,
+		listID:  card.ListID,
+		kind:    types.ActivityAttachmentDeleted,
+		before:  map[string]any{"attachmentID": attachment.ID, "fileName": attachment.FileName},
+	})
+
+	return s.db.Prisma.Transaction(attachmentTxn, activityTxn).Exec(ctx)
+}
+
+func toAttachment(attachment *db.AttachmentModel) *types.Attachment {
+	fileType, ok := attachment.FileType()
+	if !ok {
+		fileType = "application/octet-stream"
+	}
+
+	fileSize, ok := attachment.FileSize()
+	if !ok {
+		fileSize = 0
+	}
+
+	return &types.Attachment{
+		ID:         attachment.ID,
+		CardID:     attachment.CardID,
+		FileName:   attachment.FileName,
+		FileType:   fileType,
+		FileSize:   fileSize,
+		UploadedBy: attachment.UploadedBy,
+		CreatedAt:  attachment.CreatedAt,
+		FileKey:    attachment.FileURL,
+	}
+}
@@ -0,0 +1,327 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/db/db"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func (s *Store) CreateAutomation(ctx context.Context, automation *types.CreateAutomation) (*types.Automation, error) {
+	trigger, err := json.Marshal(automation.Trigger)
+	if err != nil {
+		return nil, err
+	}
+
+	actions, err := json.Marshal(automation.Actions)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.db.Automation.CreateOne(
+		db.Automation.Name.Set(automation.Name),
+		db.Automation.Trigger.Set(string(trigger)),
+		db.Automation.Action.Set(string(actions)),
+		db.Automation.CreatedBy.Set(automation.CreatedBy),
+		db.Automation.Board.Link(
+			db.Board.ID.Equals(automation.BoardID),
+		),
+		db.Automation.Enabled.SetIfPresent(automation.Enabled),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAutomation(created)
+}
+
+func (s *Store) ListBoardAutomations(ctx context.Context, boardID string) ([]*types.Automation, error) {
+	automations, err := s.db.Automation.FindMany(
+		db.Automation.BoardID.Equals(boardID),
+	).OrderBy(
+		db.Automation.CreatedAt.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAutomations(automations)
+}
+
+// ListEnabledAutomations returns the enabled rules fired by triggerType. An
+// empty boardID searches every board.
+func (s *Store) ListEnabledAutomations(ctx context.Context, boardID, triggerType string) ([]*types.Automation, error) {
+	filters := []db.AutomationWhereParam{
+		db.Automation.Enabled.Equals(true),
+	}
+	if boardID != "" {
+		filters = append(filters, db.Automation.BoardID.Equals(boardID))
+	}
+
+	automations, err := s.db.Automation.FindMany(
+		filters...,
+	).OrderBy(
+		db.Automation.CreatedAt.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := toAutomations(automations)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.Automation, 0, len(all))
+	for _, automation := range all {
+		if automation.Trigger.Type == triggerType {
+			res = append(res, automation)
+		}
+	}
+	return res, nil
+}
+
+func (s *Store) GetAutomation(ctx context.Context, automationID string) (*types.Automation, error) {
+	automation, err := s.db.Automation.FindUnique(
+		db.Automation.ID.Equals(automationID),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return toAutomation(automation)
+}
+
+func (s *Store) UpdateAutomation(ctx context.Context, automation *types.UpdateAutomation) (*types.Automation, error) {
+	params := []db.AutomationSetParam{
+		db.Automation.Name.SetIfPresent(automation.Name),
+		db.Automation.Enabled.SetIfPresent(automation.Enabled),
+	}
+
+	if automation.Trigger != nil {
+		trigger, err := json.Marshal(automation.Trigger)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, db.Automation.Trigger.Set(string(trigger)))
+	}
+
+	if automation.Actions != nil {
+		actions, err := json.Marshal(automation.Actions)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, db.Automation.Action.Set(string(actions)))
+	}
+
+	updated, err := s.db.Automation.FindUnique(
+		db.Automation.ID.Equals(automation.AutomationID),
+	).Update(
+		params...,
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAutomation(updated)
+}
+
+func (s *Store) DeleteAutomation(ctx context.Context, automationID string) error {
+	_, err := s.db.Automation.FindUnique(
+		db.Automation.ID.Equals(automationID),
+	).Delete().Exec(ctx)
+	return err
+}
+
+// BoardRefsExist reports whether every list, label and user in refs belongs
+// to the board.
+func (s *Store) BoardRefsExist(ctx context.Context, boardID string, refs *types.AutomationRefs) (bool, error) {
+	if ids := unique(refs.ListIDs); len(ids) > 0 {
+		lists, err := s.db.List.FindMany(
+			db.List.BoardID.Equals(boardID),
+			db.List.ID.In(ids),
+		).Exec(ctx)
+		if err != nil {
+			return false, err
+		}
+		if len(lists) != len(ids) {
+			return false, nil
+		}
+	}
+
+	if ids := unique(refs.LabelIDs); len(ids) > 0 {
+		labels, err := s.db.Label.FindMany(
+			db.Label.BoardID.Equals(boardID),
+			db.Label.ID.In(ids),
+		).Exec(ctx)
+		if err != nil {
+			return false, err
+		}
+		if len(labels) != len(ids) {
+			return false, nil
+		}
+	}
+
+	if ids := unique(refs.UserIDs); len(ids) > 0 {
+		members, err := s.db.BoardMember.FindMany(
+			db.BoardMember.BoardID.Equals(boardID),
+			db.BoardMember.UserID.In(ids),
+		).Exec(ctx)
+		if err != nil {
+			return false, err
+		}
+		if len(members) != len(ids) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (s *Store) CreateAutomationRun(ctx context.Context, run *types.CreateAutomationRun) error {
+	params := []db.AutomationRunSetParam{}
+	if run.CardID != "" {
+		params = append(params, db.AutomationRun.CardID.Set(run.CardID))
+	}
+	if run.Error != "" {
+		params = append(params, db.AutomationRun.Error.Set(run.Error))
+	}
+
+	_, err := s.db.AutomationRun.CreateOne(
+		db.AutomationRun.Trigger.Set(run.Trigger),
+		db.AutomationRun.Status.Set(run.Status),
+		db.AutomationRun.Automation.Link(
+			db.Automation.ID.Equals(run.AutomationID),
+		),
+		params...,
+	).Exec(ctx)
+	return err
+}
+
+func (s *Store) ListAutomationRuns(ctx context.Context, automationID string, paginate *types.Paginate) ([]*types.AutomationRun, error) {
+	runs, err := s.db.AutomationRun.FindMany(
+		db.AutomationRun.AutomationID.Equals(automationID),
+	).OrderBy(
+		db.AutomationRun.CreatedAt.Order(db.SortOrder(paginate.SortOrder)),
+	).Skip(paginate.Offset).Take(paginate.Size).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.AutomationRun, 0, len(runs))
+	for _, run := range runs {
+		cardID, _ := run.CardID()
+		runErr, _ := run.Error()
+
+		res = append(res, &types.AutomationRun{
+			ID:           run.ID,
+			AutomationID: run.AutomationID,
+			CardID:       cardID,
+			Trigger:      run.Trigger,
+			Status:       run.Status,
+			Error:        runErr,
+			CreatedAt:    run.CreatedAt,
+		})
+	}
+	return res, nil
+}
+
+// ListOverdueCardIDs returns the open cards on the board whose due date has
+// passed and that the automation has not run for since they became due, so
+// moving a card's due date lets the rule fire again.
+func (s *Store) ListOverdueCardIDs(ctx context.Context, boardID, automationID string) ([]string, error) {
+	cards, err := s.db.Card.FindMany(
+		db.Card.BoardID.Equals(boardID),
+		db.Card.DueDate.Lt(time.Now()),
+		db.Card.Completed.Equals(false),
+		db.Card.Archived.Equals(false),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(cards) == 0 {
+		return nil, nil
+	}
+
+	cardIDs := make([]string, 0, len(cards))
+	for _, card := range cards {
+		cardIDs = append(cardIDs, card.ID)
+	}
+
+	runs, err := s.db.AutomationRun.FindMany(
+		db.AutomationRun.AutomationID.Equals(automationID),
+		db.AutomationRun.CardID.In(cardIDs),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lastRun := make(map[string]time.Time, len(runs))
+	for _, run := range runs {
+		cardID, _ := run.CardID()
+		if run.CreatedAt.After(lastRun[cardID]) {
+			lastRun[cardID] = run.CreatedAt
+		}
+	}
+
+	var res []string
+	for _, card := range cards {
+		dueDate, _ := card.DueDate()
+		if last, ok := lastRun[card.ID]; ok && !last.Before(dueDate) {
+			continue
+		}
+		res = append(res, card.ID)
+	}
+	return res, nil
+}
+
+func toAutomations(automations []db.AutomationModel) ([]*types.Automation, error) {
+	res := make([]*types.Automation, 0, len(automations))
+	for _, automation := range automations {
+		a, err := toAutomation(&automation)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, a)
+	}
+	return res, nil
+}
+
+func toAutomation(automation *db.AutomationModel) (*types.Automation, error) {
+	res := &types.Automation{
+		ID:        automation.ID,
+		BoardID:   automation.BoardID,
+		Name:      automation.Name,
+		Enabled:   automation.Enabled,
+		CreatedBy: automation.CreatedBy,
+		CreatedAt: automation.CreatedAt,
+		UpdatedAt: automation.UpdatedAt,
+	}
+
+	if err := json.Unmarshal([]byte(automation.Trigger), &res.Trigger); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(automation.Action), &res.Actions); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func unique(ids []string) []string {
+	seen := make(map[string]struct{}, len(ids))
+	res := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		res = append(res, id)
+	}
+	return res
+}
@@ -2,24 +2,290 @@ package store
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"time"
 
+	"github.com/steebchen/prisma-client-go/runtime/transaction"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/db/db"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
 )
 
+var (
+	// ErrInvitationPending is returned when inviting an email that already
+	// has a live invitation to the board.
+	ErrInvitationPending = errors.New("store: invitation already pending")
+
+	// ErrInvitationClosed is returned when acting on an invitation that was
+	// already accepted, declined or revoked.
+	ErrInvitationClosed = errors.New("store: invitation is no longer pending")
+
+	// ErrInvitationEmailMismatch is returned when someone other than the
+	// invited email tries to answer an invitation.
+	ErrInvitationEmailMismatch = errors.New("store: invitation was sent to another email")
+)
+
 func (s *Store) GetBoardInvitationByToken(ctx context.Context, token string) (*types.BoardInvitation, error) {
 	invitation, err := s.db.BoardInvitation.FindUnique(
 		db.BoardInvitation.Token.Equals(token),
 	).Exec(ctx)
 	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil, ErrNotFound
+		}
 		return nil, err
 	}
 
+	return toBoardInvitation(invitation), nil
+}
+
+// ListBoardInvitations returns the board's invitations, newest first,
+// optionally narrowed to one status.
+func (s *Store) ListBoardInvitations(ctx context.Context, boardID, status string) ([]*types.BoardInvitation, error) {
+	params := []db.BoardInvitationWhereParam{
+		db.BoardInvitation.BoardID.Equals(boardID),
+	}
+	if status != "" {
+		params = append(params, db.BoardInvitation.Status.Equals(status))
+	}
+
+	invitations, err := s.db.BoardInvitation.FindMany(
+		params...,
+	).OrderBy(
+		db.BoardInvitation.CreatedAt.Order(db.SortOrderDesc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.BoardInvitation, 0, len(invitations))
+	for _, invitation := range invitations {
+		res = append(res, toBoardInvitation(&invitation))
+	}
+
+	return res, nil
+}
+
+// ResendBoardInvitation gives a pending invitation a new token and expiry,
+// so the previous link stops working. It returns ErrNotFound unless the
+// invitation is pending on the board.
+func (s *Store) ResendBoardInvitation(ctx context.Context, boardID, invitationID, token string, expiresAt time.Time) (*types.BoardInvitation, error) {
+	res, err := s.db.BoardInvitation.FindMany(
+		db.BoardInvitation.ID.Equals(invitationID),
+		db.BoardInvitation.BoardID.Equals(boardID),
+		db.BoardInvitation.Status.Equals(types.InvitationPending),
+	).Update(
+		db.BoardInvitation.Token.Set(token),
+		db.BoardInvitation.ExpiresAt.Set(expiresAt),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if res.Count == 0 {
+		return nil, ErrNotFound
+	}
+
+	return s.GetBoardInvitationByToken(ctx, token)
+}
+
+// RevokeBoardInvitation withdraws a pending invitation. It returns
+// ErrNotFound unless the invitation is pending on the board.
+func (s *Store) RevokeBoardInvitation(ctx context.Context, boardID, invitationID string) error {
+	res, err := s.db.BoardInvitation.FindMany(
+		db.BoardInvitation.ID.Equals(invitationID),
+		db.BoardInvitation.BoardID.Equals(boardID),
+		db.BoardInvitation.Status.Equals(types.InvitationPending),
+	).Update(
+		db.BoardInvitation.Status.Set(types.InvitationRevoked),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if res.Count == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// AcceptBoardInvitation adds the user to the board with the invited role.
+// The invitation must be pending, unexpired and addressed to email;
+// otherwise it returns ErrInvitationClosed, ErrTokenExpired or
+// ErrInvitationEmailMismatch. It returns ErrAlreadyMember if the user
+// already belongs to the board.
+func (s *Store) AcceptBoardInvitation(ctx context.Context, token, userID, email string) (*types.BoardInvitation, error) {
+	invitation, err := s.openBoardInvitation(ctx, token, email)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetBoardMember(ctx, invitation.BoardID, userID); err == nil {
+		return nil, ErrAlreadyMember
+	} else if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	invitation.Role = types.NormalizeRole(invitation.Role)
+
+	invitationTxn := s.db.BoardInvitation.FindUnique(
+		db.BoardInvitation.Token.Equals(token),
+	).Update(
+		db.BoardInvitation.Status.Set(types.InvitationAccepted),
+	).Tx()
+
+	memberTxn := s.db.BoardMember.CreateOne(
+		db.BoardMember.Board.Link(
+			db.Board.ID.Equals(invitation.BoardID),
+		),
+		db.BoardMember.User.Link(
+			db.User.ID.Equals(userID),
+		),
+		db.BoardMember.Role.Set(invitation.Role),
+		db.BoardMember.JoinedAt.Set(time.Now()),
+	).Tx()
+
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: invitation.BoardID,
+		kind:    types.ActivityInvitationAccepted,
+		after:   map[string]any{"email": invitation.Email, "role": invitation.Role, "invitedBy": invitation.InvitedBy},
+		actorID: userID,
+	})
+
+	if err := s.db.Prisma.Transaction(invitationTxn, memberTxn, activityTxn).Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	invitation.Status = types.InvitationAccepted
+	return invitation, nil
+}
+
+// DeclineBoardInvitation turns a pending invitation down on behalf of the
+// invited email, with the same checks as AcceptBoardInvitation.
+func (s *Store) DeclineBoardInvitation(ctx context.Context, token, email string) error {
+	invitation, err := s.openBoardInvitation(ctx, token, email)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.db.BoardInvitation.FindMany(
+		db.BoardInvitation.ID.Equals(invitation.ID),
+		db.BoardInvitation.Status.Equals(types.InvitationPending),
+	).Update(
+		db.BoardInvitation.Status.Set(types.InvitationDeclined),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if res.Count == 0 {
+		return ErrInvitationClosed
+	}
+	return nil
+}
+
+// pendingInvitationTxs builds the writes that accept every live invitation
+// sent to email for userID, who has just proven they own the address. Each
+// board is joined once, with the role of its oldest invitation; boards the
+// user already belongs to are skipped but their invitations still close.
+func (s *Store) pendingInvitationTxs(ctx context.Context, userID, email string) ([]transaction.Param, error) {
+	invitations, err := s.db.BoardInvitation.FindMany(
+		db.BoardInvitation.Email.Equals(email),
+		db.BoardInvitation.Email.Mode(db.QueryModeInsensitive),
+		db.BoardInvitation.Status.Equals(types.InvitationPending),
+		db.BoardInvitation.ExpiresAt.Gt(time.Now()),
+	).OrderBy(
+		db.BoardInvitation.CreatedAt.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil || len(invitations) == 0 {
+		return nil, err
+	}
+
+	memberships, err := s.db.BoardMember.FindMany(
+		db.BoardMember.UserID.Equals(userID),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	joined := make(map[string]bool, len(memberships))
+	for _, membership := range memberships {
+		joined[membership.BoardID] = true
+	}
+
+	var txns []transaction.Param
+	for _, invitation := range invitations {
+		txns = append(txns, s.db.BoardInvitation.FindUnique(
+			db.BoardInvitation.ID.Equals(invitation.ID),
+		).Update(
+			db.BoardInvitation.Status.Set(types.InvitationAccepted),
+		).Tx())
+
+		if joined[invitation.BoardID] {
+			continue
+		}
+		joined[invitation.BoardID] = true
+
+		role := types.NormalizeRole(invitation.Role)
+		txns = append(txns,
+			s.db.BoardMember.CreateOne(
+				db.BoardMember.Board.Link(
+					db.Board.ID.Equals(invitation.BoardID),
+				),
+				db.BoardMember.User.Link(
+					db.User.ID.Equals(userID),
+				),
+				db.BoardMember.Role.Set(role),
+				db.BoardMember.JoinedAt.Set(time.Now()),
+			).Tx(),
+			s.activityTx(ctx, activity{
+				boardID: invitation.BoardID,
+				kind:    types.ActivityInvitationAccepted,
+				after:   map[string]any{"email": invitation.Email, "role": role, "invitedBy": invitation.InvitedBy},
+				actorID: userID,
+			}),
+		)
+	}
+
+	return txns, nil
+}
+
+// openBoardInvitation loads an invitation that email may still answer.
+func (s *Store) openBoardInvitation(ctx context.Context, token, email string) (*types.BoardInvitation, error) {
+	invitation, err := s.GetBoardInvitationByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkOpenInvitation(invitation, email, time.Now()); err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// checkOpenInvitation reports why email may not answer invitation at now:
+// it is no longer pending, it expired, or it was sent to another address.
+// Emails are compared case-insensitively.
+func checkOpenInvitation(invitation *types.BoardInvitation, email string, now time.Time) error {
+	if invitation.Status != types.InvitationPending {
+		return ErrInvitationClosed
+	}
+	if now.After(invitation.ExpiredAt) {
+		return ErrTokenExpired
+	}
+	if !strings.EqualFold(invitation.Email, email) {
+		return ErrInvitationEmailMismatch
+	}
+	return nil
+}
+
+func toBoardInvitation(invitation *db.BoardInvitationModel) *types.BoardInvitation {
 	return &types.BoardInvitation{
+		ID:        invitation.ID,
 		BoardID:   invitation.BoardID,
 		Email:     invitation.Email,
+		InvitedBy: invitation.InvitedBy,
 		Token:     invitation.Token,
 		Role:      invitation.Role,
 		ExpiredAt: invitation.ExpiresAt,
-	}, nil
+		Status:    invitation.Status,
+		CreatedAt: invitation.CreatedAt,
+	}
 }
@@ -0,0 +1,40 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func TestCheckOpenInvitation(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		status    string
+		expiredAt time.Time
+		email     string
+		want      error
+	}{
+		{"pending and addressed to the user", types.InvitationPending, now.Add(time.Hour), "jane@example.com", nil},
+		{"email in another case", types.InvitationPending, now.Add(time.Hour), "JANE@example.COM", nil},
+		{"another email", types.InvitationPending, now.Add(time.Hour), "john@example.com", ErrInvitationEmailMismatch},
+		{"expired", types.InvitationPending, now.Add(-time.Second), "jane@example.com", ErrTokenExpired},
+		{"declined", types.InvitationDeclined, now.Add(time.Hour), "jane@example.com", ErrInvitationClosed},
+		{"accepted", types.InvitationAccepted, now.Add(time.Hour), "jane@example.com", ErrInvitationClosed},
+		{"revoked", types.InvitationRevoked, now.Add(time.Hour), "jane@example.com", ErrInvitationClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invitation := &types.BoardInvitation{
+				Email:     "jane@example.com",
+				Status:    tt.status,
+				ExpiredAt: tt.expiredAt,
+			}
+			assert.Equal(t, tt.want, checkOpenInvitation(invitation, tt.email, now))
+		})
+	}
+}
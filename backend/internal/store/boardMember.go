@@ -2,11 +2,18 @@ package store
 
 import (
 	"context"
+	"errors"
+	"strings"
 
+	"github.com/steebchen/prisma-client-go/runtime/transaction"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/db/db"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
 )
 
+// ErrLastAdmin is returned when a role change or removal would leave a
+// board without an admin.
+var ErrLastAdmin = errors.New("store: board must keep at least one admin")
+
 func (s *Store) GetBoardMember(ctx context.Context, boardID, memberID string) (*types.BoardMember, error) {
 	boardMember, err := s.db.BoardMember.FindUnique(
 		db.BoardMember.BoardIDUserID(
@@ -19,6 +26,9 @@ func (s *Store) GetBoardMember(ctx context.Context, boardID, memberID string) (*
 		),
 	).Exec(ctx)
 	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil, ErrNotFound
+		}
 		return nil, err
 	}
 
@@ -29,3 +39,192 @@ func (s *Store) GetBoardMember(ctx context.Context, boardID, memberID string) (*
 		Role:      boardMember.Role,
 	}, nil
 }
+
+// GetBoardAccess resolves the role the user acts with on the board: their
+// membership, or observer on a team board of a workspace they belong to. It
+// returns ErrNotFound when the user has no access.
+func (s *Store) GetBoardAccess(ctx context.Context, boardID, userID string) (*types.BoardMember, error) {
+	member, err := s.GetBoardMember(ctx, boardID, userID)
+	if !errors.Is(err, ErrNotFound) {
+		return member, err
+	}
+
+	board, err := s.db.Board.FindFirst(
+		db.Board.ID.Equals(boardID),
+		db.Board.Visibility.Equals(types.BoardVisibilityTeam),
+		db.Board.Workspace.Where(
+			db.Workspace.Members.Some(
+				db.WorkspaceMember.UserID.Equals(userID),
+			),
+		),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &types.BoardMember{
+		BoardName: board.Name,
+		BoardID:   boardID,
+		UserID:    userID,
+		Role:      types.RoleObserver,
+	}, nil
+}
+
+// UpdateBoardMemberRole changes a member's role. It returns ErrNotFound if
+// the user is not a member and ErrLastAdmin when demoting the only admin.
+func (s *Store) UpdateBoardMemberRole(ctx context.Context, payload *types.UpdateBoardMemberRole) error {
+	member, err := s.GetBoardMember(ctx, payload.BoardID, payload.UserID)
+	if err != nil {
+		return err
+	}
+
+	current := types.NormalizeRole(member.Role)
+	if current == payload.Role {
+		return nil
+	}
+
+	demotesAdmin := current == types.RoleAdmin
+	if demotesAdmin {
+		if err := s.ensureOtherAdmin(ctx, payload.BoardID); err != nil {
+			return err
+		}
+	}
+
+	memberTxn := s.db.BoardMember.FindUnique(
+		db.BoardMember.BoardIDUserID(
+			db.BoardMember.BoardID.Equals(payload.BoardID),
+			db.BoardMember.UserID.Equals(payload.UserID),
+		),
+	).Update(
+		db.BoardMember.Role.Set(payload.Role),
+	).Tx()
+
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: payload.BoardID,
+		kind:    types.ActivityMemberRoleChanged,
+		before:  map[string]any{"userID": payload.UserID, "role": current},
+		after:   map[string]any{"userID": payload.UserID, "role": payload.Role},
+	})
+
+	txns := []transaction.Param{memberTxn, activityTxn}
+	if demotesAdmin {
+		lockTxn, guardTxn := s.adminGuardTxs(payload.BoardID)
+		txns = append([]transaction.Param{lockTxn}, append(txns, guardTxn)...)
+	}
+
+	if err := s.db.Prisma.Transaction(txns...).Exec(ctx); err != nil {
+		return adminChangeErr(err)
+	}
+	return nil
+}
+
+// RemoveBoardMember takes the user off the board along with their card
+// assignments on it. kind records whether they were removed or left. It
+// returns ErrNotFound if the user is not a member and ErrLastAdmin when
+// removing the only admin.
+func (s *Store) RemoveBoardMember(ctx context.Context, boardID, userID, kind string) error {
+	member, err := s.GetBoardMember(ctx, boardID, userID)
+	if err != nil {
+		return err
+	}
+
+	role := types.NormalizeRole(member.Role)
+	removesAdmin := role == types.RoleAdmin
+	if removesAdmin {
+		if err := s.ensureOtherAdmin(ctx, boardID); err != nil {
+			return err
+		}
+	}
+
+	cardMembersTxn := s.db.CardMember.FindMany(
+		db.CardMember.UserID.Equals(userID),
+		db.CardMember.Card.Where(
+			db.Card.BoardID.Equals(boardID),
+		),
+	).Delete().Tx()
+
+	memberTxn := s.db.BoardMember.FindUnique(
+		db.BoardMember.BoardIDUserID(
+			db.BoardMember.BoardID.Equals(boardID),
+			db.BoardMember.UserID.Equals(userID),
+		),
+	).Delete().Tx()
+
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: boardID,
+		kind:    kind,
+		before:  map[string]any{"userID": userID, "role": role},
+	})
+
+	txns := []transaction.Param{cardMembersTxn, memberTxn, activityTxn}
+	if removesAdmin {
+		lockTxn, guardTxn := s.adminGuardTxs(boardID)
+		txns = append([]transaction.Param{lockTxn}, append(txns, guardTxn)...)
+	}
+
+	if err := s.db.Prisma.Transaction(txns...).Exec(ctx); err != nil {
+		return adminChangeErr(err)
+	}
+	return nil
+}
+
+// ensureOtherAdmin returns ErrLastAdmin unless the board has more than one
+// admin, so that one of them can step down.
+func (s *Store) ensureOtherAdmin(ctx context.Context, boardID string) error {
+	admins, err := s.db.BoardMember.FindMany(
+		db.BoardMember.BoardID.Equals(boardID),
+		db.BoardMember.Role.Equals(types.RoleAdmin),
+	).Take(2).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(admins) < 2 {
+		return ErrLastAdmin
+	}
+	return nil
+}
+
+// lastAdminError is the message of the exception adminGuardTxs raises.
+const lastAdminError = "last_admin"
+
+// adminGuardTxs returns the statements that wrap a transaction taking an
+// admin off the board. The lock goes first and serializes such changes per
+// board, so two admins stepping down at once cannot both pass
+// ensureOtherAdmin; it also hands the board to the guard, since a DO block
+// takes no parameters. The guard goes last and raises lastAdminError when
+// no admin is left, which rolls the transaction back.
+func (s *Store) adminGuardTxs(boardID string) (lock, guard transaction.Param) {
+	lock = s.db.Prisma.ExecuteRaw(
+		`SELECT pg_advisory_xact_lock(hashtext('board_admins:' || $1)), set_config('nexus.admin_guard_board', $1, true)`,
+		boardID,
+	).Tx()
+	guard = s.db.Prisma.ExecuteRaw(
+		`DO $$
+		BEGIN
+			IF NOT EXISTS (
+				SELECT 1 FROM board_members
+				WHERE "boardId" = current_setting('nexus.admin_guard_board') AND role = '` + types.RoleAdmin + `'
+			) THEN
+				RAISE EXCEPTION '` + lastAdminError + `';
+			END IF;
+		END $$`,
+	).Tx()
+	return lock, guard
+}
+
+// adminChangeErr maps the error of a failed role change or removal,
+// turning the exception raised by the guard from adminGuardTxs into
+// ErrLastAdmin.
+func adminChangeErr(err error) error {
+	if db.IsErrNotFound(err) {
+		return ErrNotFound
+	}
+	if strings.Contains(err.Error(), lastAdminError) {
+		return ErrLastAdmin
+	}
+	return err
+}
@@ -0,0 +1,37 @@
+package store
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminChangeErr(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "guard raised",
+			err:  errors.New(`Raw query failed. Code: P0001. Message: ERROR: last_admin`),
+			want: ErrLastAdmin,
+		},
+		{
+			name: "other failure",
+			err:  errors.New("connection reset by peer"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := adminChangeErr(tt.err)
+			if tt.want != nil {
+				assert.ErrorIs(t, got, tt.want)
+			} else {
+				assert.Equal(t, tt.err, got)
+			}
+		})
+	}
+}
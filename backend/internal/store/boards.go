@@ -11,14 +11,23 @@ import (
 
 func (s *Store) CreateBoard(ctx context.Context, board *types.CreateBoard) error {
 	boardID := uuid.New().String()
+	params := []db.BoardSetParam{
+		db.Board.ID.Set(boardID),
+		db.Board.Visibility.SetIfPresent(&board.Visibility),
+		db.Board.Background.SetIfPresent(&board.Background),
+	}
+	if board.WorkspaceID != "" {
+		params = append(params, db.Board.Workspace.Link(
+			db.Workspace.ID.Equals(board.WorkspaceID),
+		))
+	}
+
 	boardTxn := s.db.Board.CreateOne(
 		db.Board.Name.Set(board.Name),
 		db.Board.Owner.Link(
 			db.User.ID.Equals(board.OwnerID),
 		),
-		db.Board.ID.Set(boardID),
-		db.Board.Visibility.SetIfPresent(&board.Visibility),
-		db.Board.Background.SetIfPresent(&board.Background),
+		params...,
 	).Tx()
 
 	boardMemTxn := s.db.BoardMember.CreateOne(
@@ -31,44 +40,149 @@ func (s *Store) CreateBoard(ctx context.Context, board *types.CreateBoard) error
 		db.BoardMember.Role.Set("admin"),
 	).Tx()
 
-	return s.db.Prisma.Transaction(boardTxn, boardMemTxn).Exec(ctx)
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: boardID,
+		kind:    types.ActivityBoardCreated,
+		after:   map[string]any{"name": board.Name},
+	})
+
+	return s.db.Prisma.Transaction(boardTxn, boardMemTxn, activityTxn).Exec(ctx)
 }
 
-func (s *Store) ListBoards(ctx context.Context, ownerID string, paginate *types.Paginate) ([]*types.Board, error) {
-	query := s.db.Board.FindMany(
-		db.Board.UserID.Equals(ownerID),
-	).Skip(paginate.Offset).Take(paginate.Size)
+// ListBoards returns the boards the user is a member of, whether they own
+// them or joined through an invitation, with their role and star on each.
+func (s *Store) ListBoards(ctx context.Context, filter *types.ListBoardsFilter, paginate *types.Paginate) ([]*types.Board, error) {
+	membership := []db.BoardMemberWhereParam{
+		db.BoardMember.UserID.Equals(filter.UserID),
+	}
+	if filter.Starred {
+		membership = append(membership, db.BoardMember.Starred.Equals(true))
+	}
+
+	params := []db.BoardWhereParam{
+		db.Board.BoardMembers.Some(membership...),
+	}
+
+	switch filter.Archived {
+	case "", "false":
+		params = append(params, db.Board.Archived.Equals(false))
+	case "true":
+		params = append(params, db.Board.Archived.Equals(true))
+	}
 
-	if paginate.SortBy == "created_at" {
-		query.OrderBy(
-			db.Board.CreatedAt.Order(db.SortOrder(paginate.SortOrder)),
+	switch filter.Ownership {
+	case "owned":
+		params = append(params, db.Board.UserID.Equals(filter.UserID))
+	case "shared":
+		params = append(params, db.Board.UserID.Not(filter.UserID))
+	}
+
+	if filter.Visibility != "" {
+		params = append(params, db.Board.Visibility.Equals(filter.Visibility))
+	}
+
+	if filter.Search != "" {
+		params = append(params,
+			db.Board.Name.Contains(filter.Search),
+			db.Board.Name.Mode(db.QueryModeInsensitive),
 		)
 	}
 
-	boards, err := query.Exec(ctx)
+	order := db.SortOrder(paginate.SortOrder)
+	var orderBy db.BoardOrderByParam
+	switch filter.SortBy {
+	case "name":
+		orderBy = db.Board.Name.Order(order)
+	case "updated_at":
+		orderBy = db.Board.UpdatedAt.Order(order)
+	default:
+		orderBy = db.Board.CreatedAt.Order(order)
+	}
+
+	boards, err := s.db.Board.FindMany(
+		params...,
+	).With(
+		db.Board.BoardMembers.Fetch(
+			db.BoardMember.UserID.Equals(filter.UserID),
+		),
+	).OrderBy(
+		orderBy,
+		db.Board.ID.Order(db.SortOrderAsc),
+	).Skip(paginate.Offset).Take(paginate.Size).Exec(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var listRes []*types.Board
+	listRes := make([]*types.Board, 0, len(boards))
 	for _, board := range boards {
-		background, ok := board.Background()
-		if !ok {
-			background = "#FFFFFF"
+		res := toBoard(&board)
+		res.Owned = board.UserID == filter.UserID
+		if members := board.BoardMembers(); len(members) > 0 {
+			res.Role = types.NormalizeRole(members[0].Role)
+			res.Starred = members[0].Starred
 		}
-
-		listRes = append(listRes, &types.Board{
-			ID:         board.ID,
-			Name:       board.Name,
-			Background: background,
-		})
+		listRes = append(listRes, res)
 	}
 
 	return listRes, nil
 }
 
+// SetBoardStarred stars or unstars the board for one of its members. It
+// returns ErrNotFound if the user is not a member.
+func (s *Store) SetBoardStarred(ctx context.Context, boardID, userID string, starred bool) error {
+	_, err := s.db.BoardMember.FindUnique(
+		db.BoardMember.BoardIDUserID(
+			db.BoardMember.BoardID.Equals(boardID),
+			db.BoardMember.UserID.Equals(userID),
+		),
+	).Update(
+		db.BoardMember.Starred.Set(starred),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func toBoard(board *db.BoardModel) *types.Board {
+	background, ok := board.Background()
+	if !ok {
+		background = "#FFFFFF"
+	}
+	workspaceID, _ := board.WorkspaceID()
+
+	return &types.Board{
+		ID:          board.ID,
+		Name:        board.Name,
+		Background:  background,
+		Visibility:  board.Visibility,
+		WorkspaceID: workspaceID,
+		Archived:    board.Archived,
+	}
+}
+
+// CreateBoardInvitation stores a pending invitation. The email does not need
+// an account yet; the invitation is accepted for them once they sign up and
+// prove they own it. It returns ErrInvitationPending when the email already
+// has a live invitation to the board, which should be resent instead.
 func (s *Store) CreateBoardInvitation(ctx context.Context, invitation *types.BoardInvitation) error {
-	_, err := s.db.BoardInvitation.CreateOne(
+	pending, err := s.db.BoardInvitation.FindMany(
+		db.BoardInvitation.BoardID.Equals(invitation.BoardID),
+		db.BoardInvitation.Email.Equals(invitation.Email),
+		db.BoardInvitation.Status.Equals(types.InvitationPending),
+		db.BoardInvitation.ExpiresAt.Gt(time.Now()),
+	).Take(1).Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return ErrInvitationPending
+	}
+
+	invitationTxn := s.db.BoardInvitation.CreateOne(
 		db.BoardInvitation.Email.Set(invitation.Email),
 		db.BoardInvitation.Token.Set(invitation.Token),
 		db.BoardInvitation.Role.Set(invitation.Role),
@@ -77,10 +191,35 @@ func (s *Store) CreateBoardInvitation(ctx context.Context, invitation *types.Boa
 			db.Board.ID.Equals(invitation.BoardID),
 		),
 		db.BoardInvitation.InvitedByUser.Link(
-			db.User.Email.Equals(invitation.Email),
+			db.User.ID.Equals(invitation.InvitedBy),
 		),
+	).Tx()
+
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: invitation.BoardID,
+		kind:    types.ActivityMemberInvited,
+		after:   map[string]any{"email": invitation.Email, "role": invitation.Role, "invitedBy": invitation.InvitedBy},
+		actorID: invitation.InvitedBy,
+	})
+
+	invitee, err := s.db.User.FindUnique(
+		db.User.Email.Equals(invitation.Email),
 	).Exec(ctx)
-	return err
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return s.db.Prisma.Transaction(invitationTxn, activityTxn).Exec(ctx)
+		}
+		return err
+	}
+
+	notificationTxn := s.notificationTx(&types.CreateNotification{
+		UserID:  invitee.ID,
+		Type:    types.NotificationBoardInvitation,
+		BoardID: invitation.BoardID,
+		ActorID: invitation.InvitedBy,
+	})
+
+	return s.db.Prisma.Transaction(invitationTxn, activityTxn, notificationTxn).Exec(ctx)
 }
 
 func (s *Store) IsABoardMember(ctx context.Context, email, boardID string) (bool, error) {
@@ -97,39 +236,25 @@ func (s *Store) IsABoardMember(ctx context.Context, email, boardID string) (bool
 	return len(members) == 0, nil
 }
 
-func (s *Store) AcceptBoardInvitation(ctx context.Context, token, userID, role string) error {
-	// First, retrieve the invitation to get the associated board ID
-	invitation, err := s.db.BoardInvitation.FindUnique(
-		db.BoardInvitation.Token.Equals(token),
+func (s *Store) UpdateBoard(ctx context.Context, board *types.UpdateBoard) error {
+	current, err := s.db.Board.FindUnique(
+		db.Board.ID.Equals(board.BoardID),
 	).Exec(ctx)
 	if err != nil {
 		return err
 	}
 
-	// Update the invitation status to "accepted"
-	boardTxn := s.db.BoardInvitation.FindUnique(
-		db.BoardInvitation.Token.Equals(token),
-	).Update(
-		db.BoardInvitation.Status.Set("accepted"),
-	).Tx()
+	description, _ := current.Description()
+	background, _ := current.Background()
 
-	// Create a board member linking to the correct board ID from the invitation
-	memberTxn := s.db.BoardMember.CreateOne(
-		db.BoardMember.Board.Link(
-			db.Board.ID.Equals(invitation.BoardID),
-		),
-		db.BoardMember.User.Link(
-			db.User.ID.Equals(userID),
-		),
-		db.BoardMember.Role.Set(role),
-	).Tx()
-
-	// Execute both transactions atomically
-	return s.db.Prisma.Transaction(boardTxn, memberTxn).Exec(ctx)
-}
+	before, after := map[string]any{}, map[string]any{}
+	trackChange(before, after, "name", current.Name, board.Name)
+	trackChange(before, after, "description", description, board.Description)
+	trackChange(before, after, "visibility", current.Visibility, board.Visibility)
+	trackChange(before, after, "background", background, board.Background)
+	trackChange(before, after, "archived", current.Archived, board.Archived)
 
-func (s *Store) UpdateBoard(ctx context.Context, board *types.UpdateBoard) error {
-	_, err := s.db.Board.FindUnique(
+	boardTxn := s.db.Board.FindUnique(
 		db.Board.ID.Equals(board.BoardID),
 	).Update(
 		db.Board.Name.SetIfPresent(board.Name),
@@ -137,8 +262,16 @@ func (s *Store) UpdateBoard(ctx context.Context, board *types.UpdateBoard) error
 		db.Board.Visibility.SetIfPresent(board.Visibility),
 		db.Board.Background.SetIfPresent(board.Background),
 		db.Board.Archived.SetIfPresent(board.Archived),
-	).Exec(ctx)
-	return err
+	).Tx()
+
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: board.BoardID,
+		kind:    types.ActivityBoardUpdated,
+		before:  before,
+		after:   after,
+	})
+
+	return s.db.Prisma.Transaction(boardTxn, activityTxn).Exec(ctx)
 }
 
 func (s *Store) DeleteBoard(ctx context.Context, boardID string) error {
@@ -156,6 +289,7 @@ func (s *Store) GetCardsAndLists(ctx context.Context, boardID string) (*types.Bo
 			db.List.ID.Field(),
 			db.List.Name.Field(),
 			db.List.Position.Field(),
+			db.List.Color.Field(),
 		).OrderBy(
 			db.List.Position.Order(db.SortOrder("asc")),
 		).With(
@@ -184,6 +318,15 @@ func (s *Store) GetCardsAndLists(ctx context.Context, boardID string) (*types.Bo
 					db.Checklist.Name.Field(),
 					db.Checklist.Position.Field(),
 				),
+				db.Card.Dependencies.Fetch().Select(
+					db.CardDependency.DependsOnID.Field(),
+				),
+				db.Card.Dependents.Fetch().Select(
+					db.CardDependency.CardID.Field(),
+				),
+				db.Card.CardVotes.Fetch().Select(
+					db.CardVote.UserID.Field(),
+				),
 			).OrderBy(
 				db.Card.Position.Order(db.SortOrder("asc")),
 			),
@@ -197,10 +340,12 @@ func (s *Store) GetCardsAndLists(ctx context.Context, boardID string) (*types.Bo
 	board.ID = dbBoard.ID
 
 	for _, list := range dbBoard.Lists() {
+		listColor, _ := list.Color()
 		board.Lists = append(board.Lists, &types.List{
 			ID:       list.ID,
 			Name:     list.Name,
 			Position: list.Position,
+			Color:    listColor,
 		})
 
 		cards := list.Cards()
@@ -251,6 +396,16 @@ func (s *Store) GetCardsAndLists(ctx context.Context, boardID string) (*types.Bo
 				})
 			}
 
+			blockedBy := make([]string, 0)
+			for _, dep := range card.Dependencies() {
+				blockedBy = append(blockedBy, dep.DependsOnID)
+			}
+
+			blocking := make([]string, 0)
+			for _, dep := range card.Dependents() {
+				blocking = append(blocking, dep.CardID)
+			}
+
 			board.Cards = append(board.Cards, &types.MinimalCard{
 				ID:          card.ID,
 				ListID:      list.ID,
@@ -265,6 +420,9 @@ func (s *Store) GetCardsAndLists(ctx context.Context, boardID string) (*types.Bo
 				Labels:      cardLabels,
 				MemberIDs:   memberIDs,
 				Checklists:  checklists,
+				BlockedBy:   blockedBy,
+				Blocking:    blocking,
+				VoteCount:   len(card.CardVotes()),
 			})
 		}
 	}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/db/db"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
 )
 
-func (s *Store) CreateCard(ctx context.Context, card *types.CreateCard) error {
-	_, err := s.db.Card.CreateOne(
+func (s *Store) CreateCard(ctx context.Context, card *types.CreateCard) (*types.MinimalCard, error) {
+	cardID := uuid.New().String()
+	cardTxn := s.db.Card.CreateOne(
 		db.Card.Title.Set(card.Title),
 		db.Card.List.Link(
 			db.List.ID.Equals(card.ListID),
@@ -20,13 +22,85 @@ func (s *Store) CreateCard(ctx context.Context, card *types.CreateCard) error {
 		db.Card.Board.Link(
 			db.Board.ID.Equals(card.BoardID),
 		),
+		db.Card.ID.Set(cardID),
 		db.Card.Position.Set(card.Position),
-	).Exec(ctx)
-	return err
+	).Tx()
+
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: card.BoardID,
+		cardID:  cardID,
+		listID:  card.ListID,
+		kind:    types.ActivityCardCreated,
+		after:   map[string]any{"title": card.Title, "position": card.Position},
+	})
+
+	if err := s.db.Prisma.Transaction(cardTxn, activityTxn).Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	created := cardTxn.Result()
+	return &types.MinimalCard{
+		ID:         created.ID,
+		BoardID:    created.BoardID,
+		ListID:     created.ListID,
+		Title:      created.Title,
+		CoverSize:  "normal",
+		Completed:  created.Completed,
+		Position:   created.Position,
+		LabelIDs:   []string{},
+		MemberIDs:  []string{},
+		Checklists: []*types.CardChecklist{},
+		Labels:     []*types.BoardLabel{},
+		BlockedBy:  []string{},
+		Blocking:   []string{},
+	}, nil
 }
 
 func (s *Store) UpdateCard(ctx context.Context, cardID string, card *types.UpdateCard) error {
-	_, err := s.db.Card.FindUnique(
+	current, err := s.db.Card.FindUnique(
+		db.Card.ID.Equals(cardID),
+	).With(
+		db.Card.Dependencies.Fetch().With(
+			db.CardDependency.DependsOn.Fetch(),
+		),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	if card.Completed != nil && *card.Completed && !current.Completed && !card.Force {
+		for _, dep := range current.Dependencies() {
+			if !dep.DependsOn().Completed {
+				return ErrCardBlocked
+			}
+		}
+	}
+
+	description, _ := current.Description()
+	cover, _ := current.Cover()
+	coverSize, _ := current.CoverSize()
+	startDate, _ := current.StartDate()
+	dueDate, _ := current.DueDate()
+
+	before, after := map[string]any{}, map[string]any{}
+	trackChange(before, after, "title", current.Title, card.Title)
+	trackChange(before, after, "description", description, card.Description)
+	trackChange(before, after, "position", current.Position, card.Position)
+	trackChange(before, after, "cover", cover, card.Cover)
+	trackChange(before, after, "coverSize", coverSize, card.CoverSize)
+	trackChange(before, after, "archived", current.Archived, card.Archived)
+	trackChange(before, after, "completed", current.Completed, card.Completed)
+	trackChange(before, after, "startDate", startDate, card.StartDate)
+	trackChange(before, after, "dueDate", dueDate, card.DueDate)
+
+	kind := types.ActivityCardUpdated
+	if current.ListID != card.ListID {
+		kind = types.ActivityCardMoved
+		before["listID"] = current.ListID
+		after["listID"] = card.ListID
+	}
+
+	cardTxn := s.db.Card.FindUnique(
 		db.Card.ID.Equals(cardID),
 	).Update(
 		db.Card.Title.SetIfPresent(card.Title),
@@ -41,8 +115,18 @@ func (s *Store) UpdateCard(ctx context.Context, cardID string, card *types.Updat
 		db.Card.List.Link(
 			db.List.ID.Equals(card.ListID),
 		),
-	).Exec(ctx)
-	return err
+	).Tx()
+
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: current.BoardID,
+		cardID:  cardID,
+		listID:  card.ListID,
+		kind:    kind,
+		before:  before,
+		after:   after,
+	})
+
+	return s.db.Prisma.Transaction(cardTxn, activityTxn).Exec(ctx)
 }
 
 func (s *Store) GetCardDetail(ctx context.Context, cardID string) (*types.CompleteCard, error) {
@@ -56,6 +140,12 @@ func (s *Store) GetCardDetail(ctx context.Context, cardID string) (*types.Comple
 		db.Card.Checklists.Fetch().Select(
 			db.Checklist.ID.Field(),
 		),
+		db.Card.Dependencies.Fetch().With(
+			db.CardDependency.DependsOn.Fetch(),
+		),
+		db.Card.Dependents.Fetch().With(
+			db.CardDependency.Card.Fetch(),
+		),
 	).Exec(ctx)
 	if err != nil {
 		return nil, err
@@ -63,6 +153,7 @@ func (s *Store) GetCardDetail(ctx context.Context, cardID string) (*types.Comple
 
 	var card types.CompleteCard
 	card.ID = dbCard.ID
+	card.ListID = dbCard.ListID
 	card.Name = dbCard.Title
 	if desc, ok := dbCard.Description(); !ok {
 		card.Description = ""
@@ -107,37 +198,115 @@ func (s *Store) GetCardDetail(ctx context.Context, cardID string) (*types.Comple
 	for _, checklist := range dbCard.Checklists() {
 		card.ChecklistIDs = append(card.ChecklistIDs, checklist.ID)
 	}
+	card.BlockedBy = make([]*types.DependencyCard, 0)
+	for _, dep := range dbCard.Dependencies() {
+		card.BlockedBy = append(card.BlockedBy, toDependencyCard(dep.DependsOn()))
+	}
+	card.Blocking = make([]*types.DependencyCard, 0)
+	for _, dep := range dbCard.Dependents() {
+		card.Blocking = append(card.Blocking, toDependencyCard(dep.Card()))
+	}
 	return &card, nil
 }
 
+// GetCardBoardID returns the board a card is on, or ErrNotFound.
+func (s *Store) GetCardBoardID(ctx context.Context, cardID string) (string, error) {
+	card, err := s.db.Card.FindUnique(
+		db.Card.ID.Equals(cardID),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	return card.BoardID, nil
+}
+
 func (s *Store) DeleteCard(ctx context.Context, cardID string) error {
-	_, err := s.db.Card.FindUnique(
+	current, err := s.db.Card.FindUnique(
+		db.Card.ID.Equals(cardID),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	cardTxn := s.db.Card.FindUnique(
 		db.Card.ID.Equals(cardID),
-	).Delete().Exec(ctx)
-	return err
+	).Delete().Tx()
+
+	// the card is gone after this transaction, so the activity is only
+	// linked to the board and list and keeps the card id in its metadata
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: current.BoardID,
+		listID:  current.ListID,
+		kind:    types.ActivityCardDeleted,
+		before:  map[string]any{"cardID": current.ID, "title": current.Title},
+	})
+
+	return s.db.Prisma.Transaction(cardTxn, activityTxn).Exec(ctx)
 }
 
 func (s *Store) ToggleCardMembership(ctx context.Context, member *types.ToggleCardMembership) error {
+	card, err := s.db.Card.FindUnique(
+		db.Card.ID.Equals(member.CardID),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
 	cardMember, err := s.db.CardMember.FindFirst(
 		db.CardMember.CardID.Equals(member.CardID),
 		db.CardMember.UserID.Equals(member.UserID),
 	).Exec(ctx)
 	if err != nil {
 		if db.IsErrNotFound(err) {
-			_, err = s.db.CardMember.CreateOne(
+			memberTxn := s.db.CardMember.CreateOne(
 				db.CardMember.Card.Link(
 					db.Card.ID.Equals(member.CardID),
 				),
 				db.CardMember.User.Link(
 					db.User.ID.Equals(member.UserID),
 				),
-			).Exec(ctx)
-			return err
+			).Tx()
+
+			activityTxn := s.activityTx(ctx, activity{
+				boardID: card.BoardID,
+				cardID:  card.ID,
+				listID:  card.ListID,
+				kind:    types.ActivityCardMemberAdded,
+				after:   map[string]any{"userID": member.UserID},
+			})
+
+			actor := actorID(ctx)
+			if actor == member.UserID {
+				return s.db.Prisma.Transaction(memberTxn, activityTxn).Exec(ctx)
+			}
+
+			notificationTxn := s.notificationTx(&types.CreateNotification{
+				UserID:  member.UserID,
+				Type:    types.NotificationCardAssigned,
+				BoardID: card.BoardID,
+				CardID:  card.ID,
+				ActorID: actor,
+			})
+
+			return s.db.Prisma.Transaction(memberTxn, activityTxn, notificationTxn).Exec(ctx)
 		}
 		return err
 	}
-	_, err = s.db.CardMember.FindUnique(
+
+	memberTxn := s.db.CardMember.FindUnique(
 		db.CardMember.ID.Equals(cardMember.ID),
-	).Delete().Exec(ctx)
-	return err
+	).Delete().Tx()
+
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: card.BoardID,
+		cardID:  card.ID,
+		listID:  card.ListID,
+		kind:    types.ActivityCardMemberRemoved,
+		before:  map[string]any{"userID": member.UserID},
+	})
+
+	return s.db.Prisma.Transaction(memberTxn, activityTxn).Exec(ctx)
 }
@@ -8,13 +8,29 @@ import (
 )
 
 func (s *Store) AddChecklistToCard(ctx context.Context, addChecklist *types.AddChecklist) error {
-	_, err := s.db.Checklist.CreateOne(
+	card, err := s.db.Card.FindUnique(
+		db.Card.ID.Equals(addChecklist.CardID),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	checklistTxn := s.db.Checklist.CreateOne(
 		db.Checklist.Name.Set(addChecklist.Name),
 		db.Checklist.Card.Link(
 			db.Card.ID.Equals(addChecklist.CardID),
 		),
-	).Exec(ctx)
-	return err
+	).Tx()
+
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: card.BoardID,
+		cardID:  card.ID,
+		listID:  card.ListID,
+		kind:    types.ActivityChecklistCreated,
+		after:   map[string]any{"name": addChecklist.Name},
+	})
+
+	return s.db.Prisma.Transaction(checklistTxn, activityTxn).Exec(ctx)
 }
 
 func (s *Store) GetChecklist(ctx context.Context, checklistID string) (*types.Checklist, error) {
@@ -52,23 +68,62 @@ func (s *Store) GetChecklist(ctx context.Context, checklistID string) (*types.Ch
 }
 
 func (s *Store) DeleteChecklist(ctx context.Context, checklistID string) error {
-	_, err := s.db.Checklist.FindUnique(
+	checklist, err := s.db.Checklist.FindUnique(
 		db.Checklist.ID.Equals(checklistID),
-	).Delete().Exec(ctx)
-	return err
+	).With(
+		db.Checklist.Card.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	checklistTxn := s.db.Checklist.FindUnique(
+		db.Checklist.ID.Equals(checklistID),
+	).Delete().Tx()
+
+	card := checklist.Card()
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: card.BoardID,
+		cardID:  card.ID,
+		listID:  card.ListID,
+		kind:    types.ActivityChecklistDeleted,
+		before:  map[string]any{"name": checklist.Name},
+	})
+
+	return s.db.Prisma.Transaction(checklistTxn, activityTxn).Exec(ctx)
 }
 
 func (s *Store) AddChecklistItem(ctx context.Context, addItem *types.AddChecklistItem) (*types.ChecklistItem, error) {
-	item, err := s.db.ChecklistItem.CreateOne(
+	checklist, err := s.db.Checklist.FindUnique(
+		db.Checklist.ID.Equals(addItem.ChecklistID),
+	).With(
+		db.Checklist.Card.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	itemTxn := s.db.ChecklistItem.CreateOne(
 		db.ChecklistItem.Text.Set(addItem.Name),
 		db.ChecklistItem.Checklist.Link(
 			db.Checklist.ID.Equals(addItem.ChecklistID),
 		),
-	).Exec(ctx)
-	if err != nil {
+	).Tx()
+
+	card := checklist.Card()
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: card.BoardID,
+		cardID:  card.ID,
+		listID:  card.ListID,
+		kind:    types.ActivityChecklistItemCreated,
+		after:   map[string]any{"checklistID": checklist.ID, "text": addItem.Name},
+	})
+
+	if err := s.db.Prisma.Transaction(itemTxn, activityTxn).Exec(ctx); err != nil {
 		return nil, err
 	}
 
+	item := itemTxn.Result()
 	return &types.ChecklistItem{
 		ID:        item.ID,
 		Name:      item.Text,
@@ -78,18 +133,64 @@ func (s *Store) AddChecklistItem(ctx context.Context, addItem *types.AddChecklis
 }
 
 func (s *Store) DeleteChecklistItem(ctx context.Context, itemID string) error {
-	_, err := s.db.ChecklistItem.FindUnique(
+	item, err := s.getChecklistItemWithCard(ctx, itemID)
+	if err != nil {
+		return err
+	}
+
+	itemTxn := s.db.ChecklistItem.FindUnique(
 		db.ChecklistItem.ID.Equals(itemID),
-	).Delete().Exec(ctx)
-	return err
+	).Delete().Tx()
+
+	card := item.Checklist().Card()
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: card.BoardID,
+		cardID:  card.ID,
+		listID:  card.ListID,
+		kind:    types.ActivityChecklistItemDeleted,
+		before:  map[string]any{"checklistID": item.ChecklistID, "text": item.Text},
+	})
+
+	return s.db.Prisma.Transaction(itemTxn, activityTxn).Exec(ctx)
 }
 
 func (s *Store) UpdateChecklistItem(ctx context.Context, updateItem *types.UpdateChecklistItem) error {
-	_, err := s.db.ChecklistItem.FindUnique(
+	item, err := s.getChecklistItemWithCard(ctx, updateItem.ItemID)
+	if err != nil {
+		return err
+	}
+
+	before := map[string]any{"checklistID": item.ChecklistID}
+	after := map[string]any{"checklistID": item.ChecklistID}
+	trackChange(before, after, "text", item.Text, updateItem.Name)
+	trackChange(before, after, "completed", item.Completed, updateItem.Completed)
+
+	itemTxn := s.db.ChecklistItem.FindUnique(
 		db.ChecklistItem.ID.Equals(updateItem.ItemID),
 	).Update(
 		db.ChecklistItem.Text.SetIfPresent(updateItem.Name),
 		db.ChecklistItem.Completed.SetIfPresent(updateItem.Completed),
+	).Tx()
+
+	card := item.Checklist().Card()
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: card.BoardID,
+		cardID:  card.ID,
+		listID:  card.ListID,
+		kind:    types.ActivityChecklistItemUpdated,
+		before:  before,
+		after:   after,
+	})
+
+	return s.db.Prisma.Transaction(itemTxn, activityTxn).Exec(ctx)
+}
+
+func (s *Store) getChecklistItemWithCard(ctx context.Context, itemID string) (*db.ChecklistItemModel, error) {
+	return s.db.ChecklistItem.FindUnique(
+		db.ChecklistItem.ID.Equals(itemID),
+	).With(
+		db.ChecklistItem.Checklist.Fetch().With(
+			db.Checklist.Card.Fetch(),
+		),
 	).Exec(ctx)
-	return err
 }
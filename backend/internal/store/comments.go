@@ -0,0 +1,168 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/db/db"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func (s *Store) CreateComment(ctx context.Context, comment *types.CreateComment) (*types.Comment, error) {
+	card, err := s.db.Card.FindFirst(
+		db.Card.ID.Equals(comment.CardID),
+		db.Card.BoardID.Equals(comment.BoardID),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	commentID := uuid.New().String()
+	commentTxn := s.db.Comment.CreateOne(
+		db.Comment.Content.Set(comment.Content),
+		db.Comment.Card.Link(
+			db.Card.ID.Equals(comment.CardID),
+		),
+		db.Comment.User.Link(
+			db.User.ID.Equals(comment.UserID),
+		),
+		db.Comment.ID.Set(commentID),
+	).Tx()
+
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: card.BoardID,
+		cardID:  card.ID,
+		listID:  card.ListID,
+		kind:    types.ActivityCommentAdded,
+		after:   map[string]any{"commentID": commentID, "content": comment.Content},
+	})
+
+	if err := s.db.Prisma.Transaction(commentTxn, activityTxn).Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetComment(ctx, commentID)
+}
+
+func (s *Store) ListCardComments(ctx context.Context, cardID string, paginate *types.Paginate) ([]*types.Comment, error) {
+	comments, err := s.db.Comment.FindMany(
+		db.Comment.CardID.Equals(cardID),
+	).With(
+		db.Comment.User.Fetch(),
+	).OrderBy(
+		db.Comment.CreatedAt.Order(db.SortOrder(paginate.SortOrder)),
+	).Skip(paginate.Offset).Take(paginate.Size).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.Comment, 0, len(comments))
+	for _, comment := range comments {
+		res = append(res, toComment(&comment))
+	}
+	return res, nil
+}
+
+func (s *Store) GetComment(ctx context.Context, commentID string) (*types.Comment, error) {
+	comment, err := s.db.Comment.FindUnique(
+		db.Comment.ID.Equals(commentID),
+	).With(
+		db.Comment.User.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return toComment(comment), nil
+}
+
+func (s *Store) UpdateComment(ctx context.Context, comment *types.UpdateComment) (*types.Comment, error) {
+	current, err := s.db.Comment.FindUnique(
+		db.Comment.ID.Equals(comment.CommentID),
+	).With(
+		db.Comment.Card.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	commentTxn := s.db.Comment.FindUnique(
+		db.Comment.ID.Equals(comment.CommentID),
+	).Update(
+		db.Comment.Content.Set(comment.Content),
+		db.Comment.Edited.Set(true),
+		db.Comment.EditedAt.Set(time.Now()),
+	).Tx()
+
+	card := current.Card()
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: card.BoardID,
+		cardID:  card.ID,
+		listID:  card.ListID,
+		kind:    types.ActivityCommentUpdated,
+		before:  map[string]any{"commentID": current.ID, "content": current.Content},
+		after:   map[string]any{"commentID": current.ID, "content": comment.Content},
+	})
+
+	if err := s.db.Prisma.Transaction(commentTxn, activityTxn).Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetComment(ctx, comment.CommentID)
+}
+
+func (s *Store) DeleteComment(ctx context.Context, commentID string) error {
+	current, err := s.db.Comment.FindUnique(
+		db.Comment.ID.Equals(commentID),
+	).With(
+		db.Comment.Card.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	commentTxn := s.db.Comment.FindUnique(
+		db.Comment.ID.Equals(commentID),
+	).Delete().Tx()
+
+	card := current.Card()
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: card.BoardID,
+		cardID:  card.ID,
+		listID:  card.ListID,
+		kind:    types.ActivityCommentDeleted,
+		before:  map[string]any{"commentID": current.ID, "content": current.Content},
+	})
+
+	return s.db.Prisma.Transaction(commentTxn, activityTxn).Exec(ctx)
+}
+
+func toComment(comment *db.CommentModel) *types.Comment {
+	editedAt, ok := comment.EditedAt()
+	if !ok {
+		editedAt = time.Time{}
+	}
+
+	username, ok := comment.User().Username()
+	if !ok {
+		username = ""
+	}
+
+	return &types.Comment{
+		ID:        comment.ID,
+		CardID:    comment.CardID,
+		UserID:    comment.UserID,
+		Username:  username,
+		Content:   comment.Content,
+		Edited:    comment.Edited,
+		EditedAt:  editedAt,
+		CreatedAt: comment.CreatedAt,
+	}
+}
@@ -0,0 +1,207 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/db/db"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+var (
+	// ErrDependencyCycle is returned when a new dependency would make a card
+	// transitively depend on itself.
+	ErrDependencyCycle = errors.New("store: dependency would create a cycle")
+
+	// ErrCardBlocked is returned when completing a card that depends on
+	// cards which are still open.
+	ErrCardBlocked = errors.New("store: card is blocked by incomplete cards")
+)
+
+func (s *Store) AddCardDependency(ctx context.Context, dep *types.AddCardDependency) error {
+	cards, err := s.db.Card.FindMany(
+		db.Card.ID.In([]string{dep.CardID, dep.DependsOnID}),
+		db.Card.BoardID.Equals(dep.BoardID),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if len(cards) != 2 {
+		return ErrNotFound
+	}
+
+	var card db.CardModel
+	for _, c := range cards {
+		if c.ID == dep.CardID {
+			card = c
+		}
+	}
+
+	edges, err := s.boardDependencies(ctx, dep.BoardID)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range edges[dep.CardID] {
+		if id == dep.DependsOnID {
+			return nil
+		}
+	}
+
+	if reachable(edges, dep.DependsOnID, dep.CardID) {
+		return ErrDependencyCycle
+	}
+
+	// The graph above was read outside the transaction, so a concurrent add
+	// could close a cycle in between. The insert takes a per-board lock and
+	// repeats the duplicate and cycle checks in SQL; the activity row is only
+	// written when the dependency was.
+	metadata, err := json.Marshal(&types.ActivityMetadata{After: map[string]any{"dependsOnID": dep.DependsOnID}})
+	if err != nil {
+		return err
+	}
+
+	dependencyID := uuid.New().String()
+	lockTxn := s.db.Prisma.ExecuteRaw(
+		`SELECT pg_advisory_xact_lock(hashtext('card_dependencies:' || $1))`,
+		dep.BoardID,
+	).Tx()
+	dependencyTxn := s.db.Prisma.ExecuteRaw(
+		`INSERT INTO card_dependencies (id, "cardId", "dependsOnId")
+		SELECT $1, $2, $3
+		WHERE NOT EXISTS (
+			SELECT 1 FROM card_dependencies WHERE "cardId" = $2 AND "dependsOnId" = $3
+		) AND NOT EXISTS (
+			WITH RECURSIVE reach(id) AS (
+				SELECT $3::text
+				UNION
+				SELECT d."dependsOnId" FROM card_dependencies d JOIN reach r ON d."cardId" = r.id
+			)
+			SELECT 1 FROM reach WHERE id = $2
+		)`,
+		dependencyID, dep.CardID, dep.DependsOnID,
+	).Tx()
+	activityTxn := s.db.Prisma.ExecuteRaw(
+		`INSERT INTO activities (id, "boardId", "cardId", "listId", "userId", type, metadata)
+		SELECT $1, $2, $3, $4, $5, $6, $7
+		WHERE EXISTS (SELECT 1 FROM card_dependencies WHERE id = $8)`,
+		uuid.New().String(), card.BoardID, card.ID, card.ListID, actorID(ctx),
+		types.ActivityCardDependencyAdded, string(metadata), dependencyID,
+	).Tx()
+
+	if err := s.db.Prisma.Transaction(lockTxn, dependencyTxn, activityTxn).Exec(ctx); err != nil {
+		return err
+	}
+	if dependencyTxn.Result().Count > 0 {
+		return nil
+	}
+
+	// nothing was inserted: either the same dependency was added
+	// concurrently, which is fine, or it would now close a cycle
+	_, err = s.db.CardDependency.FindFirst(
+		db.CardDependency.CardID.Equals(dep.CardID),
+		db.CardDependency.DependsOnID.Equals(dep.DependsOnID),
+	).Exec(ctx)
+	if err == nil {
+		return nil
+	}
+	if !db.IsErrNotFound(err) {
+		return err
+	}
+	return ErrDependencyCycle
+}
+
+func (s *Store) RemoveCardDependency(ctx context.Context, dep *types.RemoveCardDependency) error {
+	card, err := s.db.Card.FindFirst(
+		db.Card.ID.Equals(dep.CardID),
+		db.Card.BoardID.Equals(dep.BoardID),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	// check first so a missing dependency does not leave a removal in the
+	// activity log
+	_, err = s.db.CardDependency.FindFirst(
+		db.CardDependency.CardID.Equals(dep.CardID),
+		db.CardDependency.DependsOnID.Equals(dep.DependsOnID),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	dependencyTxn := s.db.CardDependency.FindMany(
+		db.CardDependency.CardID.Equals(dep.CardID),
+		db.CardDependency.DependsOnID.Equals(dep.DependsOnID),
+	).Delete().Tx()
+
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: card.BoardID,
+		cardID:  card.ID,
+		listID:  card.ListID,
+		kind:    types.ActivityCardDependencyRemoved,
+		before:  map[string]any{"dependsOnID": dep.DependsOnID},
+	})
+
+	return s.db.Prisma.Transaction(dependencyTxn, activityTxn).Exec(ctx)
+}
+
+// boardDependencies returns the board's dependency graph as a map from each
+// card to the cards it depends on.
+func (s *Store) boardDependencies(ctx context.Context, boardID string) (map[string][]string, error) {
+	deps, err := s.db.CardDependency.FindMany(
+		db.CardDependency.Card.Where(
+			db.Card.BoardID.Equals(boardID),
+		),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make(map[string][]string, len(deps))
+	for _, dep := range deps {
+		edges[dep.CardID] = append(edges[dep.CardID], dep.DependsOnID)
+	}
+	return edges, nil
+}
+
+// reachable reports whether to can be reached from from by following edges.
+func reachable(edges map[string][]string, from, to string) bool {
+	visited := map[string]bool{from: true}
+	queue := []string{from}
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+
+		if id == to {
+			return true
+		}
+
+		for _, next := range edges[id] {
+			if !visited[next] {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return false
+}
+
+func toDependencyCard(card *db.CardModel) *types.DependencyCard {
+	return &types.DependencyCard{
+		ID:        card.ID,
+		ListID:    card.ListID,
+		Title:     card.Title,
+		Completed: card.Completed,
+	}
+}
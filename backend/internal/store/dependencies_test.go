@@ -0,0 +1,31 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReachable(t *testing.T) {
+	// a depends on b, b on c; d is on its own
+	edges := map[string][]string{
+		"a": {"b"},
+		"b": {"c"},
+		"d": {"c"},
+	}
+
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{"a", "c", true},
+		{"a", "a", true},
+		{"c", "a", false},
+		{"d", "a", false},
+		{"b", "d", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, reachable(edges, tt.from, tt.to), "%s -> %s", tt.from, tt.to)
+	}
+}
@@ -0,0 +1,539 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/steebchen/prisma-client-go/runtime/transaction"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/db/db"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+// ErrInvalidImport is returned when a board export cannot be imported; the
+// wrapping error says why.
+var ErrInvalidImport = errors.New("store: invalid board export")
+
+// GetBoardExport returns everything in the board's export except its
+// cards, which are read in batches with ListBoardExportCards.
+func (s *Store) GetBoardExport(ctx context.Context, boardID string) (*types.BoardExport, error) {
+	board, err := s.db.Board.FindUnique(
+		db.Board.ID.Equals(boardID),
+	).With(
+		db.Board.Labels.Fetch().OrderBy(
+			db.Label.CreatedAt.Order(db.SortOrderAsc),
+		),
+		db.Board.BoardMembers.Fetch().With(
+			db.BoardMember.User.Fetch(),
+		),
+		db.Board.Lists.Fetch().OrderBy(
+			db.List.Position.Order(db.SortOrderAsc),
+		),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	description, _ := board.Description()
+	background, _ := board.Background()
+
+	export := &types.BoardExport{
+		Version:    types.BoardExportVersion,
+		ExportedAt: time.Now(),
+		Board: &types.ExportBoard{
+			ID:          board.ID,
+			Name:        board.Name,
+			Description: description,
+			Background:  background,
+			Visibility:  board.Visibility,
+		},
+		Labels:  make([]*types.ExportLabel, 0, len(board.Labels())),
+		Members: make([]*types.ExportMember, 0, len(board.BoardMembers())),
+		Lists:   make([]*types.ExportList, 0, len(board.Lists())),
+	}
+
+	for _, label := range board.Labels() {
+		export.Labels = append(export.Labels, &types.ExportLabel{
+			ID:    label.ID,
+			Name:  label.Name,
+			Color: label.Color,
+		})
+	}
+
+	for _, member := range board.BoardMembers() {
+		username, _ := member.User().Username()
+		export.Members = append(export.Members, &types.ExportMember{
+			UserID:   member.UserID,
+			Username: username,
+			Email:    member.User().Email,
+			Role:     types.NormalizeRole(member.Role),
+		})
+	}
+
+	for _, list := range board.Lists() {
+		color, _ := list.Color()
+		export.Lists = append(export.Lists, &types.ExportList{
+			ID:       list.ID,
+			Name:     list.Name,
+			Position: list.Position,
+			Color:    color,
+			Archived: list.Archived,
+		})
+	}
+
+	return export, nil
+}
+
+// ListBoardExportCards returns up to limit of the board's cards, archived
+// ones included, ordered by ID and starting after the card with ID after.
+// Pass the last ID of a batch to read the next one.
+func (s *Store) ListBoardExportCards(ctx context.Context, boardID, after string, limit int) ([]*types.ExportCard, error) {
+	params := []db.CardWhereParam{
+		db.Card.BoardID.Equals(boardID),
+	}
+	if after != "" {
+		params = append(params, db.Card.ID.Gt(after))
+	}
+
+	cards, err := s.db.Card.FindMany(
+		params...,
+	).OrderBy(
+		db.Card.ID.Order(db.SortOrderAsc),
+	).Take(limit).With(
+		db.Card.CardLabels.Fetch(),
+		db.Card.CardMembers.Fetch(),
+		db.Card.Dependencies.Fetch(),
+		db.Card.CardVotes.Fetch(),
+		db.Card.Checklists.Fetch().OrderBy(
+			db.Checklist.Position.Order(db.SortOrderAsc),
+		).With(
+			db.Checklist.Items.Fetch().OrderBy(
+				db.ChecklistItem.Position.Order(db.SortOrderAsc),
+			),
+		),
+		db.Card.Comments.Fetch().OrderBy(
+			db.Comment.CreatedAt.Order(db.SortOrderAsc),
+		).With(
+			db.Comment.User.Fetch(),
+		),
+		db.Card.Attachments.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.ExportCard, 0, len(cards))
+	for _, card := range cards {
+		description, _ := card.Description()
+		cover, _ := card.Cover()
+		coverSize, _ := card.CoverSize()
+
+		c := &types.ExportCard{
+			ID:          card.ID,
+			ListID:      card.ListID,
+			Title:       card.Title,
+			Description: description,
+			Position:    card.Position,
+			Cover:       cover,
+			CoverSize:   coverSize,
+			Archived:    card.Archived,
+			Completed:   card.Completed,
+			CreatedAt:   card.CreatedAt,
+			LabelIDs:    []string{},
+			MemberIDs:   []string{},
+			BlockedBy:   []string{},
+			VoteCount:   len(card.CardVotes()),
+			Checklists:  []*types.ExportChecklist{},
+			Comments:    []*types.ExportComment{},
+			Attachments: []*types.ExportAttachment{},
+		}
+		if dueDate, ok := card.DueDate(); ok {
+			c.DueDate = &dueDate
+		}
+		if startDate, ok := card.StartDate(); ok {
+			c.StartDate = &startDate
+		}
+
+		for _, label := range card.CardLabels() {
+			c.LabelIDs = append(c.LabelIDs, label.LabelID)
+		}
+		for _, member := range card.CardMembers() {
+			c.MemberIDs = append(c.MemberIDs, member.UserID)
+		}
+		for _, dependency := range card.Dependencies() {
+			c.BlockedBy = append(c.BlockedBy, dependency.DependsOnID)
+		}
+
+		for _, checklist := range card.Checklists() {
+			cl := &types.ExportChecklist{
+				ID:       checklist.ID,
+				Name:     checklist.Name,
+				Position: checklist.Position,
+				Items:    []*types.ExportChecklistItem{},
+			}
+			for _, item := range checklist.Items() {
+				i := &types.ExportChecklistItem{
+					ID:        item.ID,
+					Text:      item.Text,
+					Completed: item.Completed,
+					Position:  item.Position,
+				}
+				if dueDate, ok := item.DueDate(); ok {
+					i.DueDate = &dueDate
+				}
+				cl.Items = append(cl.Items, i)
+			}
+			c.Checklists = append(c.Checklists, cl)
+		}
+
+		for _, comment := range card.Comments() {
+			username, _ := comment.User().Username()
+			c.Comments = append(c.Comments, &types.ExportComment{
+				ID:        comment.ID,
+				UserID:    comment.UserID,
+				Username:  username,
+				Content:   comment.Content,
+				CreatedAt: comment.CreatedAt,
+			})
+		}
+
+		for _, attachment := range card.Attachments() {
+			fileType, _ := attachment.FileType()
+			fileSize, _ := attachment.FileSize()
+			c.Attachments = append(c.Attachments, &types.ExportAttachment{
+				ID:         attachment.ID,
+				FileName:   attachment.FileName,
+				FileType:   fileType,
+				FileSize:   fileSize,
+				UploadedBy: attachment.UploadedBy,
+				CreatedAt:  attachment.CreatedAt,
+			})
+		}
+
+		res = append(res, c)
+	}
+
+	return res, nil
+}
+
+// ImportBoard recreates an exported board owned by the importer, giving
+// every record a new ID. Members are carried over as in.Members says, and
+// in.Invitations are created pending. Everything is written in one
+// transaction, so a failure leaves no partial board behind. Attachments are
+// skipped because their files are not part of the export.
+func (s *Store) ImportBoard(ctx context.Context, in *types.ImportBoard) (*types.BoardImportResult, error) {
+	export := in.Export
+	labelIDs, listIDs, cardIDs, err := importIDs(export)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &types.BoardImportResult{
+		BoardID: uuid.New().String(),
+		Lists:   len(export.Lists),
+		Cards:   len(export.Cards),
+	}
+
+	invited := make(map[string]bool, len(in.Invitations))
+	for _, invitation := range in.Invitations {
+		invited[strings.ToLower(invitation.Email)] = true
+	}
+	for _, member := range export.Members {
+		if _, ok := in.Members[member.UserID]; !ok && (member.Email == "" || !invited[strings.ToLower(member.Email)]) {
+			result.SkippedMembers++
+		}
+	}
+
+	// team boards belong to a workspace, which is not part of the export
+	visibility := export.Board.Visibility
+	if visibility == "" || visibility == types.BoardVisibilityTeam {
+		visibility = types.BoardVisibilityPrivate
+	}
+
+	boardID := result.BoardID
+	txns := []transaction.Param{
+		s.db.Board.CreateOne(
+			db.Board.Name.Set(export.Board.Name),
+			db.Board.Owner.Link(
+				db.User.ID.Equals(in.OwnerID),
+			),
+			db.Board.ID.Set(boardID),
+			db.Board.Visibility.Set(visibility),
+			db.Board.Description.SetIfPresent(optional(export.Board.Description)),
+			db.Board.Background.SetIfPresent(optional(export.Board.Background)),
+		).Tx(),
+		s.db.BoardMember.CreateOne(
+			db.BoardMember.Board.Link(
+				db.Board.ID.Equals(boardID),
+			),
+			db.BoardMember.User.Link(
+				db.User.ID.Equals(in.OwnerID),
+			),
+			db.BoardMember.Role.Set(types.RoleAdmin),
+			db.BoardMember.JoinedAt.Set(time.Now()),
+		).Tx(),
+	}
+
+	joined := map[string]bool{in.OwnerID: true}
+	for _, member := range export.Members {
+		userID, ok := in.Members[member.UserID]
+		if !ok || joined[userID] {
+			continue
+		}
+		joined[userID] = true
+		result.Members++
+
+		role := types.NormalizeRole(member.Role)
+		if !types.RoleCan(role, types.PermissionView) {
+			role = types.RoleNormal
+		}
+		txns = append(txns, s.db.BoardMember.CreateOne(
+			db.BoardMember.Board.Link(
+				db.Board.ID.Equals(boardID),
+			),
+			db.BoardMember.User.Link(
+				db.User.ID.Equals(userID),
+			),
+			db.BoardMember.Role.Set(role),
+			db.BoardMember.JoinedAt.Set(time.Now()),
+		).Tx())
+	}
+
+	for _, invitation := range in.Invitations {
+		invitation.BoardID = boardID
+		invitation.InvitedBy = in.OwnerID
+		invitation.Status = types.InvitationPending
+		txns = append(txns,
+			s.db.BoardInvitation.CreateOne(
+				db.BoardInvitation.Email.Set(invitation.Email),
+				db.BoardInvitation.Token.Set(invitation.Token),
+				db.BoardInvitation.Role.Set(invitation.Role),
+				db.BoardInvitation.ExpiresAt.Set(invitation.ExpiredAt),
+				db.BoardInvitation.Board.Link(
+					db.Board.ID.Equals(boardID),
+				),
+				db.BoardInvitation.InvitedByUser.Link(
+					db.User.ID.Equals(in.OwnerID),
+				),
+			).Tx(),
+			s.activityTx(ctx, activity{
+				boardID: boardID,
+				kind:    types.ActivityMemberInvited,
+				after:   map[string]any{"email": invitation.Email, "role": invitation.Role, "invitedBy": in.OwnerID},
+				actorID: in.OwnerID,
+			}),
+		)
+	}
+	result.Invited = len(in.Invitations)
+
+	for _, label := range export.Labels {
+		txns = append(txns, s.db.Label.CreateOne(
+			db.Label.Name.Set(label.Name),
+			db.Label.Color.Set(label.Color),
+			db.Label.Board.Link(
+				db.Board.ID.Equals(boardID),
+			),
+			db.Label.ID.Set(labelIDs[label.ID]),
+		).Tx())
+	}
+
+	for _, list := range export.Lists {
+		txns = append(txns, s.db.List.CreateOne(
+			db.List.Name.Set(list.Name),
+			db.List.Board.Link(
+				db.Board.ID.Equals(boardID),
+			),
+			db.List.ID.Set(listIDs[list.ID]),
+			db.List.Position.Set(list.Position),
+			db.List.Color.SetIfPresent(optional(list.Color)),
+			db.List.Archived.Set(list.Archived),
+		).Tx())
+	}
+
+	var dependencyTxns []transaction.Param
+	for _, card := range export.Cards {
+		cardID := cardIDs[card.ID]
+		result.SkippedAttachments += len(card.Attachments)
+
+		txns = append(txns, s.db.Card.CreateOne(
+			db.Card.Title.Set(card.Title),
+			db.Card.List.Link(
+				db.List.ID.Equals(listIDs[card.ListID]),
+			),
+			db.Card.Creator.Link(
+				db.User.ID.Equals(in.OwnerID),
+			),
+			db.Card.Board.Link(
+				db.Board.ID.Equals(boardID),
+			),
+			db.Card.ID.Set(cardID),
+			db.Card.Position.Set(card.Position),
+			db.Card.Description.SetIfPresent(optional(card.Description)),
+			db.Card.DueDate.SetIfPresent(card.DueDate),
+			db.Card.StartDate.SetIfPresent(card.StartDate),
+			db.Card.Cover.SetIfPresent(optional(card.Cover)),
+			db.Card.CoverSize.SetIfPresent(optional(card.CoverSize)),
+			db.Card.Archived.Set(card.Archived),
+			db.Card.Completed.Set(card.Completed),
+		).Tx())
+
+		seenLabels := make(map[string]bool, len(card.LabelIDs))
+		for _, id := range card.LabelIDs {
+			labelID, ok := labelIDs[id]
+			if !ok || seenLabels[labelID] {
+				continue
+			}
+			seenLabels[labelID] = true
+			txns = append(txns, s.db.CardLabel.CreateOne(
+				db.CardLabel.Card.Link(
+					db.Card.ID.Equals(cardID),
+				),
+				db.CardLabel.Label.Link(
+					db.Label.ID.Equals(labelID),
+				),
+			).Tx())
+		}
+
+		assigned := make(map[string]bool, len(card.MemberIDs))
+		for _, id := range card.MemberIDs {
+			userID, ok := in.Members[id]
+			if !ok || assigned[userID] {
+				continue
+			}
+			assigned[userID] = true
+			txns = append(txns, s.db.CardMember.CreateOne(
+				db.CardMember.Card.Link(
+					db.Card.ID.Equals(cardID),
+				),
+				db.CardMember.User.Link(
+					db.User.ID.Equals(userID),
+				),
+			).Tx())
+		}
+
+		for _, checklist := range card.Checklists {
+			checklistID := uuid.New().String()
+			txns = append(txns, s.db.Checklist.CreateOne(
+				db.Checklist.Name.Set(checklist.Name),
+				db.Checklist.Card.Link(
+					db.Card.ID.Equals(cardID),
+				),
+				db.Checklist.ID.Set(checklistID),
+				db.Checklist.Position.Set(checklist.Position),
+			).Tx())
+
+			for _, item := range checklist.Items {
+				txns = append(txns, s.db.ChecklistItem.CreateOne(
+					db.ChecklistItem.Text.Set(item.Text),
+					db.ChecklistItem.Checklist.Link(
+						db.Checklist.ID.Equals(checklistID),
+					),
+					db.ChecklistItem.Position.Set(item.Position),
+					db.ChecklistItem.Completed.Set(item.Completed),
+					db.ChecklistItem.DueDate.SetIfPresent(item.DueDate),
+				).Tx())
+			}
+		}
+
+		for _, comment := range card.Comments {
+			content := comment.Content
+			authorID, ok := in.Members[comment.UserID]
+			if !ok {
+				authorID = in.OwnerID
+				content = fmt.Sprintf("_Originally posted by %s_\n\n%s", importedAuthor(comment), content)
+			}
+			txns = append(txns, s.db.Comment.CreateOne(
+				db.Comment.Content.Set(content),
+				db.Comment.Card.Link(
+					db.Card.ID.Equals(cardID),
+				),
+				db.Comment.User.Link(
+					db.User.ID.Equals(authorID),
+				),
+				db.Comment.CreatedAt.Set(comment.CreatedAt),
+			).Tx())
+		}
+
+		seenDependencies := make(map[string]bool, len(card.BlockedBy))
+		for _, id := range card.BlockedBy {
+			dependsOnID, ok := cardIDs[id]
+			if !ok || dependsOnID == cardID || seenDependencies[dependsOnID] {
+				continue
+			}
+			seenDependencies[dependsOnID] = true
+			dependencyTxns = append(dependencyTxns, s.db.CardDependency.CreateOne(
+				db.CardDependency.Card.Link(
+					db.Card.ID.Equals(cardID),
+				),
+				db.CardDependency.DependsOn.Link(
+					db.Card.ID.Equals(dependsOnID),
+				),
+			).Tx())
+		}
+	}
+
+	// dependencies go last, once every card they point at exists
+	txns = append(txns, dependencyTxns...)
+	txns = append(txns, s.activityTx(ctx, activity{
+		boardID: boardID,
+		kind:    types.ActivityBoardCreated,
+		after:   map[string]any{"name": export.Board.Name, "importedFrom": export.Board.ID},
+	}))
+
+	if err := s.db.Prisma.Transaction(txns...).Exec(ctx); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// importIDs gives every label, list and card of export a new ID, keyed by
+// its exported ID. Card IDs are assigned up front so dependencies can point
+// forward. It returns ErrInvalidImport for exports from a newer version,
+// duplicate IDs and cards in a list the export does not contain.
+func importIDs(export *types.BoardExport) (labelIDs, listIDs, cardIDs map[string]string, err error) {
+	if export.Version > types.BoardExportVersion {
+		return nil, nil, nil, fmt.Errorf("%w: version %d is newer than this server supports", ErrInvalidImport, export.Version)
+	}
+
+	labelIDs = make(map[string]string, len(export.Labels))
+	for _, label := range export.Labels {
+		if _, ok := labelIDs[label.ID]; ok {
+			return nil, nil, nil, fmt.Errorf("%w: duplicate label id %q", ErrInvalidImport, label.ID)
+		}
+		labelIDs[label.ID] = uuid.New().String()
+	}
+
+	listIDs = make(map[string]string, len(export.Lists))
+	for _, list := range export.Lists {
+		if _, ok := listIDs[list.ID]; ok {
+			return nil, nil, nil, fmt.Errorf("%w: duplicate list id %q", ErrInvalidImport, list.ID)
+		}
+		listIDs[list.ID] = uuid.New().String()
+	}
+
+	cardIDs = make(map[string]string, len(export.Cards))
+	for _, card := range export.Cards {
+		if _, ok := cardIDs[card.ID]; ok {
+			return nil, nil, nil, fmt.Errorf("%w: duplicate card id %q", ErrInvalidImport, card.ID)
+		}
+		if _, ok := listIDs[card.ListID]; !ok {
+			return nil, nil, nil, fmt.Errorf("%w: card %q is in unknown list %q", ErrInvalidImport, card.ID, card.ListID)
+		}
+		cardIDs[card.ID] = uuid.New().String()
+	}
+
+	return labelIDs, listIDs, cardIDs, nil
+}
+
+func importedAuthor(comment *types.ExportComment) string {
+	if comment.Username != "" {
+		return comment.Username
+	}
+	return "a former member"
+}
@@ -0,0 +1,91 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func TestImportIDs(t *testing.T) {
+	t.Run("remaps every id", func(t *testing.T) {
+		export := &types.BoardExport{
+			Version: types.BoardExportVersion,
+			Labels:  []*types.ExportLabel{{ID: "l1"}, {ID: "l2"}},
+			Lists:   []*types.ExportList{{ID: "list1"}, {ID: "list2"}},
+			Cards:   []*types.ExportCard{{ID: "c1", ListID: "list1"}, {ID: "c2", ListID: "list2"}},
+		}
+
+		labelIDs, listIDs, cardIDs, err := importIDs(export)
+		require.NoError(t, err)
+
+		seen := map[string]bool{}
+		for _, ids := range []map[string]string{labelIDs, listIDs, cardIDs} {
+			assert.Len(t, ids, 2)
+			for old, id := range ids {
+				assert.NotEqual(t, old, id)
+				assert.NoError(t, uuid.Validate(id))
+				assert.False(t, seen[id], "id %s handed out twice", id)
+				seen[id] = true
+			}
+		}
+		assert.Contains(t, cardIDs, "c1")
+		assert.Contains(t, listIDs, "list2")
+	})
+
+	tests := []struct {
+		name    string
+		export  *types.BoardExport
+		message string
+	}{
+		{
+			name:    "newer version",
+			export:  &types.BoardExport{Version: types.BoardExportVersion + 1},
+			message: "newer than this server supports",
+		},
+		{
+			name: "duplicate label",
+			export: &types.BoardExport{
+				Version: types.BoardExportVersion,
+				Labels:  []*types.ExportLabel{{ID: "l1"}, {ID: "l1"}},
+			},
+			message: `duplicate label id "l1"`,
+		},
+		{
+			name: "duplicate list",
+			export: &types.BoardExport{
+				Version: types.BoardExportVersion,
+				Lists:   []*types.ExportList{{ID: "list1"}, {ID: "list1"}},
+			},
+			message: `duplicate list id "list1"`,
+		},
+		{
+			name: "duplicate card",
+			export: &types.BoardExport{
+				Version: types.BoardExportVersion,
+				Lists:   []*types.ExportList{{ID: "list1"}},
+				Cards:   []*types.ExportCard{{ID: "c1", ListID: "list1"}, {ID: "c1", ListID: "list1"}},
+			},
+			message: `duplicate card id "c1"`,
+		},
+		{
+			name: "card in unknown list",
+			export: &types.BoardExport{
+				Version: types.BoardExportVersion,
+				Lists:   []*types.ExportList{{ID: "list1"}},
+				Cards:   []*types.ExportCard{{ID: "c1", ListID: "list9"}},
+			},
+			message: `card "c1" is in unknown list "list9"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, _, err := importIDs(tt.export)
+			assert.ErrorIs(t, err, ErrInvalidImport)
+			assert.ErrorContains(t, err, tt.message)
+		})
+	}
+}
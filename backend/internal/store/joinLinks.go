@@ -0,0 +1,185 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/db/db"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+// ErrJoinLinkExhausted is returned when a join link has been used as many
+// times as it allows.
+var ErrJoinLinkExhausted = errors.New("store: join link has no uses left")
+
+func (s *Store) CreateJoinLink(ctx context.Context, link *types.CreateJoinLink) (*types.JoinLink, error) {
+	params := []db.BoardJoinLinkSetParam{
+		db.BoardJoinLink.Role.Set(link.Role),
+	}
+	if link.MaxUses != nil {
+		params = append(params, db.BoardJoinLink.MaxUses.Set(*link.MaxUses))
+	}
+	if link.ExpiresAt != nil {
+		params = append(params, db.BoardJoinLink.ExpiresAt.Set(*link.ExpiresAt))
+	}
+
+	created, err := s.db.BoardJoinLink.CreateOne(
+		db.BoardJoinLink.Board.Link(
+			db.Board.ID.Equals(link.BoardID),
+		),
+		db.BoardJoinLink.Token.Set(link.Token),
+		db.BoardJoinLink.Creator.Link(
+			db.User.ID.Equals(link.CreatedBy),
+		),
+		params...,
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toJoinLink(created), nil
+}
+
+// ListJoinLinks returns the board's join links, revoked ones included,
+// newest first.
+func (s *Store) ListJoinLinks(ctx context.Context, boardID string) ([]*types.JoinLink, error) {
+	links, err := s.db.BoardJoinLink.FindMany(
+		db.BoardJoinLink.BoardID.Equals(boardID),
+	).OrderBy(
+		db.BoardJoinLink.CreatedAt.Order(db.SortOrderDesc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.JoinLink, 0, len(links))
+	for _, link := range links {
+		res = append(res, toJoinLink(&link))
+	}
+
+	return res, nil
+}
+
+// RevokeJoinLink stops the link from admitting anyone else. It returns
+// ErrNotFound unless the link is live on the board.
+func (s *Store) RevokeJoinLink(ctx context.Context, boardID, linkID string) error {
+	res, err := s.db.BoardJoinLink.FindMany(
+		db.BoardJoinLink.ID.Equals(linkID),
+		db.BoardJoinLink.BoardID.Equals(boardID),
+		db.BoardJoinLink.RevokedAt.IsNull(),
+	).Update(
+		db.BoardJoinLink.RevokedAt.Set(time.Now()),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if res.Count == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// JoinBoardWithLink adds the user to the board with the link's role and
+// returns the link. It returns ErrNotFound for unknown or revoked links and
+// links to another board, ErrTokenExpired, ErrJoinLinkExhausted, or
+// ErrAlreadyMember.
+func (s *Store) JoinBoardWithLink(ctx context.Context, boardID, token, userID string) (*types.JoinLink, error) {
+	found, err := s.db.BoardJoinLink.FindUnique(
+		db.BoardJoinLink.Token.Equals(token),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	link := toJoinLink(found)
+	if link.BoardID != boardID || link.RevokedAt != nil {
+		return nil, ErrNotFound
+	}
+	if link.ExpiresAt != nil && time.Now().After(*link.ExpiresAt) {
+		return nil, ErrTokenExpired
+	}
+
+	if _, err := s.GetBoardMember(ctx, link.BoardID, userID); err == nil {
+		return nil, ErrAlreadyMember
+	} else if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	// claim a use first; the condition keeps concurrent joins from going
+	// past maxUses
+	claim := []db.BoardJoinLinkWhereParam{
+		db.BoardJoinLink.ID.Equals(link.ID),
+		db.BoardJoinLink.RevokedAt.IsNull(),
+	}
+	if link.MaxUses != nil {
+		claim = append(claim, db.BoardJoinLink.Uses.Lt(*link.MaxUses))
+	}
+
+	res, err := s.db.BoardJoinLink.FindMany(
+		claim...,
+	).Update(
+		db.BoardJoinLink.Uses.Increment(1),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if res.Count == 0 {
+		return nil, ErrJoinLinkExhausted
+	}
+
+	memberTxn := s.db.BoardMember.CreateOne(
+		db.BoardMember.Board.Link(
+			db.Board.ID.Equals(link.BoardID),
+		),
+		db.BoardMember.User.Link(
+			db.User.ID.Equals(userID),
+		),
+		db.BoardMember.Role.Set(link.Role),
+		db.BoardMember.JoinedAt.Set(time.Now()),
+	).Tx()
+
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: link.BoardID,
+		kind:    types.ActivityMemberJoined,
+		after:   map[string]any{"userID": userID, "role": link.Role, "joinLinkID": link.ID},
+	})
+
+	if err := s.db.Prisma.Transaction(memberTxn, activityTxn).Exec(ctx); err != nil {
+		// give the use back; the user did not join
+		if _, rerr := s.db.BoardJoinLink.FindUnique(
+			db.BoardJoinLink.ID.Equals(link.ID),
+		).Update(
+			db.BoardJoinLink.Uses.Decrement(1),
+		).Exec(ctx); rerr != nil {
+			return nil, errors.Join(err, rerr)
+		}
+		return nil, err
+	}
+
+	return link, nil
+}
+
+func toJoinLink(link *db.BoardJoinLinkModel) *types.JoinLink {
+	res := &types.JoinLink{
+		ID:        link.ID,
+		BoardID:   link.BoardID,
+		Token:     link.Token,
+		Role:      link.Role,
+		Uses:      link.Uses,
+		CreatedAt: link.CreatedAt,
+	}
+	if maxUses, ok := link.MaxUses(); ok {
+		res.MaxUses = &maxUses
+	}
+	if expiresAt, ok := link.ExpiresAt(); ok {
+		res.ExpiresAt = &expiresAt
+	}
+	if revokedAt, ok := link.RevokedAt(); ok {
+		res.RevokedAt = &revokedAt
+	}
+	return res
+}
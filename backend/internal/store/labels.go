@@ -8,17 +8,25 @@ import (
 )
 
 func (s *Store) CreateLabel(ctx context.Context, label *types.CreateLabel) (*types.ListLabels, error) {
-	newLabel, err := s.db.Label.CreateOne(
+	labelTxn := s.db.Label.CreateOne(
 		db.Label.Name.Set(label.Name),
 		db.Label.Color.Set(label.Color),
 		db.Label.Board.Link(
 			db.Board.ID.Equals(label.BoardID),
 		),
-	).Exec(ctx)
-	if err != nil {
+	).Tx()
+
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: label.BoardID,
+		kind:    types.ActivityLabelCreated,
+		after:   map[string]any{"name": label.Name, "color": label.Color},
+	})
+
+	if err := s.db.Prisma.Transaction(labelTxn, activityTxn).Exec(ctx); err != nil {
 		return nil, err
 	}
 
+	newLabel := labelTxn.Result()
 	return &types.ListLabels{
 		ID:    newLabel.ID,
 		Name:  newLabel.Name,
@@ -27,40 +35,89 @@ func (s *Store) CreateLabel(ctx context.Context, label *types.CreateLabel) (*typ
 }
 
 func (s *Store) UpdateLabel(ctx context.Context, label *types.ModifyLabel) error {
-	_, err := s.db.Label.FindUnique(
+	current, err := s.db.Label.FindUnique(
+		db.Label.ID.Equals(label.ID),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	before, after := map[string]any{}, map[string]any{}
+	trackChange(before, after, "name", current.Name, &label.Name)
+	trackChange(before, after, "color", current.Color, &label.Color)
+
+	labelTxn := s.db.Label.FindUnique(
 		db.Label.ID.Equals(label.ID),
 	).Update(
 		db.Label.Name.Set(label.Name),
 		db.Label.Color.Set(label.Color),
-	).Exec(ctx)
-	return err
+	).Tx()
+
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: current.BoardID,
+		kind:    types.ActivityLabelUpdated,
+		before:  before,
+		after:   after,
+	})
+
+	return s.db.Prisma.Transaction(labelTxn, activityTxn).Exec(ctx)
 }
 
 func (s *Store) DeleteLabel(ctx context.Context, label *types.ModifyLabel) error {
-	_, err := s.db.Label.FindUnique(
+	current, err := s.db.Label.FindUnique(
 		db.Label.ID.Equals(label.ID),
-	).Delete().Exec(ctx)
-	return err
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	labelTxn := s.db.Label.FindUnique(
+		db.Label.ID.Equals(label.ID),
+	).Delete().Tx()
+
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: current.BoardID,
+		kind:    types.ActivityLabelDeleted,
+		before:  map[string]any{"name": current.Name, "color": current.Color},
+	})
+
+	return s.db.Prisma.Transaction(labelTxn, activityTxn).Exec(ctx)
 }
 
 func (s *Store) AddLabelToCard(ctx context.Context, label *types.ToggleLabelToCard) error {
-	_, err := s.db.CardLabel.CreateOne(
+	cardLabelTxn := s.db.CardLabel.CreateOne(
 		db.CardLabel.Card.Link(
 			db.Card.ID.Equals(label.CardID),
 		),
 		db.CardLabel.Label.Link(
 			db.Label.ID.Equals(label.LabelID),
 		),
-	).Exec(ctx)
-	return err
+	).Tx()
+
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: label.BoardID,
+		cardID:  label.CardID,
+		kind:    types.ActivityLabelAddedToCard,
+		after:   map[string]any{"labelID": label.LabelID},
+	})
+
+	return s.db.Prisma.Transaction(cardLabelTxn, activityTxn).Exec(ctx)
 }
 
 func (s *Store) RemoveLabelFromCard(ctx context.Context, label *types.ToggleLabelToCard) error {
-	_, err := s.db.CardLabel.FindMany(
+	cardLabelTxn := s.db.CardLabel.FindMany(
 		db.CardLabel.CardID.Equals(label.CardID),
 		db.CardLabel.LabelID.Equals(label.LabelID),
-	).Delete().Exec(ctx)
-	return err
+	).Delete().Tx()
+
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: label.BoardID,
+		cardID:  label.CardID,
+		kind:    types.ActivityLabelRemovedFromCard,
+		before:  map[string]any{"labelID": label.LabelID},
+	})
+
+	return s.db.Prisma.Transaction(cardLabelTxn, activityTxn).Exec(ctx)
 }
 
 func (s *Store) ListBoardLabels(ctx context.Context, boardID string) ([]*types.ListLabels, error) {
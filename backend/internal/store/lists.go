@@ -3,24 +3,63 @@ package store
 import (
 	"context"
 
+	"github.com/google/uuid"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/db/db"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
 )
 
-func (s *Store) CreateList(ctx context.Context, list *types.CreateList) error {
-	_, err := s.db.List.CreateOne(
+func (s *Store) CreateList(ctx context.Context, list *types.CreateList) (*types.List, error) {
+	listID := uuid.New().String()
+	listTxn := s.db.List.CreateOne(
 		db.List.Name.Set(list.Name),
 		db.List.Board.Link(
 			db.Board.ID.Equals(list.BoardID),
 		),
+		db.List.ID.Set(listID),
 		db.List.Position.SetIfPresent(&list.Position),
 		db.List.Color.SetIfPresent(&list.Color),
-	).Exec(ctx)
-	return err
+	).Tx()
+
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: list.BoardID,
+		listID:  listID,
+		kind:    types.ActivityListCreated,
+		after:   map[string]any{"name": list.Name, "position": list.Position},
+	})
+
+	if err := s.db.Prisma.Transaction(listTxn, activityTxn).Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	created := listTxn.Result()
+	color, _ := created.Color()
+	return &types.List{
+		ID:       created.ID,
+		BoardID:  created.BoardID,
+		Name:     created.Name,
+		Position: created.Position,
+		Color:    color,
+	}, nil
 }
 
 func (s *Store) UpdateList(ctx context.Context, list *types.UpdateList) error {
-	_, err := s.db.List.FindUnique(
+	current, err := s.db.List.FindUnique(
+		db.List.ID.Equals(list.ListID),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	color, _ := current.Color()
+
+	before, after := map[string]any{}, map[string]any{}
+	trackChange(before, after, "name", current.Name, list.Name)
+	trackChange(before, after, "position", current.Position, list.Position)
+	trackChange(before, after, "color", color, list.Color)
+	trackChange(before, after, "archived", current.Archived, list.Archived)
+	trackChange(before, after, "collapsed", current.Collapsed, list.Collapsed)
+
+	listTxn := s.db.List.FindUnique(
 		db.List.ID.Equals(list.ListID),
 	).Update(
 		db.List.Name.SetIfPresent(list.Name),
@@ -28,13 +67,37 @@ func (s *Store) UpdateList(ctx context.Context, list *types.UpdateList) error {
 		db.List.Color.SetIfPresent(list.Color),
 		db.List.Archived.SetIfPresent(list.Archived),
 		db.List.Collapsed.SetIfPresent(list.Collapsed),
-	).Exec(ctx)
-	return err
+	).Tx()
+
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: current.BoardID,
+		listID:  current.ID,
+		kind:    types.ActivityListUpdated,
+		before:  before,
+		after:   after,
+	})
+
+	return s.db.Prisma.Transaction(listTxn, activityTxn).Exec(ctx)
 }
 
 func (s *Store) DeleteList(ctx context.Context, listID string) error {
-	_, err := s.db.List.FindUnique(
+	current, err := s.db.List.FindUnique(
+		db.List.ID.Equals(listID),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	listTxn := s.db.List.FindUnique(
 		db.List.ID.Equals(listID),
-	).Delete().Exec(ctx)
-	return err
+	).Delete().Tx()
+
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: current.BoardID,
+		listID:  current.ID,
+		kind:    types.ActivityListDeleted,
+		before:  map[string]any{"name": current.Name, "position": current.Position},
+	})
+
+	return s.db.Prisma.Transaction(listTxn, activityTxn).Exec(ctx)
 }
@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
@@ -24,9 +25,12 @@ func (m *MockStore) GetUserByEmail(ctx context.Context, email string) (*types.Us
 	return args.Get(0).(*types.User), args.Error(1)
 }
 
-func (m *MockStore) UpdateUserRefreshToken(ctx context.Context, userID string, refreshToken string) error {
-	args := m.Called(ctx, userID, refreshToken)
-	return args.Error(0)
+func (m *MockStore) GetUserByID(ctx context.Context, userID string) (*types.User, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.User), args.Error(1)
 }
 
 func (m *MockStore) CreateOAuthUser(ctx context.Context, user *types.CreateOAuthUser) error {
@@ -52,6 +56,152 @@ func (m *MockStore) UpdateUserPassword(ctx context.Context, userID string, passw
 	return args.Error(0)
 }
 
+func (m *MockStore) VerifyEmail(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockStore) CreateSession(ctx context.Context, session *types.CreateSession) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
+func (m *MockStore) RotateSession(ctx context.Context, rotate *types.RotateSession) (*types.User, error) {
+	args := m.Called(ctx, rotate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.User), args.Error(1)
+}
+
+func (m *MockStore) ListSessions(ctx context.Context, userID string) ([]*types.Session, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*types.Session), args.Error(1)
+}
+
+func (m *MockStore) IsSessionActive(ctx context.Context, userID, sessionID string) (bool, error) {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStore) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockStore) RevokeAllSessions(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockStore) GetSession(ctx context.Context, userID, sessionID string) (*types.Session, error) {
+	args := m.Called(ctx, userID, sessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.Session), args.Error(1)
+}
+
+func (m *MockStore) MarkSessionAuthenticated(ctx context.Context, userID, sessionID string) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockStore) ListLoginMethods(ctx context.Context, userID string) (*types.LoginMethods, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.LoginMethods), args.Error(1)
+}
+
+func (m *MockStore) GetAccountUserID(ctx context.Context, provider, providerAccountID string) (string, error) {
+	args := m.Called(ctx, provider, providerAccountID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockStore) LinkAccount(ctx context.Context, link *types.LinkAccount) error {
+	args := m.Called(ctx, link)
+	return args.Error(0)
+}
+
+func (m *MockStore) UnlinkAccount(ctx context.Context, userID, accountID string) error {
+	args := m.Called(ctx, userID, accountID)
+	return args.Error(0)
+}
+
+func (m *MockStore) GetTwoFactor(ctx context.Context, userID string) (*types.TwoFactor, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.TwoFactor), args.Error(1)
+}
+
+func (m *MockStore) SetPendingTwoFactor(ctx context.Context, userID, secret string) error {
+	args := m.Called(ctx, userID, secret)
+	return args.Error(0)
+}
+
+func (m *MockStore) EnableTwoFactor(ctx context.Context, enable *types.EnableTwoFactor) error {
+	args := m.Called(ctx, enable)
+	return args.Error(0)
+}
+
+func (m *MockStore) ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error {
+	args := m.Called(ctx, userID, codeHashes)
+	return args.Error(0)
+}
+
+func (m *MockStore) ClaimTOTPStep(ctx context.Context, userID string, step int64) (bool, error) {
+	args := m.Called(ctx, userID, step)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStore) UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error) {
+	args := m.Called(ctx, userID, codeHash)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStore) CountUnusedRecoveryCodes(ctx context.Context, userID string) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStore) DisableTwoFactor(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockStore) CreateAPIToken(ctx context.Context, token *types.CreateAPIToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockStore) ListAPITokens(ctx context.Context, userID string) ([]*types.APIToken, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*types.APIToken), args.Error(1)
+}
+
+func (m *MockStore) RevokeAPIToken(ctx context.Context, userID, tokenID string) error {
+	args := m.Called(ctx, userID, tokenID)
+	return args.Error(0)
+}
+
+func (m *MockStore) GetUserByAPIToken(ctx context.Context, tokenHash string) (*types.User, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.User), args.Error(1)
+}
+
 func (m *MockStore) Close() error {
 	args := m.Called()
 	return args.Error(0)
@@ -62,14 +212,43 @@ func (m *MockStore) CreateBoard(ctx context.Context, board *types.CreateBoard) e
 	return args.Error(0)
 }
 
-func (m *MockStore) ListBoards(ctx context.Context, ownerID string, paginate *types.Paginate) ([]*types.Board, error) {
-	args := m.Called(ctx, ownerID, paginate)
-	return nil, args.Error(0)
+func (m *MockStore) ListBoards(ctx context.Context, filter *types.ListBoardsFilter, paginate *types.Paginate) ([]*types.Board, error) {
+	args := m.Called(ctx, filter, paginate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*types.Board), args.Error(1)
+}
+
+func (m *MockStore) SetBoardStarred(ctx context.Context, boardID, userID string, starred bool) error {
+	args := m.Called(ctx, boardID, userID, starred)
+	return args.Error(0)
 }
 
 func (m *MockStore) GetBoardMember(ctx context.Context, boardID, memberID string) (*types.BoardMember, error) {
 	args := m.Called(ctx, boardID, memberID)
-	return nil, args.Error(0)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.BoardMember), args.Error(1)
+}
+
+func (m *MockStore) GetBoardAccess(ctx context.Context, boardID, userID string) (*types.BoardMember, error) {
+	args := m.Called(ctx, boardID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.BoardMember), args.Error(1)
+}
+
+func (m *MockStore) UpdateBoardMemberRole(ctx context.Context, payload *types.UpdateBoardMemberRole) error {
+	args := m.Called(ctx, payload)
+	return args.Error(0)
+}
+
+func (m *MockStore) RemoveBoardMember(ctx context.Context, boardID, userID, kind string) error {
+	args := m.Called(ctx, boardID, userID, kind)
+	return args.Error(0)
 }
 
 func (m *MockStore) CreateBoardInvitation(ctx context.Context, invitation *types.BoardInvitation) error {
@@ -82,11 +261,122 @@ func (m *MockStore) IsABoardMember(ctx context.Context, email, boardID string) (
 	return true, args.Error(0)
 }
 
-func (m *MockStore) AcceptBoardInvitation(ctx context.Context, token, userID, role string) error {
-	args := m.Called(ctx, token, userID, role)
+func (m *MockStore) AcceptBoardInvitation(ctx context.Context, token, userID, email string) (*types.BoardInvitation, error) {
+	args := m.Called(ctx, token, userID, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.BoardInvitation), args.Error(1)
+}
+
+func (m *MockStore) DeclineBoardInvitation(ctx context.Context, token, email string) error {
+	args := m.Called(ctx, token, email)
+	return args.Error(0)
+}
+
+func (m *MockStore) ListBoardInvitations(ctx context.Context, boardID, status string) ([]*types.BoardInvitation, error) {
+	args := m.Called(ctx, boardID, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*types.BoardInvitation), args.Error(1)
+}
+
+func (m *MockStore) ResendBoardInvitation(ctx context.Context, boardID, invitationID, token string, expiresAt time.Time) (*types.BoardInvitation, error) {
+	args := m.Called(ctx, boardID, invitationID, token, expiresAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.BoardInvitation), args.Error(1)
+}
+
+func (m *MockStore) RevokeBoardInvitation(ctx context.Context, boardID, invitationID string) error {
+	args := m.Called(ctx, boardID, invitationID)
+	return args.Error(0)
+}
+
+func (m *MockStore) CreateJoinLink(ctx context.Context, link *types.CreateJoinLink) (*types.JoinLink, error) {
+	args := m.Called(ctx, link)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.JoinLink), args.Error(1)
+}
+
+func (m *MockStore) ListJoinLinks(ctx context.Context, boardID string) ([]*types.JoinLink, error) {
+	args := m.Called(ctx, boardID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*types.JoinLink), args.Error(1)
+}
+
+func (m *MockStore) RevokeJoinLink(ctx context.Context, boardID, linkID string) error {
+	args := m.Called(ctx, boardID, linkID)
+	return args.Error(0)
+}
+
+func (m *MockStore) JoinBoardWithLink(ctx context.Context, boardID, token, userID string) (*types.JoinLink, error) {
+	args := m.Called(ctx, boardID, token, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.JoinLink), args.Error(1)
+}
+
+func (m *MockStore) CreateShareToken(ctx context.Context, share *types.CreateShareToken) (*types.ShareToken, error) {
+	args := m.Called(ctx, share)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.ShareToken), args.Error(1)
+}
+
+func (m *MockStore) ListShareTokens(ctx context.Context, boardID string) ([]*types.ShareToken, error) {
+	args := m.Called(ctx, boardID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*types.ShareToken), args.Error(1)
+}
+
+func (m *MockStore) RevokeShareToken(ctx context.Context, boardID, shareID string) error {
+	args := m.Called(ctx, boardID, shareID)
 	return args.Error(0)
 }
 
+func (m *MockStore) GetSharedBoard(ctx context.Context, boardID, tokenHash string) (*types.Board, error) {
+	args := m.Called(ctx, boardID, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.Board), args.Error(1)
+}
+
+func (m *MockStore) GetBoardExport(ctx context.Context, boardID string) (*types.BoardExport, error) {
+	args := m.Called(ctx, boardID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.BoardExport), args.Error(1)
+}
+
+func (m *MockStore) ListBoardExportCards(ctx context.Context, boardID, after string, limit int) ([]*types.ExportCard, error) {
+	args := m.Called(ctx, boardID, after, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*types.ExportCard), args.Error(1)
+}
+
+func (m *MockStore) ImportBoard(ctx context.Context, in *types.ImportBoard) (*types.BoardImportResult, error) {
+	args := m.Called(ctx, in)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.BoardImportResult), args.Error(1)
+}
+
 func (m *MockStore) GetBoardInvitationByToken(ctx context.Context, token string) (*types.BoardInvitation, error) {
 	args := m.Called(ctx, token)
 	if args.Get(0) == nil {
@@ -105,9 +395,12 @@ func (m *MockStore) DeleteBoard(ctx context.Context, boardID string) error {
 	return args.Error(0)
 }
 
-func (m *MockStore) CreateList(ctx context.Context, list *types.CreateList) error {
+func (m *MockStore) CreateList(ctx context.Context, list *types.CreateList) (*types.List, error) {
 	args := m.Called(ctx, list)
-	return args.Error(0)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.List), args.Error(1)
 }
 
 func (m *MockStore) UpdateList(ctx context.Context, list *types.UpdateList) error {
@@ -120,9 +413,12 @@ func (m *MockStore) DeleteList(ctx context.Context, listID string) error {
 	return args.Error(0)
 }
 
-func (m *MockStore) CreateCard(ctx context.Context, card *types.CreateCard) error {
+func (m *MockStore) CreateCard(ctx context.Context, card *types.CreateCard) (*types.MinimalCard, error) {
 	args := m.Called(ctx, card)
-	return args.Error(0)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.MinimalCard), args.Error(1)
 }
 
 func (m *MockStore) UpdateCard(ctx context.Context, cardID string, card *types.UpdateCard) error {
@@ -156,6 +452,45 @@ func (m *MockStore) ToggleCardMembership(ctx context.Context, member *types.Togg
 	return args.Error(0)
 }
 
+func (m *MockStore) AddCardDependency(ctx context.Context, dep *types.AddCardDependency) error {
+	args := m.Called(ctx, dep)
+	return args.Error(0)
+}
+
+func (m *MockStore) RemoveCardDependency(ctx context.Context, dep *types.RemoveCardDependency) error {
+	args := m.Called(ctx, dep)
+	return args.Error(0)
+}
+
+func (m *MockStore) GetVotingSettings(ctx context.Context, boardID string) (*types.VotingSettings, error) {
+	args := m.Called(ctx, boardID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.VotingSettings), args.Error(1)
+}
+
+func (m *MockStore) UpdateVotingSettings(ctx context.Context, settings *types.UpdateVotingSettings) (*types.VotingSettings, error) {
+	args := m.Called(ctx, settings)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.VotingSettings), args.Error(1)
+}
+
+func (m *MockStore) ToggleCardVote(ctx context.Context, vote *types.ToggleCardVote) (bool, error) {
+	args := m.Called(ctx, vote)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStore) ListCardVoters(ctx context.Context, boardID, cardID string) ([]*types.CardVoter, error) {
+	args := m.Called(ctx, boardID, cardID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*types.CardVoter), args.Error(1)
+}
+
 func (m *MockStore) CreateLabel(ctx context.Context, label *types.CreateLabel) (*types.ListLabels, error) {
 	args := m.Called(ctx, label)
 	if args.Get(0) == nil {
@@ -208,6 +543,40 @@ func (m *MockStore) GetBoard(ctx context.Context, boardID string) (*types.Comple
 	return args.Get(0).(*types.CompleteBoard), args.Error(1)
 }
 
+func (m *MockStore) CreateBoardTemplate(ctx context.Context, template *types.CreateBoardTemplate) (*types.BoardTemplate, error) {
+	args := m.Called(ctx, template)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.BoardTemplate), args.Error(1)
+}
+
+func (m *MockStore) ListBoardTemplates(ctx context.Context, userID string, paginate *types.Paginate) ([]*types.BoardTemplate, error) {
+	args := m.Called(ctx, userID, paginate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*types.BoardTemplate), args.Error(1)
+}
+
+func (m *MockStore) GetBoardTemplate(ctx context.Context, templateID string) (*types.BoardTemplate, error) {
+	args := m.Called(ctx, templateID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.BoardTemplate), args.Error(1)
+}
+
+func (m *MockStore) DeleteBoardTemplate(ctx context.Context, templateID string) error {
+	args := m.Called(ctx, templateID)
+	return args.Error(0)
+}
+
+func (m *MockStore) CreateBoardFromTemplate(ctx context.Context, template *types.BoardTemplate, board *types.CreateBoardFromTemplate) (string, error) {
+	args := m.Called(ctx, template, board)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockStore) AddChecklistToCard(ctx context.Context, addChecklist *types.AddChecklist) error {
 	args := m.Called(ctx, addChecklist)
 	return args.Error(0)
@@ -243,3 +612,330 @@ func (m *MockStore) UpdateChecklistItem(ctx context.Context, updateItem *types.U
 	args := m.Called(ctx, updateItem)
 	return args.Error(0)
 }
+
+func (m *MockStore) GetCardBoardID(ctx context.Context, cardID string) (string, error) {
+	args := m.Called(ctx, cardID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockStore) CreateComment(ctx context.Context, comment *types.CreateComment) (*types.Comment, error) {
+	args := m.Called(ctx, comment)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.Comment), args.Error(1)
+}
+
+func (m *MockStore) ListCardComments(ctx context.Context, cardID string, paginate *types.Paginate) ([]*types.Comment, error) {
+	args := m.Called(ctx, cardID, paginate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*types.Comment), args.Error(1)
+}
+
+func (m *MockStore) GetComment(ctx context.Context, commentID string) (*types.Comment, error) {
+	args := m.Called(ctx, commentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.Comment), args.Error(1)
+}
+
+func (m *MockStore) UpdateComment(ctx context.Context, comment *types.UpdateComment) (*types.Comment, error) {
+	args := m.Called(ctx, comment)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.Comment), args.Error(1)
+}
+
+func (m *MockStore) DeleteComment(ctx context.Context, commentID string) error {
+	args := m.Called(ctx, commentID)
+	return args.Error(0)
+}
+
+func (m *MockStore) ListBoardActivity(ctx context.Context, boardID string, paginate *types.Paginate) ([]*types.Activity, error) {
+	args := m.Called(ctx, boardID, paginate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*types.Activity), args.Error(1)
+}
+
+func (m *MockStore) ListCardActivity(ctx context.Context, cardID string, paginate *types.Paginate) ([]*types.Activity, error) {
+	args := m.Called(ctx, cardID, paginate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*types.Activity), args.Error(1)
+}
+
+func (m *MockStore) CreateAttachment(ctx context.Context, attachment *types.CreateAttachment) (*types.Attachment, error) {
+	args := m.Called(ctx, attachment)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.Attachment), args.Error(1)
+}
+
+func (m *MockStore) ListCardAttachments(ctx context.Context, cardID string) ([]*types.Attachment, error) {
+	args := m.Called(ctx, cardID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*types.Attachment), args.Error(1)
+}
+
+func (m *MockStore) GetAttachment(ctx context.Context, attachmentID string) (*types.Attachment, error) {
+	args := m.Called(ctx, attachmentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.Attachment), args.Error(1)
+}
+
+func (m *MockStore) DeleteAttachment(ctx context.Context, attachmentID string) error {
+	args := m.Called(ctx, attachmentID)
+	return args.Error(0)
+}
+
+func (m *MockStore) CreateNotifications(ctx context.Context, notifications []*types.CreateNotification) error {
+	args := m.Called(ctx, notifications)
+	return args.Error(0)
+}
+
+func (m *MockStore) FindBoardMemberIDsByUsername(ctx context.Context, boardID string, usernames []string) ([]string, error) {
+	args := m.Called(ctx, boardID, usernames)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockStore) ListNotifications(ctx context.Context, userID string, unreadOnly bool, paginate *types.Paginate) ([]*types.Notification, error) {
+	args := m.Called(ctx, userID, unreadOnly, paginate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*types.Notification), args.Error(1)
+}
+
+func (m *MockStore) MarkNotificationRead(ctx context.Context, userID, notificationID string) error {
+	args := m.Called(ctx, userID, notificationID)
+	return args.Error(0)
+}
+
+func (m *MockStore) MarkAllNotificationsRead(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockStore) CountUnreadNotifications(ctx context.Context, userID string) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStore) CreateDueDateNotifications(ctx context.Context, window time.Duration) (int, error) {
+	args := m.Called(ctx, window)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStore) CreateAutomation(ctx context.Context, automation *types.CreateAutomation) (*types.Automation, error) {
+	args := m.Called(ctx, automation)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.Automation), args.Error(1)
+}
+
+func (m *MockStore) ListBoardAutomations(ctx context.Context, boardID string) ([]*types.Automation, error) {
+	args := m.Called(ctx, boardID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*types.Automation), args.Error(1)
+}
+
+func (m *MockStore) ListEnabledAutomations(ctx context.Context, boardID, triggerType string) ([]*types.Automation, error) {
+	args := m.Called(ctx, boardID, triggerType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*types.Automation), args.Error(1)
+}
+
+func (m *MockStore) GetAutomation(ctx context.Context, automationID string) (*types.Automation, error) {
+	args := m.Called(ctx, automationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.Automation), args.Error(1)
+}
+
+func (m *MockStore) UpdateAutomation(ctx context.Context, automation *types.UpdateAutomation) (*types.Automation, error) {
+	args := m.Called(ctx, automation)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.Automation), args.Error(1)
+}
+
+func (m *MockStore) DeleteAutomation(ctx context.Context, automationID string) error {
+	args := m.Called(ctx, automationID)
+	return args.Error(0)
+}
+
+func (m *MockStore) BoardRefsExist(ctx context.Context, boardID string, refs *types.AutomationRefs) (bool, error) {
+	args := m.Called(ctx, boardID, refs)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStore) CreateAutomationRun(ctx context.Context, run *types.CreateAutomationRun) error {
+	args := m.Called(ctx, run)
+	return args.Error(0)
+}
+
+func (m *MockStore) ListAutomationRuns(ctx context.Context, automationID string, paginate *types.Paginate) ([]*types.AutomationRun, error) {
+	args := m.Called(ctx, automationID, paginate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*types.AutomationRun), args.Error(1)
+}
+
+func (m *MockStore) ListOverdueCardIDs(ctx context.Context, boardID, automationID string) ([]string, error) {
+	args := m.Called(ctx, boardID, automationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockStore) CreateView(ctx context.Context, view *types.CreateView) (*types.View, error) {
+	args := m.Called(ctx, view)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.View), args.Error(1)
+}
+
+func (m *MockStore) ListBoardViews(ctx context.Context, boardID string) ([]*types.View, error) {
+	args := m.Called(ctx, boardID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*types.View), args.Error(1)
+}
+
+func (m *MockStore) GetView(ctx context.Context, viewID string) (*types.View, error) {
+	args := m.Called(ctx, viewID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.View), args.Error(1)
+}
+
+func (m *MockStore) UpdateView(ctx context.Context, view *types.UpdateView) (*types.View, error) {
+	args := m.Called(ctx, view)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.View), args.Error(1)
+}
+
+func (m *MockStore) DeleteView(ctx context.Context, viewID string) error {
+	args := m.Called(ctx, viewID)
+	return args.Error(0)
+}
+
+func (m *MockStore) ListViewCards(ctx context.Context, query *types.ViewCardQuery) ([]*types.ViewCard, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*types.ViewCard), args.Error(1)
+}
+
+func (m *MockStore) MoveBoardToWorkspace(ctx context.Context, payload *types.MoveBoard) error {
+	args := m.Called(ctx, payload)
+	return args.Error(0)
+}
+
+func (m *MockStore) CreateWorkspace(ctx context.Context, payload *types.CreateWorkspace) (*types.Workspace, error) {
+	args := m.Called(ctx, payload)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.Workspace), args.Error(1)
+}
+
+func (m *MockStore) ListWorkspaces(ctx context.Context, userID string) ([]*types.Workspace, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*types.Workspace), args.Error(1)
+}
+
+func (m *MockStore) GetWorkspace(ctx context.Context, workspaceID string) (*types.WorkspaceDetail, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.WorkspaceDetail), args.Error(1)
+}
+
+func (m *MockStore) UpdateWorkspace(ctx context.Context, payload *types.UpdateWorkspace) error {
+	args := m.Called(ctx, payload)
+	return args.Error(0)
+}
+
+func (m *MockStore) DeleteWorkspace(ctx context.Context, workspaceID string) error {
+	args := m.Called(ctx, workspaceID)
+	return args.Error(0)
+}
+
+func (m *MockStore) GetWorkspaceMember(ctx context.Context, workspaceID, userID string) (*types.WorkspaceMember, error) {
+	args := m.Called(ctx, workspaceID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.WorkspaceMember), args.Error(1)
+}
+
+func (m *MockStore) UpdateWorkspaceMemberRole(ctx context.Context, payload *types.UpdateWorkspaceMemberRole) error {
+	args := m.Called(ctx, payload)
+	return args.Error(0)
+}
+
+func (m *MockStore) RemoveWorkspaceMember(ctx context.Context, workspaceID, userID string) error {
+	args := m.Called(ctx, workspaceID, userID)
+	return args.Error(0)
+}
+
+func (m *MockStore) CreateWorkspaceInvitation(ctx context.Context, invitation *types.WorkspaceInvitation) error {
+	args := m.Called(ctx, invitation)
+	return args.Error(0)
+}
+
+func (m *MockStore) GetWorkspaceInvitationByToken(ctx context.Context, token string) (*types.WorkspaceInvitation, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.WorkspaceInvitation), args.Error(1)
+}
+
+func (m *MockStore) AcceptWorkspaceInvitation(ctx context.Context, token, userID string) error {
+	args := m.Called(ctx, token, userID)
+	return args.Error(0)
+}
+
+func (m *MockStore) ListWorkspaceBoards(ctx context.Context, workspaceID, userID string, paginate *types.Paginate) ([]*types.Board, error) {
+	args := m.Called(ctx, workspaceID, userID, paginate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*types.Board), args.Error(1)
+}
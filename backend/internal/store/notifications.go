@@ -0,0 +1,235 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/steebchen/prisma-client-go/runtime/transaction"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/db/db"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func (s *Store) notificationTx(n *types.CreateNotification) db.NotificationUniqueTxResult {
+	params := []db.NotificationSetParam{}
+	if n.BoardID != "" {
+		params = append(params, db.Notification.BoardID.Set(n.BoardID))
+	}
+	if n.CardID != "" {
+		params = append(params, db.Notification.CardID.Set(n.CardID))
+	}
+	if n.ActorID != "" {
+		params = append(params, db.Notification.ActorID.Set(n.ActorID))
+	}
+	if n.DueDate != nil {
+		params = append(params, db.Notification.DueDate.Set(*n.DueDate))
+	}
+
+	return s.db.Notification.CreateOne(
+		db.Notification.Type.Set(n.Type),
+		db.Notification.User.Link(
+			db.User.ID.Equals(n.UserID),
+		),
+		params...,
+	).Tx()
+}
+
+func (s *Store) CreateNotifications(ctx context.Context, notifications []*types.CreateNotification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	txns := make([]transaction.Param, 0, len(notifications))
+	for _, n := range notifications {
+		txns = append(txns, s.notificationTx(n))
+	}
+
+	return s.db.Prisma.Transaction(txns...).Exec(ctx)
+}
+
+// FindBoardMemberIDsByUsername resolves usernames to the IDs of users who are
+// members of the board. Unknown usernames and non-members are dropped.
+func (s *Store) FindBoardMemberIDsByUsername(ctx context.Context, boardID string, usernames []string) ([]string, error) {
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+
+	members, err := s.db.BoardMember.FindMany(
+		db.BoardMember.BoardID.Equals(boardID),
+		db.BoardMember.User.Where(
+			db.User.Username.In(usernames),
+		),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var userIDs []string
+	for _, member := range members {
+		userIDs = append(userIDs, member.UserID)
+	}
+	return userIDs, nil
+}
+
+func (s *Store) ListNotifications(ctx context.Context, userID string, unreadOnly bool, paginate *types.Paginate) ([]*types.Notification, error) {
+	filters := []db.NotificationWhereParam{
+		db.Notification.UserID.Equals(userID),
+	}
+	if unreadOnly {
+		filters = append(filters, db.Notification.Read.Equals(false))
+	}
+
+	notifications, err := s.db.Notification.FindMany(
+		filters...,
+	).OrderBy(
+		db.Notification.CreatedAt.Order(db.SortOrder(paginate.SortOrder)),
+	).Skip(paginate.Offset).Take(paginate.Size).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.Notification, 0, len(notifications))
+	for _, n := range notifications {
+		boardID, _ := n.BoardID()
+		cardID, _ := n.CardID()
+		actorID, _ := n.ActorID()
+
+		var dueDate *time.Time
+		if due, ok := n.DueDate(); ok {
+			dueDate = &due
+		}
+
+		res = append(res, &types.Notification{
+			ID:        n.ID,
+			Type:      n.Type,
+			BoardID:   boardID,
+			CardID:    cardID,
+			ActorID:   actorID,
+			DueDate:   dueDate,
+			Read:      n.Read,
+			CreatedAt: n.CreatedAt,
+		})
+	}
+	return res, nil
+}
+
+func (s *Store) MarkNotificationRead(ctx context.Context, userID, notificationID string) error {
+	res, err := s.db.Notification.FindMany(
+		db.Notification.ID.Equals(notificationID),
+		db.Notification.UserID.Equals(userID),
+	).Update(
+		db.Notification.Read.Set(true),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	if res.Count == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *Store) MarkAllNotificationsRead(ctx context.Context, userID string) error {
+	_, err := s.db.Notification.FindMany(
+		db.Notification.UserID.Equals(userID),
+		db.Notification.Read.Equals(false),
+	).Update(
+		db.Notification.Read.Set(true),
+	).Exec(ctx)
+	return err
+}
+
+func (s *Store) CountUnreadNotifications(ctx context.Context, userID string) (int, error) {
+	var res []struct {
+		Count int `json:"count"`
+	}
+
+	err := s.db.Prisma.QueryRaw(
+		`SELECT COUNT(*)::int AS count FROM notifications WHERE "userId" = $1 AND read = false`,
+		userID,
+	).Exec(ctx, &res)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(res) == 0 {
+		return 0, nil
+	}
+	return res[0].Count, nil
+}
+
+// CreateDueDateNotifications notifies the members of every open card that is
+// due within window. Each member is notified once per card and due date, so
+// moving the due date brings a new reminder.
+func (s *Store) CreateDueDateNotifications(ctx context.Context, window time.Duration) (int, error) {
+	now := time.Now()
+	cards, err := s.db.Card.FindMany(
+		db.Card.DueDate.Gte(now),
+		db.Card.DueDate.Lte(now.Add(window)),
+		db.Card.Completed.Equals(false),
+		db.Card.Archived.Equals(false),
+	).With(
+		db.Card.CardMembers.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(cards) == 0 {
+		return 0, nil
+	}
+
+	cardIDs := make([]string, 0, len(cards))
+	for _, card := range cards {
+		cardIDs = append(cardIDs, card.ID)
+	}
+
+	sent, err := s.db.Notification.FindMany(
+		db.Notification.Type.Equals(types.NotificationDueDate),
+		db.Notification.CardID.In(cardIDs),
+	).Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	reminded := make(map[dueDateReminder]bool, len(sent))
+	for _, n := range sent {
+		cardID, _ := n.CardID()
+		dueDate, ok := n.DueDate()
+		if !ok {
+			continue
+		}
+		reminded[dueDateReminder{n.UserID, cardID, dueDate.UnixNano()}] = true
+	}
+
+	var notifications []*types.CreateNotification
+	for _, card := range cards {
+		dueDate, _ := card.DueDate()
+		for _, member := range card.CardMembers() {
+			if reminded[dueDateReminder{member.UserID, card.ID, dueDate.UnixNano()}] {
+				continue
+			}
+
+			notifications = append(notifications, &types.CreateNotification{
+				UserID:  member.UserID,
+				Type:    types.NotificationDueDate,
+				BoardID: card.BoardID,
+				CardID:  card.ID,
+				DueDate: &dueDate,
+			})
+		}
+	}
+
+	if err := s.CreateNotifications(ctx, notifications); err != nil {
+		return 0, err
+	}
+	return len(notifications), nil
+}
+
+// dueDateReminder identifies a due date reminder; the due date is kept in
+// nanoseconds so equal instants compare equal whatever their location.
+type dueDateReminder struct {
+	userID  string
+	cardID  string
+	dueDate int64
+}
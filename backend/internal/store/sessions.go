@@ -0,0 +1,251 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/db/db"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+var (
+	// ErrSessionRevoked is returned when refreshing a session that was
+	// revoked or has expired.
+	ErrSessionRevoked = errors.New("store: session revoked or expired")
+
+	// ErrRefreshTokenReused is returned when a refresh token that was already
+	// rotated is presented again. The session is revoked before returning,
+	// since either the client or an attacker holds a stolen token.
+	ErrRefreshTokenReused = errors.New("store: refresh token reused")
+)
+
+func (s *Store) CreateSession(ctx context.Context, session *types.CreateSession) error {
+	session.ID = uuid.New().String()
+
+	sessionTxn := s.db.Session.CreateOne(
+		db.Session.ExpiresAt.Set(session.ExpiresAt),
+		db.Session.User.Link(
+			db.User.ID.Equals(session.UserID),
+		),
+		db.Session.ID.Set(session.ID),
+		db.Session.UserAgent.SetIfPresent(optional(session.UserAgent)),
+		db.Session.IP.SetIfPresent(optional(session.IP)),
+	).Tx()
+
+	tokenTxn := s.db.RefreshToken.CreateOne(
+		db.RefreshToken.TokenHash.Set(session.TokenHash),
+		db.RefreshToken.Session.Link(
+			db.Session.ID.Equals(session.ID),
+		),
+	).Tx()
+
+	return s.db.Prisma.Transaction(sessionTxn, tokenTxn).Exec(ctx)
+}
+
+// RotateSession marks the presented refresh token as used and issues its
+// successor in the same session. It returns the session's user with
+// SessionID set.
+func (s *Store) RotateSession(ctx context.Context, rotate *types.RotateSession) (*types.User, error) {
+	token, err := s.db.RefreshToken.FindUnique(
+		db.RefreshToken.TokenHash.Equals(rotate.TokenHash),
+	).With(
+		db.RefreshToken.Session.Fetch().With(
+			db.Session.User.Fetch(),
+		),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	session := token.Session()
+	if _, revoked := session.RevokedAt(); revoked || time.Now().After(session.ExpiresAt) {
+		return nil, ErrSessionRevoked
+	}
+
+	// claiming the token with a conditional update makes two concurrent
+	// refreshes with the same token count as reuse
+	claimed, err := s.db.RefreshToken.FindMany(
+		db.RefreshToken.TokenHash.Equals(rotate.TokenHash),
+		db.RefreshToken.UsedAt.IsNull(),
+	).Update(
+		db.RefreshToken.UsedAt.Set(time.Now()),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if claimed.Count == 0 {
+		if err := s.revokeSessions(ctx, db.Session.ID.Equals(session.ID)); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	tokenTxn := s.db.RefreshToken.CreateOne(
+		db.RefreshToken.TokenHash.Set(rotate.NextTokenHash),
+		db.RefreshToken.Session.Link(
+			db.Session.ID.Equals(session.ID),
+		),
+	).Tx()
+
+	sessionTxn := s.db.Session.FindUnique(
+		db.Session.ID.Equals(session.ID),
+	).Update(
+		db.Session.ExpiresAt.Set(rotate.ExpiresAt),
+		db.Session.LastUsedAt.Set(time.Now()),
+		db.Session.IP.SetIfPresent(optional(rotate.IP)),
+	).Tx()
+
+	if err := s.db.Prisma.Transaction(tokenTxn, sessionTxn).Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	user := session.User()
+	username, _ := user.Username()
+	_, emailVerified := user.EmailVerified()
+
+	return &types.User{
+		ID:            user.ID,
+		Username:      username,
+		Email:         user.Email,
+		EmailVerified: emailVerified,
+		SessionID:     session.ID,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+	}, nil
+}
+
+// ListSessions returns the user's live sessions, most recently used first.
+func (s *Store) ListSessions(ctx context.Context, userID string) ([]*types.Session, error) {
+	sessions, err := s.db.Session.FindMany(
+		db.Session.UserID.Equals(userID),
+		db.Session.RevokedAt.IsNull(),
+		db.Session.ExpiresAt.Gt(time.Now()),
+	).OrderBy(
+		db.Session.LastUsedAt.Order(db.SortOrderDesc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.Session, 0, len(sessions))
+	for _, session := range sessions {
+		userAgent, _ := session.UserAgent()
+		ip, _ := session.IP()
+
+		res = append(res, &types.Session{
+			ID:              session.ID,
+			UserAgent:       userAgent,
+			IP:              ip,
+			LastUsedAt:      session.LastUsedAt,
+			ExpiresAt:       session.ExpiresAt,
+			CreatedAt:       session.CreatedAt,
+			AuthenticatedAt: session.AuthenticatedAt,
+		})
+	}
+	return res, nil
+}
+
+// GetSession returns one of the user's live sessions.
+func (s *Store) GetSession(ctx context.Context, userID, sessionID string) (*types.Session, error) {
+	session, err := s.db.Session.FindFirst(
+		db.Session.ID.Equals(sessionID),
+		db.Session.UserID.Equals(userID),
+		db.Session.RevokedAt.IsNull(),
+		db.Session.ExpiresAt.Gt(time.Now()),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	userAgent, _ := session.UserAgent()
+	ip, _ := session.IP()
+
+	return &types.Session{
+		ID:              session.ID,
+		UserAgent:       userAgent,
+		IP:              ip,
+		LastUsedAt:      session.LastUsedAt,
+		AuthenticatedAt: session.AuthenticatedAt,
+		ExpiresAt:       session.ExpiresAt,
+		CreatedAt:       session.CreatedAt,
+	}, nil
+}
+
+// MarkSessionAuthenticated records that the user just proved their identity
+// again in this session.
+func (s *Store) MarkSessionAuthenticated(ctx context.Context, userID, sessionID string) error {
+	res, err := s.db.Session.FindMany(
+		db.Session.ID.Equals(sessionID),
+		db.Session.UserID.Equals(userID),
+		db.Session.RevokedAt.IsNull(),
+	).Update(
+		db.Session.AuthenticatedAt.Set(time.Now()),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	if res.Count == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// IsSessionActive reports whether the session belongs to the user and has
+// been neither revoked nor left to expire.
+func (s *Store) IsSessionActive(ctx context.Context, userID, sessionID string) (bool, error) {
+	_, err := s.db.Session.FindFirst(
+		db.Session.ID.Equals(sessionID),
+		db.Session.UserID.Equals(userID),
+		db.Session.RevokedAt.IsNull(),
+		db.Session.ExpiresAt.Gt(time.Now()),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *Store) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	res, err := s.db.Session.FindMany(
+		db.Session.ID.Equals(sessionID),
+		db.Session.UserID.Equals(userID),
+		db.Session.RevokedAt.IsNull(),
+	).Update(
+		db.Session.RevokedAt.Set(time.Now()),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	if res.Count == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *Store) RevokeAllSessions(ctx context.Context, userID string) error {
+	return s.revokeSessions(ctx, db.Session.UserID.Equals(userID))
+}
+
+func (s *Store) revokeSessions(ctx context.Context, where db.SessionWhereParam) error {
+	_, err := s.db.Session.FindMany(
+		where,
+		db.Session.RevokedAt.IsNull(),
+	).Update(
+		db.Session.RevokedAt.Set(time.Now()),
+	).Exec(ctx)
+	return err
+}
@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/db/db"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func (s *Store) CreateShareToken(ctx context.Context, share *types.CreateShareToken) (*types.ShareToken, error) {
+	created, err := s.db.BoardShareToken.CreateOne(
+		db.BoardShareToken.Board.Link(
+			db.Board.ID.Equals(share.BoardID),
+		),
+		db.BoardShareToken.TokenHash.Set(share.TokenHash),
+		db.BoardShareToken.Prefix.Set(share.Prefix),
+		db.BoardShareToken.Creator.Link(
+			db.User.ID.Equals(share.CreatedBy),
+		),
+		db.BoardShareToken.ExpiresAt.SetIfPresent(share.ExpiresAt),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toShareToken(created), nil
+}
+
+// ListShareTokens returns the board's share tokens, revoked ones included,
+// newest first.
+func (s *Store) ListShareTokens(ctx context.Context, boardID string) ([]*types.ShareToken, error) {
+	shares, err := s.db.BoardShareToken.FindMany(
+		db.BoardShareToken.BoardID.Equals(boardID),
+	).OrderBy(
+		db.BoardShareToken.CreatedAt.Order(db.SortOrderDesc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.ShareToken, 0, len(shares))
+	for _, share := range shares {
+		res = append(res, toShareToken(&share))
+	}
+
+	return res, nil
+}
+
+// RevokeShareToken cuts off everyone reading the board through the token.
+// It returns ErrNotFound unless the token is live on the board.
+func (s *Store) RevokeShareToken(ctx context.Context, boardID, shareID string) error {
+	res, err := s.db.BoardShareToken.FindMany(
+		db.BoardShareToken.ID.Equals(shareID),
+		db.BoardShareToken.BoardID.Equals(boardID),
+		db.BoardShareToken.RevokedAt.IsNull(),
+	).Update(
+		db.BoardShareToken.RevokedAt.Set(time.Now()),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if res.Count == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// GetSharedBoard returns the board if it can be read without an account:
+// it is public, or tokenHash is the hash of a live share token for it. Private boards and
+// unknown or revoked tokens both return ErrNotFound, so the response does
+// not tell whether the board exists; expired tokens return ErrTokenExpired.
+func (s *Store) GetSharedBoard(ctx context.Context, boardID, tokenHash string) (*types.Board, error) {
+	board, err := s.db.Board.FindUnique(
+		db.Board.ID.Equals(boardID),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	if board.Visibility == types.BoardVisibilityPublic {
+		return toBoard(board), nil
+	}
+	if tokenHash == "" {
+		return nil, ErrNotFound
+	}
+
+	share, err := s.db.BoardShareToken.FindUnique(
+		db.BoardShareToken.TokenHash.Equals(tokenHash),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	if _, revoked := share.RevokedAt(); revoked || share.BoardID != boardID {
+		return nil, ErrNotFound
+	}
+	if expiresAt, ok := share.ExpiresAt(); ok && time.Now().After(expiresAt) {
+		return nil, ErrTokenExpired
+	}
+
+	return toBoard(board), nil
+}
+
+func toShareToken(share *db.BoardShareTokenModel) *types.ShareToken {
+	res := &types.ShareToken{
+		ID:        share.ID,
+		BoardID:   share.BoardID,
+		Prefix:    share.Prefix,
+		CreatedAt: share.CreatedAt,
+	}
+	if expiresAt, ok := share.ExpiresAt(); ok {
+		res.ExpiresAt = &expiresAt
+	}
+	if revokedAt, ok := share.RevokedAt(); ok {
+		res.RevokedAt = &revokedAt
+	}
+	return res
+}
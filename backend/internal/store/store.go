@@ -2,42 +2,130 @@ package store
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/vaidik-bajpai/Nexus/backend/internal/db/db"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
 )
 
+var (
+	// ErrNotFound is returned when a lookup scoped to the caller matches nothing.
+	ErrNotFound = errors.New("store: record not found")
+
+	// ErrTokenExpired is returned when a single-use token is presented after
+	// its ttl.
+	ErrTokenExpired = errors.New("store: token expired")
+
+	// ErrAccountExists is returned when an external sign-in matches an
+	// existing user's email. The owner has to sign in and link it.
+	ErrAccountExists = errors.New("store: an account with this email already exists")
+)
+
 type Storer interface {
 	CreateCredentialsUser(ctx context.Context, user *types.CreateCredentialsUser) error
 	GetUserByEmail(ctx context.Context, email string) (*types.User, error)
-	UpdateUserRefreshToken(ctx context.Context, userID string, refreshToken string) error
+	GetUserByID(ctx context.Context, userID string) (*types.User, error)
 	CreateOAuthUser(ctx context.Context, user *types.CreateOAuthUser) error
 	CreateToken(ctx context.Context, token *types.Token) error
 	GetUserByToken(ctx context.Context, token string) (*types.TokenUser, error)
 	UpdateUserPassword(ctx context.Context, userID string, password string) error
+	VerifyEmail(ctx context.Context, token string) error
+
+	CreateSession(ctx context.Context, session *types.CreateSession) error
+	RotateSession(ctx context.Context, rotate *types.RotateSession) (*types.User, error)
+	ListSessions(ctx context.Context, userID string) ([]*types.Session, error)
+	IsSessionActive(ctx context.Context, userID, sessionID string) (bool, error)
+	RevokeSession(ctx context.Context, userID, sessionID string) error
+	RevokeAllSessions(ctx context.Context, userID string) error
+	GetSession(ctx context.Context, userID, sessionID string) (*types.Session, error)
+	MarkSessionAuthenticated(ctx context.Context, userID, sessionID string) error
+	ListLoginMethods(ctx context.Context, userID string) (*types.LoginMethods, error)
+	GetAccountUserID(ctx context.Context, provider, providerAccountID string) (string, error)
+	LinkAccount(ctx context.Context, link *types.LinkAccount) error
+	UnlinkAccount(ctx context.Context, userID, accountID string) error
+	GetTwoFactor(ctx context.Context, userID string) (*types.TwoFactor, error)
+	SetPendingTwoFactor(ctx context.Context, userID, secret string) error
+	EnableTwoFactor(ctx context.Context, enable *types.EnableTwoFactor) error
+	ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error
+	ClaimTOTPStep(ctx context.Context, userID string, step int64) (bool, error)
+	UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error)
+	CountUnusedRecoveryCodes(ctx context.Context, userID string) (int, error)
+	DisableTwoFactor(ctx context.Context, userID string) error
+	CreateAPIToken(ctx context.Context, token *types.CreateAPIToken) error
+	ListAPITokens(ctx context.Context, userID string) ([]*types.APIToken, error)
+	RevokeAPIToken(ctx context.Context, userID, tokenID string) error
+	GetUserByAPIToken(ctx context.Context, tokenHash string) (*types.User, error)
 	Close() error
 
 	CreateBoard(ctx context.Context, board *types.CreateBoard) error
-	ListBoards(ctx context.Context, ownerID string, paginate *types.Paginate) ([]*types.Board, error)
+	ListBoards(ctx context.Context, filter *types.ListBoardsFilter, paginate *types.Paginate) ([]*types.Board, error)
+	SetBoardStarred(ctx context.Context, boardID, userID string, starred bool) error
 	GetBoardMember(ctx context.Context, boardID, memberID string) (*types.BoardMember, error)
+	GetBoardAccess(ctx context.Context, boardID, userID string) (*types.BoardMember, error)
+	UpdateBoardMemberRole(ctx context.Context, payload *types.UpdateBoardMemberRole) error
+	RemoveBoardMember(ctx context.Context, boardID, userID, kind string) error
 	CreateBoardInvitation(ctx context.Context, invitation *types.BoardInvitation) error
 	IsABoardMember(ctx context.Context, email, boardID string) (bool, error)
-	AcceptBoardInvitation(ctx context.Context, token, userID, role string) error
+	AcceptBoardInvitation(ctx context.Context, token, userID, email string) (*types.BoardInvitation, error)
+	DeclineBoardInvitation(ctx context.Context, token, email string) error
 	GetBoardInvitationByToken(ctx context.Context, token string) (*types.BoardInvitation, error)
+	ListBoardInvitations(ctx context.Context, boardID, status string) ([]*types.BoardInvitation, error)
+	ResendBoardInvitation(ctx context.Context, boardID, invitationID, token string, expiresAt time.Time) (*types.BoardInvitation, error)
+	RevokeBoardInvitation(ctx context.Context, boardID, invitationID string) error
+	CreateJoinLink(ctx context.Context, link *types.CreateJoinLink) (*types.JoinLink, error)
+	ListJoinLinks(ctx context.Context, boardID string) ([]*types.JoinLink, error)
+	RevokeJoinLink(ctx context.Context, boardID, linkID string) error
+	JoinBoardWithLink(ctx context.Context, boardID, token, userID string) (*types.JoinLink, error)
+	CreateShareToken(ctx context.Context, share *types.CreateShareToken) (*types.ShareToken, error)
+	ListShareTokens(ctx context.Context, boardID string) ([]*types.ShareToken, error)
+	RevokeShareToken(ctx context.Context, boardID, shareID string) error
+	GetSharedBoard(ctx context.Context, boardID, tokenHash string) (*types.Board, error)
+	GetBoardExport(ctx context.Context, boardID string) (*types.BoardExport, error)
+	ListBoardExportCards(ctx context.Context, boardID, after string, limit int) ([]*types.ExportCard, error)
+	ImportBoard(ctx context.Context, in *types.ImportBoard) (*types.BoardImportResult, error)
 	UpdateBoard(ctx context.Context, board *types.UpdateBoard) error
 	DeleteBoard(ctx context.Context, boardID string) error
 	GetCardsAndLists(ctx context.Context, boardID string) (*types.BoardDetail, error)
 	GetBoard(ctx context.Context, boardID string) (*types.CompleteBoard, error)
+	MoveBoardToWorkspace(ctx context.Context, payload *types.MoveBoard) error
+
+	CreateWorkspace(ctx context.Context, payload *types.CreateWorkspace) (*types.Workspace, error)
+	ListWorkspaces(ctx context.Context, userID string) ([]*types.Workspace, error)
+	GetWorkspace(ctx context.Context, workspaceID string) (*types.WorkspaceDetail, error)
+	UpdateWorkspace(ctx context.Context, payload *types.UpdateWorkspace) error
+	DeleteWorkspace(ctx context.Context, workspaceID string) error
+	GetWorkspaceMember(ctx context.Context, workspaceID, userID string) (*types.WorkspaceMember, error)
+	UpdateWorkspaceMemberRole(ctx context.Context, payload *types.UpdateWorkspaceMemberRole) error
+	RemoveWorkspaceMember(ctx context.Context, workspaceID, userID string) error
+	CreateWorkspaceInvitation(ctx context.Context, invitation *types.WorkspaceInvitation) error
+	GetWorkspaceInvitationByToken(ctx context.Context, token string) (*types.WorkspaceInvitation, error)
+	AcceptWorkspaceInvitation(ctx context.Context, token, userID string) error
+	ListWorkspaceBoards(ctx context.Context, workspaceID, userID string, paginate *types.Paginate) ([]*types.Board, error)
+
+	CreateBoardTemplate(ctx context.Context, template *types.CreateBoardTemplate) (*types.BoardTemplate, error)
+	ListBoardTemplates(ctx context.Context, userID string, paginate *types.Paginate) ([]*types.BoardTemplate, error)
+	GetBoardTemplate(ctx context.Context, templateID string) (*types.BoardTemplate, error)
+	DeleteBoardTemplate(ctx context.Context, templateID string) error
+	CreateBoardFromTemplate(ctx context.Context, template *types.BoardTemplate, board *types.CreateBoardFromTemplate) (string, error)
 
-	CreateList(ctx context.Context, list *types.CreateList) error
+	CreateList(ctx context.Context, list *types.CreateList) (*types.List, error)
 	UpdateList(ctx context.Context, payload *types.UpdateList) error
 	DeleteList(ctx context.Context, listID string) error
 
-	CreateCard(ctx context.Context, card *types.CreateCard) error
+	CreateCard(ctx context.Context, card *types.CreateCard) (*types.MinimalCard, error)
 	UpdateCard(ctx context.Context, cardID string, card *types.UpdateCard) error
 	GetCardDetail(ctx context.Context, cardID string) (*types.CompleteCard, error)
+	GetCardBoardID(ctx context.Context, cardID string) (string, error)
 	DeleteCard(ctx context.Context, cardID string) error
 	ToggleCardMembership(ctx context.Context, member *types.ToggleCardMembership) error
+	AddCardDependency(ctx context.Context, dep *types.AddCardDependency) error
+	RemoveCardDependency(ctx context.Context, dep *types.RemoveCardDependency) error
+
+	GetVotingSettings(ctx context.Context, boardID string) (*types.VotingSettings, error)
+	UpdateVotingSettings(ctx context.Context, settings *types.UpdateVotingSettings) (*types.VotingSettings, error)
+	ToggleCardVote(ctx context.Context, vote *types.ToggleCardVote) (bool, error)
+	ListCardVoters(ctx context.Context, boardID, cardID string) ([]*types.CardVoter, error)
 
 	CreateLabel(ctx context.Context, label *types.CreateLabel) (*types.ListLabels, error)
 	UpdateLabel(ctx context.Context, label *types.ModifyLabel) error
@@ -52,6 +140,46 @@ type Storer interface {
 	AddChecklistItem(ctx context.Context, addItem *types.AddChecklistItem) (*types.ChecklistItem, error)
 	DeleteChecklistItem(ctx context.Context, itemID string) error
 	UpdateChecklistItem(ctx context.Context, updateItem *types.UpdateChecklistItem) error
+
+	CreateComment(ctx context.Context, comment *types.CreateComment) (*types.Comment, error)
+	ListCardComments(ctx context.Context, cardID string, paginate *types.Paginate) ([]*types.Comment, error)
+	GetComment(ctx context.Context, commentID string) (*types.Comment, error)
+	UpdateComment(ctx context.Context, comment *types.UpdateComment) (*types.Comment, error)
+	DeleteComment(ctx context.Context, commentID string) error
+
+	ListBoardActivity(ctx context.Context, boardID string, paginate *types.Paginate) ([]*types.Activity, error)
+	ListCardActivity(ctx context.Context, cardID string, paginate *types.Paginate) ([]*types.Activity, error)
+
+	CreateAttachment(ctx context.Context, attachment *types.CreateAttachment) (*types.Attachment, error)
+	ListCardAttachments(ctx context.Context, cardID string) ([]*types.Attachment, error)
+	GetAttachment(ctx context.Context, attachmentID string) (*types.Attachment, error)
+	DeleteAttachment(ctx context.Context, attachmentID string) error
+
+	CreateNotifications(ctx context.Context, notifications []*types.CreateNotification) error
+	FindBoardMemberIDsByUsername(ctx context.Context, boardID string, usernames []string) ([]string, error)
+	ListNotifications(ctx context.Context, userID string, unreadOnly bool, paginate *types.Paginate) ([]*types.Notification, error)
+	MarkNotificationRead(ctx context.Context, userID, notificationID string) error
+	MarkAllNotificationsRead(ctx context.Context, userID string) error
+	CountUnreadNotifications(ctx context.Context, userID string) (int, error)
+	CreateDueDateNotifications(ctx context.Context, window time.Duration) (int, error)
+
+	CreateView(ctx context.Context, view *types.CreateView) (*types.View, error)
+	ListBoardViews(ctx context.Context, boardID string) ([]*types.View, error)
+	GetView(ctx context.Context, viewID string) (*types.View, error)
+	UpdateView(ctx context.Context, view *types.UpdateView) (*types.View, error)
+	DeleteView(ctx context.Context, viewID string) error
+	ListViewCards(ctx context.Context, query *types.ViewCardQuery) ([]*types.ViewCard, error)
+
+	CreateAutomation(ctx context.Context, automation *types.CreateAutomation) (*types.Automation, error)
+	ListBoardAutomations(ctx context.Context, boardID string) ([]*types.Automation, error)
+	ListEnabledAutomations(ctx context.Context, boardID, triggerType string) ([]*types.Automation, error)
+	GetAutomation(ctx context.Context, automationID string) (*types.Automation, error)
+	UpdateAutomation(ctx context.Context, automation *types.UpdateAutomation) (*types.Automation, error)
+	DeleteAutomation(ctx context.Context, automationID string) error
+	BoardRefsExist(ctx context.Context, boardID string, refs *types.AutomationRefs) (bool, error)
+	CreateAutomationRun(ctx context.Context, run *types.CreateAutomationRun) error
+	ListAutomationRuns(ctx context.Context, automationID string, paginate *types.Paginate) ([]*types.AutomationRun, error)
+	ListOverdueCardIDs(ctx context.Context, boardID, automationID string) ([]string, error)
 }
 
 type Store struct {
@@ -0,0 +1,343 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/steebchen/prisma-client-go/runtime/transaction"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/db/db"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func (s *Store) CreateBoardTemplate(ctx context.Context, template *types.CreateBoardTemplate) (*types.BoardTemplate, error) {
+	structure, err := s.boardStructure(ctx, template.BoardID, !template.SkipCards)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(structure)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.db.BoardTemplate.CreateOne(
+		db.BoardTemplate.Name.Set(template.Name),
+		db.BoardTemplate.Structure.Set(string(raw)),
+		db.BoardTemplate.Creator.Link(
+			db.User.ID.Equals(template.CreatedBy),
+		),
+		db.BoardTemplate.Description.Set(template.Description),
+		db.BoardTemplate.Public.Set(template.Public),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toBoardTemplate(created)
+}
+
+// boardStructure snapshots the board from the same data the board page
+// loads, adding the checklist items that GetCardsAndLists leaves out.
+func (s *Store) boardStructure(ctx context.Context, boardID string, withCards bool) (*types.TemplateStructure, error) {
+	board, err := s.GetBoard(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	detail, err := s.GetCardsAndLists(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	structure := &types.TemplateStructure{
+		Version:    types.TemplateStructureVersion,
+		Background: board.Background,
+		Labels:     make([]*types.TemplateLabel, 0, len(board.Labels)),
+		Lists:      make([]*types.TemplateList, 0, len(detail.Lists)),
+	}
+
+	labelIndex := make(map[string]int, len(board.Labels))
+	for i, label := range board.Labels {
+		labelIndex[label.LabelID] = i
+		structure.Labels = append(structure.Labels, &types.TemplateLabel{
+			Name:  label.Name,
+			Color: label.Color,
+		})
+	}
+
+	lists := make(map[string]*types.TemplateList, len(detail.Lists))
+	for _, list := range detail.Lists {
+		l := &types.TemplateList{
+			Name:     list.Name,
+			Position: list.Position,
+			Color:    list.Color,
+		}
+		lists[list.ID] = l
+		structure.Lists = append(structure.Lists, l)
+	}
+
+	if !withCards {
+		return structure, nil
+	}
+
+	var checklistIDs []string
+	for _, card := range detail.Cards {
+		for _, checklist := range card.Checklists {
+			checklistIDs = append(checklistIDs, checklist.ID)
+		}
+	}
+
+	items := make(map[string][]*types.TemplateChecklistItem)
+	if len(checklistIDs) > 0 {
+		dbItems, err := s.db.ChecklistItem.FindMany(
+			db.ChecklistItem.ChecklistID.In(checklistIDs),
+		).OrderBy(
+			db.ChecklistItem.Position.Order(db.SortOrderAsc),
+		).Exec(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range dbItems {
+			items[item.ChecklistID] = append(items[item.ChecklistID], &types.TemplateChecklistItem{
+				Text:     item.Text,
+				Position: item.Position,
+			})
+		}
+	}
+
+	for _, card := range detail.Cards {
+		list, ok := lists[card.ListID]
+		if !ok {
+			continue
+		}
+
+		c := &types.TemplateCard{
+			Title:       card.Title,
+			Description: card.Description,
+			Position:    card.Position,
+		}
+		for _, label := range card.Labels {
+			if i, ok := labelIndex[label.LabelID]; ok {
+				c.Labels = append(c.Labels, i)
+			}
+		}
+		for _, checklist := range card.Checklists {
+			c.Checklists = append(c.Checklists, &types.TemplateChecklist{
+				Name:     checklist.Name,
+				Position: checklist.Position,
+				Items:    items[checklist.ID],
+			})
+		}
+
+		list.Cards = append(list.Cards, c)
+	}
+
+	return structure, nil
+}
+
+// ListBoardTemplates returns the user's own templates along with every
+// public one.
+func (s *Store) ListBoardTemplates(ctx context.Context, userID string, paginate *types.Paginate) ([]*types.BoardTemplate, error) {
+	templates, err := s.db.BoardTemplate.FindMany(
+		db.BoardTemplate.Or(
+			db.BoardTemplate.CreatedBy.Equals(userID),
+			db.BoardTemplate.Public.Equals(true),
+		),
+	).OrderBy(
+		db.BoardTemplate.CreatedAt.Order(db.SortOrder(paginate.SortOrder)),
+	).Skip(paginate.Offset).Take(paginate.Size).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.BoardTemplate, 0, len(templates))
+	for _, template := range templates {
+		t, err := toBoardTemplate(&template)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, t)
+	}
+	return res, nil
+}
+
+func (s *Store) GetBoardTemplate(ctx context.Context, templateID string) (*types.BoardTemplate, error) {
+	template, err := s.db.BoardTemplate.FindUnique(
+		db.BoardTemplate.ID.Equals(templateID),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return toBoardTemplate(template)
+}
+
+func (s *Store) DeleteBoardTemplate(ctx context.Context, templateID string) error {
+	_, err := s.db.BoardTemplate.FindUnique(
+		db.BoardTemplate.ID.Equals(templateID),
+	).Delete().Exec(ctx)
+	return err
+}
+
+// CreateBoardFromTemplate replays the template as a new board owned by the
+// caller. Everything is written in one transaction, so a failure leaves no
+// partial board behind.
+func (s *Store) CreateBoardFromTemplate(ctx context.Context, template *types.BoardTemplate, board *types.CreateBoardFromTemplate) (string, error) {
+	structure := template.Structure
+
+	background := board.Background
+	if background == "" {
+		background = structure.Background
+	}
+	visibility := board.Visibility
+	if visibility == "" {
+		visibility = "private"
+	}
+
+	boardID := uuid.New().String()
+	txns := []transaction.Param{
+		s.db.Board.CreateOne(
+			db.Board.Name.Set(board.Name),
+			db.Board.Owner.Link(
+				db.User.ID.Equals(board.OwnerID),
+			),
+			db.Board.ID.Set(boardID),
+			db.Board.Visibility.Set(visibility),
+			db.Board.Background.SetIfPresent(optional(background)),
+		).Tx(),
+		s.db.BoardMember.CreateOne(
+			db.BoardMember.Board.Link(
+				db.Board.ID.Equals(boardID),
+			),
+			db.BoardMember.User.Link(
+				db.User.ID.Equals(board.OwnerID),
+			),
+			db.BoardMember.Role.Set("admin"),
+		).Tx(),
+	}
+
+	labelIDs := make([]string, len(structure.Labels))
+	for i, label := range structure.Labels {
+		labelIDs[i] = uuid.New().String()
+		txns = append(txns, s.db.Label.CreateOne(
+			db.Label.Name.Set(label.Name),
+			db.Label.Color.Set(label.Color),
+			db.Label.Board.Link(
+				db.Board.ID.Equals(boardID),
+			),
+			db.Label.ID.Set(labelIDs[i]),
+		).Tx())
+	}
+
+	for _, list := range structure.Lists {
+		listID := uuid.New().String()
+		txns = append(txns, s.db.List.CreateOne(
+			db.List.Name.Set(list.Name),
+			db.List.Board.Link(
+				db.Board.ID.Equals(boardID),
+			),
+			db.List.ID.Set(listID),
+			db.List.Position.Set(list.Position),
+			db.List.Color.SetIfPresent(optional(list.Color)),
+		).Tx())
+
+		for _, card := range list.Cards {
+			cardID := uuid.New().String()
+			txns = append(txns, s.db.Card.CreateOne(
+				db.Card.Title.Set(card.Title),
+				db.Card.List.Link(
+					db.List.ID.Equals(listID),
+				),
+				db.Card.Creator.Link(
+					db.User.ID.Equals(board.OwnerID),
+				),
+				db.Card.Board.Link(
+					db.Board.ID.Equals(boardID),
+				),
+				db.Card.ID.Set(cardID),
+				db.Card.Position.Set(card.Position),
+				db.Card.Description.SetIfPresent(optional(card.Description)),
+			).Tx())
+
+			for _, i := range card.Labels {
+				if i < 0 || i >= len(labelIDs) {
+					continue
+				}
+				txns = append(txns, s.db.CardLabel.CreateOne(
+					db.CardLabel.Card.Link(
+						db.Card.ID.Equals(cardID),
+					),
+					db.CardLabel.Label.Link(
+						db.Label.ID.Equals(labelIDs[i]),
+					),
+				).Tx())
+			}
+
+			for _, checklist := range card.Checklists {
+				checklistID := uuid.New().String()
+				txns = append(txns, s.db.Checklist.CreateOne(
+					db.Checklist.Name.Set(checklist.Name),
+					db.Checklist.Card.Link(
+						db.Card.ID.Equals(cardID),
+					),
+					db.Checklist.ID.Set(checklistID),
+					db.Checklist.Position.Set(checklist.Position),
+				).Tx())
+
+				for _, item := range checklist.Items {
+					txns = append(txns, s.db.ChecklistItem.CreateOne(
+						db.ChecklistItem.Text.Set(item.Text),
+						db.ChecklistItem.Checklist.Link(
+							db.Checklist.ID.Equals(checklistID),
+						),
+						db.ChecklistItem.Position.Set(item.Position),
+					).Tx())
+				}
+			}
+		}
+	}
+
+	txns = append(txns, s.activityTx(ctx, activity{
+		boardID: boardID,
+		kind:    types.ActivityBoardCreated,
+		after:   map[string]any{"name": board.Name, "templateID": template.ID},
+	}))
+
+	if err := s.db.Prisma.Transaction(txns...).Exec(ctx); err != nil {
+		return "", err
+	}
+	return boardID, nil
+}
+
+func toBoardTemplate(template *db.BoardTemplateModel) (*types.BoardTemplate, error) {
+	description, _ := template.Description()
+
+	var structure types.TemplateStructure
+	if err := json.Unmarshal([]byte(template.Structure), &structure); err != nil {
+		return nil, err
+	}
+
+	return &types.BoardTemplate{
+		ID:          template.ID,
+		Name:        template.Name,
+		Description: description,
+		Public:      template.Public,
+		CreatedBy:   template.CreatedBy,
+		Structure:   &structure,
+		CreatedAt:   template.CreatedAt,
+	}, nil
+}
+
+// optional returns nil for empty strings so SetIfPresent leaves the column
+// unset instead of storing "".
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
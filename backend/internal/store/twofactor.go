@@ -0,0 +1,158 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/steebchen/prisma-client-go/runtime/transaction"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/db/db"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+// ErrTwoFactorEnabled is returned when enrolling a user who already has
+// two-factor authentication turned on.
+var ErrTwoFactorEnabled = errors.New("store: two-factor authentication already enabled")
+
+func (s *Store) GetTwoFactor(ctx context.Context, userID string) (*types.TwoFactor, error) {
+	user, err := s.db.User.FindUnique(
+		db.User.ID.Equals(userID),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	secret, _ := user.TotpSecret()
+	twoFactor := &types.TwoFactor{Secret: secret}
+
+	if enabledAt, ok := user.TotpEnabledAt(); ok {
+		twoFactor.EnabledAt = &enabledAt
+	}
+	if step, ok := user.TotpLastStep(); ok {
+		lastStep := int64(step)
+		twoFactor.LastStep = &lastStep
+	}
+	return twoFactor, nil
+}
+
+// SetPendingTwoFactor stores a new, not yet confirmed TOTP secret, replacing
+// any earlier unconfirmed enrollment.
+func (s *Store) SetPendingTwoFactor(ctx context.Context, userID, secret string) error {
+	res, err := s.db.User.FindMany(
+		db.User.ID.Equals(userID),
+		db.User.TotpEnabledAt.IsNull(),
+	).Update(
+		db.User.TotpSecret.Set(secret),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	if res.Count == 0 {
+		return ErrTwoFactorEnabled
+	}
+	return nil
+}
+
+// EnableTwoFactor turns on the pending secret and replaces the user's
+// recovery codes. Step is the time step of the confirming code, so it
+// cannot be used again to log in.
+func (s *Store) EnableTwoFactor(ctx context.Context, enable *types.EnableTwoFactor) error {
+	txns := []transaction.Param{
+		s.db.User.FindUnique(
+			db.User.ID.Equals(enable.UserID),
+		).Update(
+			db.User.TotpEnabledAt.Set(time.Now()),
+			db.User.TotpLastStep.Set(int(enable.Step)),
+		).Tx(),
+	}
+
+	return s.db.Prisma.Transaction(append(txns, s.replaceRecoveryCodesTx(enable.UserID, enable.RecoveryCodeHashes)...)...).Exec(ctx)
+}
+
+func (s *Store) ReplaceRecoveryCodes(ctx context.Context, userID string, codeHashes []string) error {
+	return s.db.Prisma.Transaction(s.replaceRecoveryCodesTx(userID, codeHashes)...).Exec(ctx)
+}
+
+func (s *Store) replaceRecoveryCodesTx(userID string, codeHashes []string) []transaction.Param {
+	txns := []transaction.Param{
+		s.db.RecoveryCode.FindMany(
+			db.RecoveryCode.UserID.Equals(userID),
+		).Delete().Tx(),
+	}
+
+	for _, hash := range codeHashes {
+		txns = append(txns, s.db.RecoveryCode.CreateOne(
+			db.RecoveryCode.CodeHash.Set(hash),
+			db.RecoveryCode.User.Link(
+				db.User.ID.Equals(userID),
+			),
+		).Tx())
+	}
+	return txns
+}
+
+// ClaimTOTPStep records step as the user's last accepted TOTP step. It
+// reports false when that step or a later one was already used, which
+// makes a replayed code fail even under concurrent logins.
+func (s *Store) ClaimTOTPStep(ctx context.Context, userID string, step int64) (bool, error) {
+	res, err := s.db.User.FindMany(
+		db.User.ID.Equals(userID),
+		db.User.Or(
+			db.User.TotpLastStep.IsNull(),
+			db.User.TotpLastStep.Lt(int(step)),
+		),
+	).Update(
+		db.User.TotpLastStep.Set(int(step)),
+	).Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	return res.Count > 0, nil
+}
+
+// UseRecoveryCode consumes one of the user's unused recovery codes. It
+// reports false when no unused code matches.
+func (s *Store) UseRecoveryCode(ctx context.Context, userID, codeHash string) (bool, error) {
+	res, err := s.db.RecoveryCode.FindMany(
+		db.RecoveryCode.UserID.Equals(userID),
+		db.RecoveryCode.CodeHash.Equals(codeHash),
+		db.RecoveryCode.UsedAt.IsNull(),
+	).Update(
+		db.RecoveryCode.UsedAt.Set(time.Now()),
+	).Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	return res.Count > 0, nil
+}
+
+func (s *Store) CountUnusedRecoveryCodes(ctx context.Context, userID string) (int, error) {
+	codes, err := s.db.RecoveryCode.FindMany(
+		db.RecoveryCode.UserID.Equals(userID),
+		db.RecoveryCode.UsedAt.IsNull(),
+	).Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return len(codes), nil
+}
+
+func (s *Store) DisableTwoFactor(ctx context.Context, userID string) error {
+	userTxn := s.db.User.FindUnique(
+		db.User.ID.Equals(userID),
+	).Update(
+		db.User.TotpSecret.SetOptional(nil),
+		db.User.TotpEnabledAt.SetOptional(nil),
+		db.User.TotpLastStep.SetOptional(nil),
+	).Tx()
+
+	codesTxn := s.db.RecoveryCode.FindMany(
+		db.RecoveryCode.UserID.Equals(userID),
+	).Delete().Tx()
+
+	return s.db.Prisma.Transaction(userTxn, codesTxn).Exec(ctx)
+}
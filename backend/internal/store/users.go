@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/steebchen/prisma-client-go/runtime/transaction"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/db/db"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
 )
@@ -41,9 +42,30 @@ func (s *Store) GetUserByEmail(ctx context.Context, email string) (*types.User,
 		db.User.Email.Equals(email),
 	).Exec(ctx)
 	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil, ErrNotFound
+		}
 		return nil, err
 	}
 
+	return toUser(user), nil
+}
+
+func (s *Store) GetUserByID(ctx context.Context, userID string) (*types.User, error) {
+	user, err := s.db.User.FindUnique(
+		db.User.ID.Equals(userID),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return toUser(user), nil
+}
+
+func toUser(user *db.UserModel) *types.User {
 	password, ok := user.Password()
 	if !ok {
 		password = ""
@@ -54,75 +76,96 @@ func (s *Store) GetUserByEmail(ctx context.Context, email string) (*types.User,
 		username = ""
 	}
 
-	refreshToken, ok := user.RefreshToken()
-	if !ok {
-		refreshToken = ""
-	}
+	_, emailVerified := user.EmailVerified()
+	_, twoFactorEnabled := user.TotpEnabledAt()
 
 	return &types.User{
-		ID:            user.ID,
-		Username:      username,
-		Email:         email,
-		Password:      password,
-		RefereshToken: refreshToken,
-		CreatedAt:     user.CreatedAt,
-		UpdatedAt:     user.UpdatedAt,
-	}, nil
+		ID:               user.ID,
+		Username:         username,
+		Email:            user.Email,
+		Password:         password,
+		EmailVerified:    emailVerified,
+		TwoFactorEnabled: twoFactorEnabled,
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
+	}
 }
 
-func (s *Store) UpdateUserRefreshToken(ctx context.Context, userID string, refreshToken string) error {
-	_, err := s.db.User.FindUnique(
-		db.User.ID.Equals(userID),
-	).Update(
-		db.User.RefreshToken.Set(refreshToken),
+// CreateOAuthUser resolves a provider identity to a user: the user already
+// linked to the provider account, or a new user. It sets user.ID. When
+// another user already owns the email it returns ErrAccountExists with
+// user.ID set to that user, so the caller can offer to link the identity
+// once the owner signs in; identities are never merged silently. New users
+// with a verified email join every board they were invited to.
+func (s *Store) CreateOAuthUser(ctx context.Context, user *types.CreateOAuthUser) error {
+	account, err := s.db.Account.FindUnique(
+		db.Account.ProviderProviderAccountID(
+			db.Account.Provider.Equals(user.Provider),
+			db.Account.ProviderAccountID.Equals(user.ProviderAccountID),
+		),
 	).Exec(ctx)
-	if err != nil {
+	if err == nil {
+		user.ID = account.UserID
+		return nil
+	}
+	if !db.IsErrNotFound(err) {
 		return err
 	}
 
-	return nil
-}
+	existing, err := s.db.User.FindUnique(
+		db.User.Email.Equals(user.Email),
+	).Exec(ctx)
+	if err == nil {
+		user.ID = existing.ID
+		return ErrAccountExists
+	}
+	if !db.IsErrNotFound(err) {
+		return err
+	}
 
-func (s *Store) CreateOAuthUser(ctx context.Context, user *types.CreateOAuthUser) error {
 	userID := uuid.New().String()
-
-	userTx := s.db.User.UpsertOne(
-		db.User.Email.Equals(user.Email),
-	).Create(
-		db.User.Email.Set(user.Email),
+	params := []db.UserSetParam{
 		db.User.ID.Set(userID),
-		db.User.EmailVerified.Set(time.Now()),
-	).Update(
+		db.User.Username.SetIfPresent(optional(user.Username)),
+		db.User.FirstName.SetIfPresent(optional(user.FirstName)),
+		db.User.LastName.SetIfPresent(optional(user.LastName)),
+		db.User.Avatar.SetIfPresent(optional(user.Avatar)),
+	}
+	if user.EmailVerified {
+		params = append(params, db.User.EmailVerified.Set(time.Now()))
+	}
+
+	userTx := s.db.User.CreateOne(
 		db.User.Email.Set(user.Email),
+		params...,
 	).Tx()
 
-	accTx := s.db.Account.UpsertOne(
-		db.Account.ProviderProviderAccountID(
-			db.Account.Provider.Equals(user.Provider),
-			db.Account.ProviderAccountID.Equals(user.ProviderAccountID),
-		),
-	).Create(
+	accTx := s.db.Account.CreateOne(
 		db.Account.Type.Set("oauth"),
 		db.Account.Provider.Set(user.Provider),
 		db.Account.ProviderAccountID.Set(user.ProviderAccountID),
 		db.Account.User.Link(
 			db.User.ID.Equals(userID),
 		),
-	).Update(
-		db.Account.Provider.Set(user.Provider),
 	).Tx()
 
-	if err := s.db.Prisma.Transaction(userTx, accTx).Exec(ctx); err != nil {
-		return err
-	}
+	txns := []transaction.Param{userTx, accTx}
 
-	usr := userTx.Result()
-	if user == nil {
-		return errors.New("user not found")
+	// the provider vouches for a verified email, so board invitations sent
+	// to it before the account existed can be accepted right away
+	if user.EmailVerified {
+		invitationTxns, err := s.pendingInvitationTxs(ctx, userID, user.Email)
+		if err != nil {
+			return err
+		}
+		txns = append(txns, invitationTxns...)
 	}
 
-	user.ID = usr.ID
+	if err := s.db.Prisma.Transaction(txns...).Exec(ctx); err != nil {
+		return err
+	}
 
+	user.ID = userID
 	return nil
 }
 
@@ -176,6 +219,8 @@ func (s *Store) GetUserByToken(ctx context.Context, token string) (*types.TokenU
 		password = ""
 	}
 
+	_, emailVerified := usr.EmailVerified()
+
 	return &types.TokenUser{
 		Token: types.Token{
 			UserID: usr.ID,
@@ -184,12 +229,13 @@ func (s *Store) GetUserByToken(ctx context.Context, token string) (*types.TokenU
 			Scope:  t.Scope,
 		},
 		User: types.User{
-			ID:        usr.ID,
-			Username:  username,
-			Email:     usr.Email,
-			Password:  password,
-			CreatedAt: usr.CreatedAt,
-			UpdatedAt: usr.UpdatedAt,
+			ID:            usr.ID,
+			Username:      username,
+			Email:         usr.Email,
+			Password:      password,
+			EmailVerified: emailVerified,
+			CreatedAt:     usr.CreatedAt,
+			UpdatedAt:     usr.UpdatedAt,
 		},
 	}, nil
 }
@@ -205,3 +251,59 @@ func (s *Store) UpdateUserPassword(ctx context.Context, userID string, password
 	}
 	return nil
 }
+
+// VerifyEmail consumes an email verification token and marks its owner's
+// address as verified. Tokens are deleted on use, so a second attempt with
+// the same token returns ErrNotFound. Board invitations waiting on the
+// address are accepted in the same transaction.
+func (s *Store) VerifyEmail(ctx context.Context, token string) error {
+	t, err := s.db.Token.FindFirst(
+		db.Token.Token.Equals(token),
+		db.Token.Scope.Equals(types.TokenScopeEmailVerification),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	if time.Now().After(t.TTL) {
+		_, err := s.db.Token.FindUnique(
+			db.Token.Token.Equals(token),
+		).Delete().Exec(ctx)
+		if err != nil && !db.IsErrNotFound(err) {
+			return err
+		}
+		return ErrTokenExpired
+	}
+
+	tokenTxn := s.db.Token.FindUnique(
+		db.Token.Token.Equals(token),
+	).Delete().Tx()
+
+	userTxn := s.db.User.FindUnique(
+		db.User.ID.Equals(t.UID),
+	).Update(
+		db.User.EmailVerified.Set(time.Now()),
+	).Tx()
+
+	user, err := s.GetUserByID(ctx, t.UID)
+	if err != nil {
+		return err
+	}
+
+	invitationTxns, err := s.pendingInvitationTxs(ctx, user.ID, user.Email)
+	if err != nil {
+		return err
+	}
+
+	txns := append([]transaction.Param{tokenTxn, userTxn}, invitationTxns...)
+	if err := s.db.Prisma.Transaction(txns...).Exec(ctx); err != nil {
+		if db.IsErrNotFound(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
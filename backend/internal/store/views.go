@@ -0,0 +1,223 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/db/db"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func (s *Store) CreateView(ctx context.Context, view *types.CreateView) (*types.View, error) {
+	settings, err := json.Marshal(view.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.db.View.CreateOne(
+		db.View.Name.Set(view.Name),
+		db.View.Type.Set(view.Type),
+		db.View.Board.Link(
+			db.Board.ID.Equals(view.BoardID),
+		),
+		db.View.Settings.Set(string(settings)),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toView(created)
+}
+
+func (s *Store) ListBoardViews(ctx context.Context, boardID string) ([]*types.View, error) {
+	views, err := s.db.View.FindMany(
+		db.View.BoardID.Equals(boardID),
+	).OrderBy(
+		db.View.CreatedAt.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.View, 0, len(views))
+	for _, view := range views {
+		v, err := toView(&view)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, v)
+	}
+	return res, nil
+}
+
+func (s *Store) GetView(ctx context.Context, viewID string) (*types.View, error) {
+	view, err := s.db.View.FindUnique(
+		db.View.ID.Equals(viewID),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return toView(view)
+}
+
+func (s *Store) UpdateView(ctx context.Context, view *types.UpdateView) (*types.View, error) {
+	params := []db.ViewSetParam{
+		db.View.Name.SetIfPresent(view.Name),
+	}
+
+	if view.Settings != nil {
+		settings, err := json.Marshal(view.Settings)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, db.View.Settings.Set(string(settings)))
+	}
+
+	updated, err := s.db.View.FindUnique(
+		db.View.ID.Equals(view.ViewID),
+	).Update(
+		params...,
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toView(updated)
+}
+
+func (s *Store) DeleteView(ctx context.Context, viewID string) error {
+	_, err := s.db.View.FindUnique(
+		db.View.ID.Equals(viewID),
+	).Delete().Exec(ctx)
+	return err
+}
+
+// ListViewCards returns the board's cards that pass the view's filters, in
+// board order.
+func (s *Store) ListViewCards(ctx context.Context, query *types.ViewCardQuery) ([]*types.ViewCard, error) {
+	filters := []db.CardWhereParam{
+		db.Card.BoardID.Equals(query.BoardID),
+	}
+
+	if f := query.Filters; f != nil {
+		if len(f.ListIDs) > 0 {
+			filters = append(filters, db.Card.ListID.In(f.ListIDs))
+		}
+		if len(f.LabelIDs) > 0 {
+			filters = append(filters, db.Card.CardLabels.Some(
+				db.CardLabel.LabelID.In(f.LabelIDs),
+			))
+		}
+		if len(f.MemberIDs) > 0 {
+			filters = append(filters, db.Card.CardMembers.Some(
+				db.CardMember.UserID.In(f.MemberIDs),
+			))
+		}
+		if f.Completed != nil {
+			filters = append(filters, db.Card.Completed.Equals(*f.Completed))
+		}
+		if !f.IncludeArchived {
+			filters = append(filters, db.Card.Archived.Equals(false))
+		}
+		if f.Search != "" {
+			filters = append(filters, db.Card.Title.Contains(f.Search))
+		}
+	}
+
+	// keep cards that start, end or run across the requested range
+	if query.From != nil && query.To != nil {
+		filters = append(filters, db.Card.Or(
+			db.Card.And(
+				db.Card.DueDate.Gte(*query.From),
+				db.Card.DueDate.Lt(*query.To),
+			),
+			db.Card.And(
+				db.Card.StartDate.Gte(*query.From),
+				db.Card.StartDate.Lt(*query.To),
+			),
+			db.Card.And(
+				db.Card.StartDate.Lt(*query.From),
+				db.Card.DueDate.Gte(*query.To),
+			),
+		))
+	}
+
+	cards, err := s.db.Card.FindMany(
+		filters...,
+	).With(
+		db.Card.List.Fetch(),
+		db.Card.CardLabels.Fetch().With(
+			db.CardLabel.Label.Fetch(),
+		),
+		db.Card.CardMembers.Fetch().With(
+			db.CardMember.User.Fetch(),
+		),
+	).OrderBy(
+		db.Card.Position.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.ViewCard, 0, len(cards))
+	for _, card := range cards {
+		c := &types.ViewCard{
+			ID:        card.ID,
+			Title:     card.Title,
+			ListID:    card.ListID,
+			ListName:  card.List().Name,
+			Position:  card.Position,
+			Completed: card.Completed,
+			Archived:  card.Archived,
+			Labels:    make([]*types.BoardLabel, 0),
+			Members:   make([]*types.ViewMember, 0),
+			CreatedAt: card.CreatedAt,
+		}
+		if startDate, ok := card.StartDate(); ok {
+			c.StartDate = &startDate
+		}
+		if dueDate, ok := card.DueDate(); ok {
+			c.DueDate = &dueDate
+		}
+		for _, label := range card.CardLabels() {
+			c.Labels = append(c.Labels, &types.BoardLabel{
+				LabelID: label.LabelID,
+				Name:    label.Label().Name,
+				Color:   label.Label().Color,
+			})
+		}
+		for _, member := range card.CardMembers() {
+			username, _ := member.User().Username()
+			c.Members = append(c.Members, &types.ViewMember{
+				UserID:   member.UserID,
+				Username: username,
+			})
+		}
+
+		res = append(res, c)
+	}
+	return res, nil
+}
+
+func toView(view *db.ViewModel) (*types.View, error) {
+	res := &types.View{
+		ID:        view.ID,
+		BoardID:   view.BoardID,
+		Name:      view.Name,
+		Type:      view.Type,
+		CreatedAt: view.CreatedAt,
+		UpdatedAt: view.UpdatedAt,
+	}
+
+	if settings, ok := view.Settings(); ok {
+		if err := json.Unmarshal([]byte(settings), &res.Settings); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
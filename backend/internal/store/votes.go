@@ -0,0 +1,169 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/db/db"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+// votingConfig is the part of the voting settings kept in PowerUp.config.
+type votingConfig struct {
+	RestrictObservers bool `json:"restrictObservers"`
+}
+
+// GetVotingSettings returns the board's voting settings. Boards that never
+// configured voting have it disabled.
+func (s *Store) GetVotingSettings(ctx context.Context, boardID string) (*types.VotingSettings, error) {
+	powerUp, err := s.db.PowerUp.FindFirst(
+		db.PowerUp.BoardID.Equals(boardID),
+		db.PowerUp.Name.Equals(types.VotingPowerUp),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return &types.VotingSettings{}, nil
+		}
+		return nil, err
+	}
+
+	return toVotingSettings(powerUp)
+}
+
+func (s *Store) UpdateVotingSettings(ctx context.Context, settings *types.UpdateVotingSettings) (*types.VotingSettings, error) {
+	current, err := s.GetVotingSettings(ctx, settings.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if settings.Enabled != nil {
+		current.Enabled = *settings.Enabled
+	}
+	if settings.RestrictObservers != nil {
+		current.RestrictObservers = *settings.RestrictObservers
+	}
+
+	config, err := json.Marshal(votingConfig{RestrictObservers: current.RestrictObservers})
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.db.PowerUp.FindMany(
+		db.PowerUp.BoardID.Equals(settings.BoardID),
+		db.PowerUp.Name.Equals(types.VotingPowerUp),
+	).Update(
+		db.PowerUp.Enabled.Set(current.Enabled),
+		db.PowerUp.Config.Set(string(config)),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if updated.Count == 0 {
+		_, err = s.db.PowerUp.CreateOne(
+			db.PowerUp.Name.Set(types.VotingPowerUp),
+			db.PowerUp.Board.Link(
+				db.Board.ID.Equals(settings.BoardID),
+			),
+			db.PowerUp.Description.Set("Let board members vote on cards"),
+			db.PowerUp.Enabled.Set(current.Enabled),
+			db.PowerUp.Config.Set(string(config)),
+		).Exec(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return current, nil
+}
+
+// ToggleCardVote adds the user's vote to the card, or removes it if they
+// already voted. It reports whether the user has a vote on the card
+// afterwards.
+func (s *Store) ToggleCardVote(ctx context.Context, vote *types.ToggleCardVote) (bool, error) {
+	_, err := s.db.Card.FindFirst(
+		db.Card.ID.Equals(vote.CardID),
+		db.Card.BoardID.Equals(vote.BoardID),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return false, ErrNotFound
+		}
+		return false, err
+	}
+
+	deleted, err := s.db.CardVote.FindMany(
+		db.CardVote.CardID.Equals(vote.CardID),
+		db.CardVote.UserID.Equals(vote.UserID),
+	).Delete().Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	if deleted.Count > 0 {
+		return false, nil
+	}
+
+	_, err = s.db.CardVote.CreateOne(
+		db.CardVote.Card.Link(
+			db.Card.ID.Equals(vote.CardID),
+		),
+		db.CardVote.User.Link(
+			db.User.ID.Equals(vote.UserID),
+		),
+	).Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *Store) ListCardVoters(ctx context.Context, boardID, cardID string) ([]*types.CardVoter, error) {
+	votes, err := s.db.CardVote.FindMany(
+		db.CardVote.CardID.Equals(cardID),
+		db.CardVote.Card.Where(
+			db.Card.BoardID.Equals(boardID),
+		),
+	).With(
+		db.CardVote.User.Fetch().Select(
+			db.User.ID.Field(),
+			db.User.Username.Field(),
+			db.User.FirstName.Field(),
+			db.User.LastName.Field(),
+		),
+	).OrderBy(
+		db.CardVote.VotedAt.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.CardVoter, 0, len(votes))
+	for _, vote := range votes {
+		user := vote.User()
+		username, _ := user.Username()
+		firstName, _ := user.FirstName()
+		lastName, _ := user.LastName()
+
+		res = append(res, &types.CardVoter{
+			UserID:   vote.UserID,
+			Username: username,
+			FullName: firstName + " " + lastName,
+			VotedAt:  vote.VotedAt,
+		})
+	}
+	return res, nil
+}
+
+func toVotingSettings(powerUp *db.PowerUpModel) (*types.VotingSettings, error) {
+	settings := &types.VotingSettings{Enabled: powerUp.Enabled}
+
+	if raw, ok := powerUp.Config(); ok && raw != "" {
+		var config votingConfig
+		if err := json.Unmarshal([]byte(raw), &config); err != nil {
+			return nil, err
+		}
+		settings.RestrictObservers = config.RestrictObservers
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,409 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/db/db"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+// ErrAlreadyMember is returned when inviting or adding someone who already
+// belongs to the workspace or board.
+var ErrAlreadyMember = errors.New("store: user is already a member")
+
+// CreateWorkspace creates the workspace with its creator as the only admin.
+func (s *Store) CreateWorkspace(ctx context.Context, payload *types.CreateWorkspace) (*types.Workspace, error) {
+	workspaceID := uuid.New().String()
+
+	workspaceTxn := s.db.Workspace.CreateOne(
+		db.Workspace.Name.Set(payload.Name),
+		db.Workspace.ID.Set(workspaceID),
+		db.Workspace.Description.SetIfPresent(optional(payload.Description)),
+	).Tx()
+
+	memberTxn := s.db.WorkspaceMember.CreateOne(
+		db.WorkspaceMember.Workspace.Link(
+			db.Workspace.ID.Equals(workspaceID),
+		),
+		db.WorkspaceMember.User.Link(
+			db.User.ID.Equals(payload.CreatorID),
+		),
+		db.WorkspaceMember.Role.Set(types.WorkspaceRoleAdmin),
+	).Tx()
+
+	if err := s.db.Prisma.Transaction(workspaceTxn, memberTxn).Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	workspace := toWorkspace(workspaceTxn.Result())
+	workspace.Role = types.WorkspaceRoleAdmin
+	return workspace, nil
+}
+
+// ListWorkspaces returns the workspaces the user belongs to, with their
+// role in each.
+func (s *Store) ListWorkspaces(ctx context.Context, userID string) ([]*types.Workspace, error) {
+	memberships, err := s.db.WorkspaceMember.FindMany(
+		db.WorkspaceMember.UserID.Equals(userID),
+	).With(
+		db.WorkspaceMember.Workspace.Fetch(),
+	).OrderBy(
+		db.WorkspaceMember.CreatedAt.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces := make([]*types.Workspace, 0, len(memberships))
+	for _, membership := range memberships {
+		workspace := toWorkspace(membership.Workspace())
+		workspace.Role = membership.Role
+		workspaces = append(workspaces, workspace)
+	}
+
+	return workspaces, nil
+}
+
+func (s *Store) GetWorkspace(ctx context.Context, workspaceID string) (*types.WorkspaceDetail, error) {
+	workspace, err := s.db.Workspace.FindUnique(
+		db.Workspace.ID.Equals(workspaceID),
+	).With(
+		db.Workspace.Members.Fetch().With(
+			db.WorkspaceMember.User.Fetch(),
+		).OrderBy(
+			db.WorkspaceMember.CreatedAt.Order(db.SortOrderAsc),
+		),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	detail := &types.WorkspaceDetail{
+		Workspace: *toWorkspace(workspace),
+		Members:   make([]*types.WorkspaceMember, 0, len(workspace.Members())),
+	}
+	for _, member := range workspace.Members() {
+		username, _ := member.User().Username()
+		detail.Members = append(detail.Members, &types.WorkspaceMember{
+			WorkspaceID: workspaceID,
+			UserID:      member.UserID,
+			Username:    username,
+			Email:       member.User().Email,
+			Role:        member.Role,
+		})
+	}
+
+	return detail, nil
+}
+
+func (s *Store) UpdateWorkspace(ctx context.Context, payload *types.UpdateWorkspace) error {
+	_, err := s.db.Workspace.FindUnique(
+		db.Workspace.ID.Equals(payload.WorkspaceID),
+	).Update(
+		db.Workspace.Name.SetIfPresent(payload.Name),
+		db.Workspace.Description.SetIfPresent(payload.Description),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// DeleteWorkspace deletes the workspace. Its boards are kept and detached,
+// which leaves team boards readable by their own members only.
+func (s *Store) DeleteWorkspace(ctx context.Context, workspaceID string) error {
+	_, err := s.db.Workspace.FindUnique(
+		db.Workspace.ID.Equals(workspaceID),
+	).Delete().Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *Store) GetWorkspaceMember(ctx context.Context, workspaceID, userID string) (*types.WorkspaceMember, error) {
+	member, err := s.db.WorkspaceMember.FindUnique(
+		db.WorkspaceMember.WorkspaceIDUserID(
+			db.WorkspaceMember.WorkspaceID.Equals(workspaceID),
+			db.WorkspaceMember.UserID.Equals(userID),
+		),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &types.WorkspaceMember{
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+		Role:        member.Role,
+	}, nil
+}
+
+// UpdateWorkspaceMemberRole changes a member's role. It returns ErrNotFound
+// if the user is not a member and ErrLastAdmin when demoting the only admin.
+func (s *Store) UpdateWorkspaceMemberRole(ctx context.Context, payload *types.UpdateWorkspaceMemberRole) error {
+	member, err := s.GetWorkspaceMember(ctx, payload.WorkspaceID, payload.UserID)
+	if err != nil {
+		return err
+	}
+
+	if member.Role == payload.Role {
+		return nil
+	}
+
+	if member.Role == types.WorkspaceRoleAdmin {
+		if err := s.ensureOtherWorkspaceAdmin(ctx, payload.WorkspaceID); err != nil {
+			return err
+		}
+	}
+
+	_, err = s.db.WorkspaceMember.FindUnique(
+		db.WorkspaceMember.WorkspaceIDUserID(
+			db.WorkspaceMember.WorkspaceID.Equals(payload.WorkspaceID),
+			db.WorkspaceMember.UserID.Equals(payload.UserID),
+		),
+	).Update(
+		db.WorkspaceMember.Role.Set(payload.Role),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// RemoveWorkspaceMember takes the user out of the workspace. Their board
+// memberships are kept; only team-visibility access goes away. It returns
+// ErrNotFound if the user is not a member and ErrLastAdmin when removing the
+// only admin.
+func (s *Store) RemoveWorkspaceMember(ctx context.Context, workspaceID, userID string) error {
+	member, err := s.GetWorkspaceMember(ctx, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+
+	if member.Role == types.WorkspaceRoleAdmin {
+		if err := s.ensureOtherWorkspaceAdmin(ctx, workspaceID); err != nil {
+			return err
+		}
+	}
+
+	_, err = s.db.WorkspaceMember.FindUnique(
+		db.WorkspaceMember.WorkspaceIDUserID(
+			db.WorkspaceMember.WorkspaceID.Equals(workspaceID),
+			db.WorkspaceMember.UserID.Equals(userID),
+		),
+	).Delete().Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *Store) ensureOtherWorkspaceAdmin(ctx context.Context, workspaceID string) error {
+	admins, err := s.db.WorkspaceMember.FindMany(
+		db.WorkspaceMember.WorkspaceID.Equals(workspaceID),
+		db.WorkspaceMember.Role.Equals(types.WorkspaceRoleAdmin),
+	).Take(2).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(admins) < 2 {
+		return ErrLastAdmin
+	}
+	return nil
+}
+
+// CreateWorkspaceInvitation stores a pending invitation. It returns
+// ErrAlreadyMember when the email belongs to a member of the workspace.
+func (s *Store) CreateWorkspaceInvitation(ctx context.Context, invitation *types.WorkspaceInvitation) error {
+	members, err := s.db.WorkspaceMember.FindMany(
+		db.WorkspaceMember.WorkspaceID.Equals(invitation.WorkspaceID),
+		db.WorkspaceMember.User.Where(
+			db.User.Email.Equals(invitation.Email),
+		),
+	).Take(1).Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if len(members) > 0 {
+		return ErrAlreadyMember
+	}
+
+	_, err = s.db.WorkspaceInvitation.CreateOne(
+		db.WorkspaceInvitation.Workspace.Link(
+			db.Workspace.ID.Equals(invitation.WorkspaceID),
+		),
+		db.WorkspaceInvitation.Email.Set(invitation.Email),
+		db.WorkspaceInvitation.InvitedByUser.Link(
+			db.User.ID.Equals(invitation.InvitedBy),
+		),
+		db.WorkspaceInvitation.Token.Set(invitation.Token),
+		db.WorkspaceInvitation.Role.Set(invitation.Role),
+		db.WorkspaceInvitation.ExpiresAt.Set(invitation.ExpiredAt),
+	).Exec(ctx)
+	return err
+}
+
+func (s *Store) GetWorkspaceInvitationByToken(ctx context.Context, token string) (*types.WorkspaceInvitation, error) {
+	invitation, err := s.db.WorkspaceInvitation.FindUnique(
+		db.WorkspaceInvitation.Token.Equals(token),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &types.WorkspaceInvitation{
+		WorkspaceID: invitation.WorkspaceID,
+		Email:       invitation.Email,
+		InvitedBy:   invitation.InvitedBy,
+		Token:       invitation.Token,
+		ExpiredAt:   invitation.ExpiresAt,
+		Role:        invitation.Role,
+		Status:      invitation.Status,
+	}, nil
+}
+
+// AcceptWorkspaceInvitation marks the invitation accepted and adds the user
+// with the invited role. It returns ErrNotFound for unknown or already used
+// invitations and ErrTokenExpired for expired ones.
+func (s *Store) AcceptWorkspaceInvitation(ctx context.Context, token, userID string) error {
+	invitation, err := s.GetWorkspaceInvitationByToken(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	if invitation.Status != types.InvitationPending {
+		return ErrNotFound
+	}
+	if time.Now().After(invitation.ExpiredAt) {
+		return ErrTokenExpired
+	}
+
+	if _, err := s.GetWorkspaceMember(ctx, invitation.WorkspaceID, userID); err == nil {
+		return ErrAlreadyMember
+	} else if !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	invitationTxn := s.db.WorkspaceInvitation.FindUnique(
+		db.WorkspaceInvitation.Token.Equals(token),
+	).Update(
+		db.WorkspaceInvitation.Status.Set(types.InvitationAccepted),
+	).Tx()
+
+	memberTxn := s.db.WorkspaceMember.CreateOne(
+		db.WorkspaceMember.Workspace.Link(
+			db.Workspace.ID.Equals(invitation.WorkspaceID),
+		),
+		db.WorkspaceMember.User.Link(
+			db.User.ID.Equals(userID),
+		),
+		db.WorkspaceMember.Role.Set(invitation.Role),
+	).Tx()
+
+	return s.db.Prisma.Transaction(invitationTxn, memberTxn).Exec(ctx)
+}
+
+// ListWorkspaceBoards returns the workspace's boards the user can see: team
+// and public boards, and private boards they are a member of.
+func (s *Store) ListWorkspaceBoards(ctx context.Context, workspaceID, userID string, paginate *types.Paginate) ([]*types.Board, error) {
+	query := s.db.Board.FindMany(
+		db.Board.WorkspaceID.Equals(workspaceID),
+		db.Board.Or(
+			db.Board.Visibility.In([]string{types.BoardVisibilityTeam, types.BoardVisibilityPublic}),
+			db.Board.BoardMembers.Some(
+				db.BoardMember.UserID.Equals(userID),
+			),
+		),
+	).Skip(paginate.Offset).Take(paginate.Size)
+
+	if paginate.SortBy == "created_at" {
+		query = query.OrderBy(
+			db.Board.CreatedAt.Order(db.SortOrder(paginate.SortOrder)),
+		)
+	}
+
+	boards, err := query.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.Board, 0, len(boards))
+	for _, board := range boards {
+		res = append(res, toBoard(&board))
+	}
+
+	return res, nil
+}
+
+// MoveBoardToWorkspace puts the board in the workspace, recording the move
+// in the board's activity.
+func (s *Store) MoveBoardToWorkspace(ctx context.Context, payload *types.MoveBoard) error {
+	board, err := s.db.Board.FindUnique(
+		db.Board.ID.Equals(payload.BoardID),
+	).Exec(ctx)
+	if err != nil {
+		if db.IsErrNotFound(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	current, _ := board.WorkspaceID()
+	if current == payload.WorkspaceID {
+		return nil
+	}
+
+	boardTxn := s.db.Board.FindUnique(
+		db.Board.ID.Equals(payload.BoardID),
+	).Update(
+		db.Board.Workspace.Link(
+			db.Workspace.ID.Equals(payload.WorkspaceID),
+		),
+	).Tx()
+
+	activityTxn := s.activityTx(ctx, activity{
+		boardID: payload.BoardID,
+		kind:    types.ActivityBoardUpdated,
+		before:  map[string]any{"workspaceID": current},
+		after:   map[string]any{"workspaceID": payload.WorkspaceID},
+	})
+
+	return s.db.Prisma.Transaction(boardTxn, activityTxn).Exec(ctx)
+}
+
+func toWorkspace(workspace *db.WorkspaceModel) *types.Workspace {
+	description, _ := workspace.Description()
+
+	return &types.Workspace{
+		ID:          workspace.ID,
+		Name:        workspace.Name,
+		Description: description,
+		CreatedAt:   workspace.CreatedAt,
+	}
+}
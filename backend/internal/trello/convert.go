@@ -0,0 +1,296 @@
+package trello
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+// Field limits of the Nexus board export; longer Trello values are cut.
+const (
+	maxBoardName   = 20
+	maxName        = 255
+	maxDescription = 1000
+	maxCommentText = 5000
+)
+
+// maxActions is how many actions Trello puts in a board export; comments
+// older than that are not in the file.
+const maxActions = 1000
+
+// defaultLabelColor stands in for labels Trello shows without a color.
+const defaultLabelColor = "#b3bac5"
+
+// labelColors maps Trello's label color names to hex. The _light and _dark
+// variants of newer boards map to their base color.
+var labelColors = map[string]string{
+	"green":  "#61bd4f",
+	"yellow": "#f2d600",
+	"orange": "#ff9f1a",
+	"red":    "#eb5a46",
+	"purple": "#c377e0",
+	"blue":   "#0079bf",
+	"sky":    "#00c2e0",
+	"lime":   "#51e898",
+	"pink":   "#ff78cb",
+	"black":  "#344563",
+}
+
+var memberRoles = map[string]string{
+	"admin":    types.RoleAdmin,
+	"normal":   types.RoleNormal,
+	"observer": types.RoleObserver,
+}
+
+// Convert maps a Trello board onto a Nexus board export. Records that
+// cannot be carried over are left out and counted in the report; members
+// are listed in the export and left for the caller to match.
+func Convert(board *Board) (*types.BoardExport, *types.ImportReport) {
+	report := &types.ImportReport{}
+	export := &types.BoardExport{
+		Version:    types.BoardExportVersion,
+		ExportedAt: time.Now(),
+		Labels:     []*types.ExportLabel{},
+		Members:    []*types.ExportMember{},
+		Lists:      []*types.ExportList{},
+		Cards:      []*types.ExportCard{},
+	}
+
+	export.Board = convertBoard(board, &report.Board)
+	report.Board.Imported = 1
+
+	labels := make(map[string]bool, len(board.Labels))
+	for _, label := range board.Labels {
+		color := defaultLabelColor
+		if label.Color != nil {
+			base, _, _ := strings.Cut(*label.Color, "_")
+			if hex, ok := labelColors[base]; ok {
+				color = hex
+			} else {
+				report.Labels.Warnings = append(report.Labels.Warnings,
+					fmt.Sprintf("label %q has unknown color %q; using grey", label.Name, *label.Color))
+			}
+		}
+
+		labels[label.ID] = true
+		export.Labels = append(export.Labels, &types.ExportLabel{
+			ID:    label.ID,
+			Name:  truncate(label.Name, maxName, "label name", &report.Labels),
+			Color: color,
+		})
+		report.Labels.Imported++
+	}
+
+	lists := make(map[string]bool, len(board.Lists))
+	for _, list := range board.Lists {
+		name := strings.TrimSpace(list.Name)
+		if name == "" {
+			name = "Untitled list"
+		}
+
+		lists[list.ID] = true
+		export.Lists = append(export.Lists, &types.ExportList{
+			ID:       list.ID,
+			Name:     truncate(name, maxName, "list name", &report.Lists),
+			Position: list.Pos,
+			Archived: list.Closed,
+		})
+		report.Lists.Imported++
+	}
+
+	cards := make(map[string]*types.ExportCard, len(board.Cards))
+	for _, card := range board.Cards {
+		if !lists[card.IDList] {
+			report.Cards.Skip(fmt.Sprintf("card %q is in a list that is not in the export", card.Name))
+			continue
+		}
+
+		title := strings.TrimSpace(card.Name)
+		if title == "" {
+			title = "Untitled card"
+		}
+
+		c := &types.ExportCard{
+			ID:          card.ID,
+			ListID:      card.IDList,
+			Title:       truncate(title, maxName, "card title", &report.Cards),
+			Description: card.Desc,
+			Position:    card.Pos,
+			DueDate:     card.Due,
+			StartDate:   card.Start,
+			Archived:    card.Closed,
+			Completed:   card.DueComplete,
+			LabelIDs:    []string{},
+			MemberIDs:   card.IDMembers,
+			BlockedBy:   []string{},
+			Checklists:  []*types.ExportChecklist{},
+			Comments:    []*types.ExportComment{},
+		}
+		for _, id := range card.IDLabels {
+			if labels[id] {
+				c.LabelIDs = append(c.LabelIDs, id)
+			}
+		}
+		if c.MemberIDs == nil {
+			c.MemberIDs = []string{}
+		}
+
+		for range card.Attachments {
+			report.Attachments.Skip("")
+		}
+
+		cards[card.ID] = c
+		export.Cards = append(export.Cards, c)
+		report.Cards.Imported++
+	}
+	if report.Attachments.Skipped > 0 {
+		report.Attachments.Warnings = append(report.Attachments.Warnings,
+			"attachments are not imported; their files stay on Trello")
+	}
+
+	for _, checklist := range board.Checklists {
+		card, ok := cards[checklist.IDCard]
+		if !ok {
+			report.Checklists.Skip(fmt.Sprintf("checklist %q belongs to a card that was not imported", checklist.Name))
+			report.CheckItems.Skipped += len(checklist.CheckItems)
+			continue
+		}
+
+		name := strings.TrimSpace(checklist.Name)
+		if name == "" {
+			name = "Checklist"
+		}
+
+		cl := &types.ExportChecklist{
+			ID:       checklist.ID,
+			Name:     truncate(name, maxName, "checklist name", &report.Checklists),
+			Position: checklist.Pos,
+			Items:    []*types.ExportChecklistItem{},
+		}
+		for _, item := range checklist.CheckItems {
+			text := strings.TrimSpace(item.Name)
+			if text == "" {
+				report.CheckItems.Skip(fmt.Sprintf("empty item in checklist %q", checklist.Name))
+				continue
+			}
+
+			cl.Items = append(cl.Items, &types.ExportChecklistItem{
+				ID:        item.ID,
+				Text:      text,
+				Completed: item.State == "complete",
+				Position:  item.Pos,
+				DueDate:   item.Due,
+			})
+			report.CheckItems.Imported++
+		}
+
+		card.Checklists = append(card.Checklists, cl)
+		report.Checklists.Imported++
+	}
+
+	for _, action := range board.Actions {
+		if action.Type != "commentCard" {
+			continue
+		}
+
+		if action.Data.Card == nil || cards[action.Data.Card.ID] == nil {
+			report.Comments.Skip("a comment belongs to a card that was not imported")
+			continue
+		}
+		text := strings.TrimSpace(action.Data.Text)
+		if text == "" {
+			report.Comments.Skip("")
+			continue
+		}
+
+		username := ""
+		if action.MemberCreator != nil {
+			username = displayName(action.MemberCreator)
+		}
+
+		card := cards[action.Data.Card.ID]
+		card.Comments = append(card.Comments, &types.ExportComment{
+			ID:        action.ID,
+			UserID:    action.IDMemberCreator,
+			Username:  username,
+			Content:   truncate(text, maxCommentText, "comment", &report.Comments),
+			CreatedAt: action.Date,
+		})
+		report.Comments.Imported++
+	}
+	if len(board.Actions) >= maxActions {
+		report.Comments.Warnings = append(report.Comments.Warnings,
+			fmt.Sprintf("Trello exports hold only the latest %d actions; older comments may be missing", maxActions))
+	}
+
+	roles := make(map[string]string, len(board.Memberships))
+	for _, membership := range board.Memberships {
+		roles[membership.IDMember] = memberRoles[membership.MemberType]
+	}
+	for _, member := range board.Members {
+		role := roles[member.ID]
+		if role == "" {
+			role = types.RoleNormal
+		}
+		export.Members = append(export.Members, &types.ExportMember{
+			UserID:   member.ID,
+			Username: displayName(&member),
+			Email:    member.Email,
+			Role:     role,
+		})
+	}
+
+	return export, report
+}
+
+func convertBoard(board *Board, report *types.ImportEntityReport) *types.ExportBoard {
+	name := strings.TrimSpace(board.Name)
+	if name == "" {
+		name = "Trello board"
+	}
+
+	background := board.Prefs.BackgroundColor
+	if background == "" {
+		background = board.Prefs.BackgroundImage
+	}
+
+	// boards are imported private whatever their Trello visibility, so
+	// nothing is published by accident
+	if board.Prefs.PermissionLevel == "public" {
+		report.Warnings = append(report.Warnings, "the board was public on Trello and is imported as private")
+	}
+
+	return &types.ExportBoard{
+		ID:          board.ID,
+		Name:        truncate(name, maxBoardName, "board name", report),
+		Description: truncate(board.Desc, maxDescription, "board description", report),
+		Background:  background,
+		Visibility:  types.BoardVisibilityPrivate,
+	}
+}
+
+func displayName(member *Member) string {
+	if member.FullName != "" {
+		return member.FullName
+	}
+	return member.Username
+}
+
+// truncate cuts s to max characters, noting it in the report.
+func truncate(s string, max int, what string, report *types.ImportEntityReport) string {
+	cut := truncateRunes(s, max)
+	if cut != s {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("%s %q was cut to %d characters", what, truncateRunes(s, 40), max))
+	}
+	return cut
+}
+
+func truncateRunes(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
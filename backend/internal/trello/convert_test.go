@@ -0,0 +1,154 @@
+package trello
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func parseFixture(t *testing.T, name string) *Board {
+	t.Helper()
+
+	f, err := os.Open("testdata/" + name)
+	require.NoError(t, err)
+	defer f.Close()
+
+	board, err := Parse(f)
+	require.NoError(t, err)
+	return board
+}
+
+func TestConvert(t *testing.T) {
+	export, report := Convert(parseFixture(t, "board.json"))
+
+	require.NoError(t, validator.New().Struct(export), "the export must pass import validation")
+
+	t.Run("board", func(t *testing.T) {
+		assert.Equal(t, "Product Roadmap 2025", export.Board.Name)
+		assert.Equal(t, types.BoardVisibilityPrivate, export.Board.Visibility)
+		assert.Equal(t, "#0079BF", export.Board.Background)
+		assert.Equal(t, 1, report.Board.Imported)
+		assert.Len(t, report.Board.Warnings, 2, "name cut and public board made private")
+	})
+
+	t.Run("labels", func(t *testing.T) {
+		colors := map[string]string{}
+		for _, label := range export.Labels {
+			colors[label.Name] = label.Color
+		}
+		assert.Equal(t, map[string]string{
+			"Feature": "#61bd4f",
+			"Bug":     "#eb5a46",
+			"Misc":    defaultLabelColor,
+			"Odd":     defaultLabelColor,
+		}, colors)
+		assert.Equal(t, types.ImportEntityReport{
+			Imported: 4,
+			Warnings: []string{`label "Odd" has unknown color "chartreuse"; using grey`},
+		}, report.Labels)
+	})
+
+	t.Run("lists", func(t *testing.T) {
+		require.Len(t, export.Lists, 3)
+		assert.Equal(t, float64(32768), export.Lists[1].Position)
+		assert.True(t, export.Lists[2].Archived)
+		assert.Equal(t, 3, report.Lists.Imported)
+	})
+
+	t.Run("cards", func(t *testing.T) {
+		require.Len(t, export.Cards, 2)
+		assert.Equal(t, 2, report.Cards.Imported)
+		assert.Equal(t, 1, report.Cards.Skipped)
+
+		card := export.Cards[0]
+		assert.Equal(t, "Dark mode", card.Title)
+		assert.Equal(t, "list1", card.ListID)
+		assert.Equal(t, float64(65535), card.Position)
+		require.NotNil(t, card.DueDate)
+		require.NotNil(t, card.StartDate)
+		assert.Equal(t, "2025-03-01T17:00:00Z", card.DueDate.UTC().Format("2006-01-02T15:04:05Z"))
+		assert.Equal(t, []string{"lbl1", "lbl2"}, card.LabelIDs, "unknown labels are dropped")
+		assert.Equal(t, []string{"mem1", "mem3"}, card.MemberIDs)
+
+		done := export.Cards[1]
+		assert.True(t, done.Archived)
+		assert.True(t, done.Completed)
+		assert.Nil(t, done.StartDate)
+	})
+
+	t.Run("checklists", func(t *testing.T) {
+		checklists := export.Cards[0].Checklists
+		require.Len(t, checklists, 1)
+		require.Len(t, checklists[0].Items, 2)
+		assert.True(t, checklists[0].Items[0].Completed)
+		assert.False(t, checklists[0].Items[1].Completed)
+		assert.NotNil(t, checklists[0].Items[1].DueDate)
+
+		assert.Equal(t, 1, report.Checklists.Imported)
+		assert.Equal(t, 1, report.Checklists.Skipped)
+		assert.Equal(t, 2, report.CheckItems.Imported)
+		assert.Equal(t, 3, report.CheckItems.Skipped, "the blank item and both items of the orphaned checklist")
+	})
+
+	t.Run("comments", func(t *testing.T) {
+		comments := export.Cards[0].Comments
+		require.Len(t, comments, 1)
+		assert.Equal(t, "mem1", comments[0].UserID)
+		assert.Equal(t, "Ada Lovelace", comments[0].Username)
+		assert.Equal(t, "Let's use CSS variables.", comments[0].Content)
+		assert.Equal(t, 1, report.Comments.Imported)
+		assert.Equal(t, 1, report.Comments.Skipped)
+	})
+
+	t.Run("attachments", func(t *testing.T) {
+		assert.Empty(t, export.Cards[0].Attachments)
+		assert.Equal(t, 1, report.Attachments.Skipped)
+	})
+
+	t.Run("members", func(t *testing.T) {
+		require.Len(t, export.Members, 3)
+		assert.Equal(t, &types.ExportMember{UserID: "mem1", Username: "Ada Lovelace", Email: "ada@example.com", Role: types.RoleAdmin}, export.Members[0])
+		assert.Equal(t, "", export.Members[1].Email)
+		assert.Equal(t, "carol", export.Members[2].Username)
+		assert.Equal(t, types.RoleObserver, export.Members[2].Role)
+	})
+}
+
+func TestConvertEmptyBoard(t *testing.T) {
+	export, report := Convert(parseFixture(t, "empty.json"))
+
+	require.NoError(t, validator.New().Struct(export))
+	assert.Equal(t, "Trello board", export.Board.Name)
+	assert.Equal(t, "https://trello-backgrounds.s3.amazonaws.com/photo.jpg", export.Board.Background)
+	assert.Empty(t, export.Lists)
+	assert.Empty(t, export.Cards)
+	assert.Empty(t, report.Board.Warnings)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   error
+	}{
+		{"not a board", `{"foo": "bar"}`, ErrNotABoard},
+		{"card export", `{"id": "card1", "name": "a card", "idList": "list1"}`, ErrNotABoard},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.input))
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	t.Run("malformed json", func(t *testing.T) {
+		_, err := Parse(strings.NewReader(`{"id":`))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,133 @@
+// Package trello reads the JSON export of a Trello board and maps it onto
+// the Nexus board export, which the store knows how to import.
+package trello
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrNotABoard is returned when the document does not look like a Trello
+// board export.
+var ErrNotABoard = errors.New("trello: not a board export")
+
+// Board is the part of Trello's board export that the import reads. Trello
+// adds fields over time, so unknown ones are ignored.
+type Board struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Desc        string       `json:"desc"`
+	Closed      bool         `json:"closed"`
+	Prefs       Prefs        `json:"prefs"`
+	Labels      []Label      `json:"labels"`
+	Lists       []List       `json:"lists"`
+	Cards       []Card       `json:"cards"`
+	Checklists  []Checklist  `json:"checklists"`
+	Members     []Member     `json:"members"`
+	Memberships []Membership `json:"memberships"`
+	Actions     []Action     `json:"actions"`
+}
+
+type Prefs struct {
+	PermissionLevel string `json:"permissionLevel"`
+	BackgroundColor string `json:"backgroundColor"`
+	BackgroundImage string `json:"backgroundImage"`
+}
+
+type Label struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Color is a Trello color name such as "green" or "sky_dark"; labels
+	// without a color have none.
+	Color *string `json:"color"`
+}
+
+type List struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Closed bool    `json:"closed"`
+	Pos    float64 `json:"pos"`
+}
+
+type Card struct {
+	ID          string       `json:"id"`
+	IDList      string       `json:"idList"`
+	Name        string       `json:"name"`
+	Desc        string       `json:"desc"`
+	Closed      bool         `json:"closed"`
+	Pos         float64      `json:"pos"`
+	Due         *time.Time   `json:"due"`
+	Start       *time.Time   `json:"start"`
+	DueComplete bool         `json:"dueComplete"`
+	IDLabels    []string     `json:"idLabels"`
+	IDMembers   []string     `json:"idMembers"`
+	Attachments []Attachment `json:"attachments"`
+}
+
+type Attachment struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	MimeType string `json:"mimeType"`
+	Bytes    int    `json:"bytes"`
+}
+
+type Checklist struct {
+	ID         string      `json:"id"`
+	IDCard     string      `json:"idCard"`
+	Name       string      `json:"name"`
+	Pos        float64     `json:"pos"`
+	CheckItems []CheckItem `json:"checkItems"`
+}
+
+type CheckItem struct {
+	ID    string     `json:"id"`
+	Name  string     `json:"name"`
+	State string     `json:"state"` // complete, incomplete
+	Pos   float64    `json:"pos"`
+	Due   *time.Time `json:"due"`
+}
+
+type Member struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	FullName string `json:"fullName"`
+	// Email is only present in exports made by workspace admins.
+	Email string `json:"email"`
+}
+
+type Membership struct {
+	IDMember   string `json:"idMember"`
+	MemberType string `json:"memberType"` // admin, normal, observer
+}
+
+// Action is an entry of the board's history; the import only reads
+// comments from it.
+type Action struct {
+	ID              string     `json:"id"`
+	Type            string     `json:"type"`
+	Date            time.Time  `json:"date"`
+	IDMemberCreator string     `json:"idMemberCreator"`
+	Data            ActionData `json:"data"`
+	MemberCreator   *Member    `json:"memberCreator"`
+}
+
+type ActionData struct {
+	Text string `json:"text"`
+	Card *struct {
+		ID string `json:"id"`
+	} `json:"card"`
+}
+
+// Parse decodes a Trello board export.
+func Parse(r io.Reader) (*Board, error) {
+	var board Board
+	if err := json.NewDecoder(r).Decode(&board); err != nil {
+		return nil, err
+	}
+	if board.ID == "" || board.Lists == nil {
+		return nil, ErrNotABoard
+	}
+	return &board, nil
+}
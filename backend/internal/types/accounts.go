@@ -0,0 +1,50 @@
+package types
+
+import "time"
+
+// Account link token purposes. An intent is issued to a signed-in user
+// starting a provider flow; a pending link is issued by the callback when a
+// new identity's email matches an existing user.
+const (
+	AccountLinkIntent  = "link_intent"
+	AccountReauth      = "reauth"
+	AccountLinkPending = "link_pending"
+)
+
+// AccountLinkClaims travel in the short-lived, signed tokens that carry an
+// account link or re-authentication across the provider redirect.
+type AccountLinkClaims struct {
+	Purpose           string `json:"purpose"`
+	UserID            string `json:"userID"`
+	SessionID         string `json:"sessionID,omitempty"`
+	Provider          string `json:"provider"`
+	ProviderAccountID string `json:"providerAccountID,omitempty"`
+	Email             string `json:"email,omitempty"`
+}
+
+type LinkAccount struct {
+	UserID            string
+	Provider          string
+	ProviderAccountID string
+}
+
+type ConfirmAccountLink struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type Reauthenticate struct {
+	Password string `json:"password" validate:"required,max=72"`
+}
+
+type LinkedAccount struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Provider  string    `json:"provider"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// LoginMethods lists every way a user can sign in.
+type LoginMethods struct {
+	Password bool             `json:"password"`
+	Accounts []*LinkedAccount `json:"accounts"`
+}
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	ActivityBoardCreated = "board_created"
+	ActivityBoardUpdated = "board_updated"
+
+	ActivityMemberInvited      = "member_invited"
+	ActivityInvitationAccepted = "invitation_accepted"
+	ActivityMemberRoleChanged  = "member_role_changed"
+	ActivityMemberRemoved      = "member_removed"
+	ActivityMemberLeft         = "member_left"
+	ActivityMemberJoined       = "member_joined"
+
+	ActivityListCreated = "list_created"
+	ActivityListUpdated = "list_updated"
+	ActivityListDeleted = "list_deleted"
+
+	ActivityCardCreated       = "card_created"
+	ActivityCardUpdated       = "card_updated"
+	ActivityCardMoved         = "card_moved"
+	ActivityCardDeleted       = "card_deleted"
+	ActivityCardMemberAdded   = "card_member_added"
+	ActivityCardMemberRemoved = "card_member_removed"
+
+	ActivityCardDependencyAdded   = "card_dependency_added"
+	ActivityCardDependencyRemoved = "card_dependency_removed"
+
+	ActivityLabelCreated         = "label_created"
+	ActivityLabelUpdated         = "label_updated"
+	ActivityLabelDeleted         = "label_deleted"
+	ActivityLabelAddedToCard     = "label_added_to_card"
+	ActivityLabelRemovedFromCard = "label_removed_from_card"
+
+	ActivityChecklistCreated     = "checklist_created"
+	ActivityChecklistDeleted     = "checklist_deleted"
+	ActivityChecklistItemCreated = "checklist_item_created"
+	ActivityChecklistItemUpdated = "checklist_item_updated"
+	ActivityChecklistItemDeleted = "checklist_item_deleted"
+
+	ActivityCommentAdded   = "comment_added"
+	ActivityCommentUpdated = "comment_updated"
+	ActivityCommentDeleted = "comment_deleted"
+
+	ActivityAttachmentAdded   = "attachment_added"
+	ActivityAttachmentDeleted = "attachment_deleted"
+)
+
+type Activity struct {
+	ID        string          `json:"id"`
+	BoardID   string          `json:"boardID"`
+	CardID    string          `json:"cardID,omitempty"`
+	ListID    string          `json:"listID,omitempty"`
+	UserID    string          `json:"userID"`
+	Type      string          `json:"type"`
+	Metadata  json.RawMessage `json:"metadata,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+// ActivityMetadata is the JSON document stored in Activity.metadata.
+type ActivityMetadata struct {
+	Before map[string]any `json:"before,omitempty"`
+	After  map[string]any `json:"after,omitempty"`
+}
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Personal access token scopes. A write scope also grants the matching
+// read scope.
+const (
+	ScopeBoardsRead     = "boards:read"
+	ScopeBoardsWrite    = "boards:write"
+	ScopeCardsRead      = "cards:read"
+	ScopeCardsWrite     = "cards:write"
+	ScopeTemplatesRead  = "templates:read"
+	ScopeTemplatesWrite = "templates:write"
+)
+
+// APITokenPrefix starts every personal access token so they can be told
+// apart from session JWTs, and found by secret scanners.
+const APITokenPrefix = "nxp_"
+
+// HasScope reports whether an API token user holds scope, directly or
+// through the matching write scope. Session users hold every scope.
+func (u *User) HasScope(scope string) bool {
+	if u.APITokenID == "" {
+		return true
+	}
+
+	if slices.Contains(u.Scopes, scope) {
+		return true
+	}
+
+	resource, access, _ := strings.Cut(scope, ":")
+	return access == "read" && slices.Contains(u.Scopes, resource+":write")
+}
+
+type CreateAPIToken struct {
+	ID        string     `json:"-"`
+	UserID    string     `json:"-"`
+	TokenHash string     `json:"-"`
+	Prefix    string     `json:"-"`
+	Name      string     `json:"name" validate:"required,min=1,max=100"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1,unique,dive,oneof=boards:read boards:write cards:read cards:write templates:read templates:write"`
+	ExpiresAt *time.Time `json:"expiresAt" validate:"omitempty"`
+}
+
+type APIToken struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
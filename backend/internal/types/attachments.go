@@ -0,0 +1,26 @@
+package types
+
+import "time"
+
+type CreateAttachment struct {
+	CardID   string `validate:"required,uuid"`
+	UserID   string `validate:"required,uuid"`
+	FileName string `validate:"required,max=255"`
+	FileKey  string `validate:"required"`
+	FileType string `validate:"required"`
+	FileSize int    `validate:"gte=0,lte=10485760"`
+}
+
+type Attachment struct {
+	ID         string    `json:"id"`
+	CardID     string    `json:"cardID"`
+	FileName   string    `json:"fileName"`
+	FileType   string    `json:"fileType"`
+	FileSize   int       `json:"fileSize"`
+	UploadedBy string    `json:"uploadedBy"`
+	URL        string    `json:"url"`
+	CreatedAt  time.Time `json:"createdAt"`
+
+	// FileKey is the blob storage key the contents are stored under.
+	FileKey string `json:"-"`
+}
@@ -0,0 +1,95 @@
+package types
+
+import "time"
+
+const (
+	TriggerCardMoved          = "card_moved"
+	TriggerLabelAdded         = "label_added"
+	TriggerDueDatePassed      = "due_date_passed"
+	TriggerChecklistCompleted = "checklist_completed"
+
+	ActionMoveCard      = "move_card"
+	ActionAddLabel      = "add_label"
+	ActionRemoveLabel   = "remove_label"
+	ActionAssignMember  = "assign_member"
+	ActionMarkCompleted = "mark_completed"
+	ActionSetDueDate    = "set_due_date"
+	ActionPostComment   = "post_comment"
+
+	AutomationRunSucceeded = "succeeded"
+	AutomationRunFailed    = "failed"
+	AutomationRunSkipped   = "skipped"
+)
+
+// AutomationTrigger decides which events fire a rule. ListID narrows
+// card_moved to a destination list and LabelID narrows label_added to a
+// label; leaving them empty matches any list or label.
+type AutomationTrigger struct {
+	Type    string `json:"type" validate:"required,oneof=card_moved label_added due_date_passed checklist_completed"`
+	ListID  string `json:"listID,omitempty" validate:"omitempty,uuid"`
+	LabelID string `json:"labelID,omitempty" validate:"omitempty,uuid"`
+}
+
+type AutomationAction struct {
+	Type      string `json:"type" validate:"required,oneof=move_card add_label remove_label assign_member mark_completed set_due_date post_comment"`
+	ListID    string `json:"listID,omitempty" validate:"required_if=Type move_card,omitempty,uuid"`
+	LabelID   string `json:"labelID,omitempty" validate:"required_if=Type add_label,required_if=Type remove_label,omitempty,uuid"`
+	UserID    string `json:"userID,omitempty" validate:"required_if=Type assign_member,omitempty,uuid"`
+	DueInDays *int   `json:"dueInDays,omitempty" validate:"required_if=Type set_due_date,omitempty,min=0,max=365"`
+	Comment   string `json:"comment,omitempty" validate:"required_if=Type post_comment,omitempty,max=5000"`
+}
+
+type CreateAutomation struct {
+	BoardID   string             `json:"-" validate:"required,uuid"`
+	CreatedBy string             `json:"-" validate:"required,uuid"`
+	Name      string             `json:"name" validate:"required,max=100"`
+	Trigger   AutomationTrigger  `json:"trigger" validate:"required"`
+	Actions   []AutomationAction `json:"actions" validate:"required,min=1,max=10,dive"`
+	Enabled   *bool              `json:"enabled" validate:"omitempty"`
+}
+
+type UpdateAutomation struct {
+	AutomationID string             `json:"-" validate:"required,uuid"`
+	Name         *string            `json:"name" validate:"omitempty,max=100"`
+	Trigger      *AutomationTrigger `json:"trigger" validate:"omitempty"`
+	Actions      []AutomationAction `json:"actions" validate:"omitempty,min=1,max=10,dive"`
+	Enabled      *bool              `json:"enabled" validate:"omitempty"`
+}
+
+type Automation struct {
+	ID        string             `json:"id"`
+	BoardID   string             `json:"boardID"`
+	Name      string             `json:"name"`
+	Trigger   AutomationTrigger  `json:"trigger"`
+	Actions   []AutomationAction `json:"actions"`
+	Enabled   bool               `json:"enabled"`
+	CreatedBy string             `json:"createdBy"`
+	CreatedAt time.Time          `json:"createdAt"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+}
+
+// AutomationRefs are the board objects a rule points at. All of them must
+// belong to the rule's board.
+type AutomationRefs struct {
+	ListIDs  []string
+	LabelIDs []string
+	UserIDs  []string
+}
+
+type CreateAutomationRun struct {
+	AutomationID string
+	CardID       string
+	Trigger      string
+	Status       string
+	Error        string
+}
+
+type AutomationRun struct {
+	ID           string    `json:"id"`
+	AutomationID string    `json:"automationID"`
+	CardID       string    `json:"cardID,omitempty"`
+	Trigger      string    `json:"trigger"`
+	Status       string    `json:"status"`
+	Error        string    `json:"error,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
@@ -1,5 +1,50 @@
 package types
 
+// Board roles. Invitations created before roles were settled may still carry
+// RoleMemberLegacy, which is treated as RoleNormal.
+const (
+	RoleAdmin        = "admin"
+	RoleNormal       = "normal"
+	RoleObserver     = "observer"
+	RoleMemberLegacy = "member"
+)
+
+// Permission is an action class on a board, granted by the caller's role.
+type Permission string
+
+const (
+	// PermissionView covers reading the board and its content.
+	PermissionView Permission = "view"
+	// PermissionEdit covers changing lists, cards, labels and views.
+	PermissionEdit Permission = "edit"
+	// PermissionManage covers members, invitations, settings and automations.
+	PermissionManage Permission = "manage"
+)
+
+var rolePermissions = map[string][]Permission{
+	RoleAdmin:    {PermissionView, PermissionEdit, PermissionManage},
+	RoleNormal:   {PermissionView, PermissionEdit},
+	RoleObserver: {PermissionView},
+}
+
+// NormalizeRole maps legacy role names to their current equivalent.
+func NormalizeRole(role string) string {
+	if role == RoleMemberLegacy {
+		return RoleNormal
+	}
+	return role
+}
+
+// RoleCan reports whether role grants permission. Unknown roles grant nothing.
+func RoleCan(role string, permission Permission) bool {
+	for _, p := range rolePermissions[NormalizeRole(role)] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
 type BoardMember struct {
 	BoardName string
 	BoardID   string
@@ -8,8 +53,23 @@ type BoardMember struct {
 	Members   []*Member
 }
 
+// Can reports whether the member's role grants permission.
+func (m *BoardMember) Can(permission Permission) bool {
+	return RoleCan(m.Role, permission)
+}
+
 type Member struct {
 	Name  string
 	Email string
 	Role  string
 }
+
+type BoardMemberContextKey string
+
+var BoardMemberCtxKey = BoardMemberContextKey("board_member")
+
+type UpdateBoardMemberRole struct {
+	BoardID string `json:"-" validate:"required,uuid"`
+	UserID  string `json:"-" validate:"required,uuid"`
+	Role    string `json:"role" validate:"required,oneof=admin normal observer"`
+}
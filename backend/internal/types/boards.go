@@ -2,18 +2,56 @@ package types
 
 import "time"
 
+// Board visibilities. Team boards are readable by every member of the
+// board's workspace.
+const (
+	BoardVisibilityPrivate = "private"
+	BoardVisibilityTeam    = "team"
+	BoardVisibilityPublic  = "public"
+)
+
 type CreateBoard struct {
 	Name       string `json:"name" validate:"required,max=20"`
 	Background string `json:"background" validate:"required,color_or_url"`
 	Visibility string `json:"visibility,omitempty" validate:"omitempty,oneof=private team public"`
+	// WorkspaceID optionally creates the board inside a workspace the owner
+	// belongs to.
+	WorkspaceID string `json:"workspace_id,omitempty" validate:"omitempty,uuid"`
 
 	OwnerID string `json:"-" validate:"-"`
 }
 
 type Board struct {
-	ID         string `json:"id"`
-	Name       string `json:"name"`
-	Background string `json:"background"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Background  string `json:"background"`
+	Visibility  string `json:"visibility,omitempty"`
+	WorkspaceID string `json:"workspace_id,omitempty"`
+	Archived    bool   `json:"archived"`
+	// Role, Owned and Starred describe the board from the caller's side;
+	// they are set by listings that go through the caller's membership.
+	Role    string `json:"role,omitempty"`
+	Owned   bool   `json:"owned"`
+	Starred bool   `json:"starred"`
+}
+
+// ListBoardsFilter narrows the boards listing. Archived is "false" by
+// default; "all" includes both. Ownership is "owned" for boards the caller
+// created and "shared" for boards they joined.
+type ListBoardsFilter struct {
+	UserID     string `validate:"-"`
+	Archived   string `validate:"omitempty,oneof=true false all"`
+	Ownership  string `validate:"omitempty,oneof=all owned shared"`
+	Visibility string `validate:"omitempty,oneof=private team public"`
+	Search     string `validate:"max=100"`
+	Starred    bool   `validate:"-"`
+	// SortBy is checked against the fields the listing can order by, rather
+	// than passed through from the query.
+	SortBy string `validate:"oneof=name created_at updated_at"`
+}
+
+type StarBoard struct {
+	Starred *bool `json:"starred" validate:"required"`
 }
 
 type BoardDetail struct {
@@ -27,6 +65,7 @@ type List struct {
 	BoardID  string  `json:"board_id"`
 	Name     string  `json:"name"`
 	Position float64 `json:"position"`
+	Color    string  `json:"color,omitempty"`
 }
 
 type MinimalCard struct {
@@ -44,17 +83,23 @@ type MinimalCard struct {
 	MemberIDs   []string         `json:"member_ids"`
 	Checklists  []*CardChecklist `json:"checklists"`
 	Labels      []*BoardLabel    `json:"labels"`
+	BlockedBy   []string         `json:"blocked_by"`
+	Blocking    []string         `json:"blocking"`
+	VoteCount   int              `json:"vote_count"`
 	// Checklists
 	// Attachments
 }
 
 type BoardInvitation struct {
+	ID        string    `json:"id" validate:"-"`
 	BoardID   string    `json:"-" validate:"required,uuid"`
 	Email     string    `json:"email" validate:"required,email"`
-	InvitedBy string    `json:"-" validate:"required,uuid"`
+	InvitedBy string    `json:"invited_by" validate:"required,uuid"`
 	Token     string    `json:"-" validate:"required"`
-	ExpiredAt time.Time `json:"-" validate:"required,gt"`
-	Role      string    `json:"role" validate:"required,oneof=observer member"`
+	ExpiredAt time.Time `json:"expires_at" validate:"required,gt"`
+	Role      string    `json:"role" validate:"required,oneof=admin normal observer member"`
+	Status    string    `json:"status" validate:"-"`
+	CreatedAt time.Time `json:"created_at" validate:"-"`
 }
 
 type UpdateBoard struct {
@@ -89,8 +134,9 @@ type BoardMembers struct {
 	ID       string `json:"id"`
 	FullName string `json:"fullName"`
 	Username string `json:"username"`
-	Email    string `json:"email"`
-	Role     string `json:"role"`
+	// Email is left out of boards read without an account.
+	Email string `json:"email,omitempty"`
+	Role  string `json:"role"`
 }
 
 type BoardContextKey string
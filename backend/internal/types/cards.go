@@ -22,6 +22,9 @@ type UpdateCard struct {
 	StartDate   *time.Time `json:"startDate" validate:"omitempty"`
 	DueDate     *time.Time `json:"dueDate" validate:"omitempty"`
 	Position    *float64   `json:"position" validate:"omitempty"`
+
+	// Force completes the card even while cards it depends on are open.
+	Force bool `json:"force"`
 }
 
 type Card struct {
@@ -60,6 +63,7 @@ type ToggleCardMembership struct {
 
 type CompleteCard struct {
 	ID          string    `json:"id"`
+	ListID      string    `json:"listID"`
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
 	Position    float64   `json:"position"`
@@ -70,9 +74,11 @@ type CompleteCard struct {
 	Start       time.Time `json:"start"`
 	Due         time.Time `json:"due"`
 
-	MemberIDs    []string      `json:"member_ids"`
-	Labels       []*BoardLabel `json:"labels"`
-	ChecklistIDs []string      `json:"checklist_ids"`
+	MemberIDs    []string          `json:"member_ids"`
+	Labels       []*BoardLabel     `json:"labels"`
+	ChecklistIDs []string          `json:"checklist_ids"`
+	BlockedBy    []*DependencyCard `json:"blocked_by"`
+	Blocking     []*DependencyCard `json:"blocking"`
 	// Checklist []Checklists `json:"checklist"`
 	// Attachments []Attachment `json:"attachments"`
 }
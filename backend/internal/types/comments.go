@@ -0,0 +1,26 @@
+package types
+
+import "time"
+
+type CreateComment struct {
+	BoardID string `json:"-" validate:"required,uuid"`
+	CardID  string `json:"-" validate:"required,uuid"`
+	UserID  string `json:"-" validate:"required,uuid"`
+	Content string `json:"content" validate:"required,max=5000"`
+}
+
+type UpdateComment struct {
+	CommentID string `json:"-" validate:"required,uuid"`
+	Content   string `json:"content" validate:"required,max=5000"`
+}
+
+type Comment struct {
+	ID        string    `json:"id"`
+	CardID    string    `json:"cardID"`
+	UserID    string    `json:"userID"`
+	Username  string    `json:"username"`
+	Content   string    `json:"content"`
+	Edited    bool      `json:"edited"`
+	EditedAt  time.Time `json:"editedAt"`
+	CreatedAt time.Time `json:"createdAt"`
+}
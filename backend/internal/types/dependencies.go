@@ -0,0 +1,21 @@
+package types
+
+type AddCardDependency struct {
+	BoardID     string `json:"-" validate:"required,uuid"`
+	CardID      string `json:"-" validate:"required,uuid"`
+	DependsOnID string `json:"dependsOnID" validate:"required,uuid,nefield=CardID"`
+}
+
+type RemoveCardDependency struct {
+	BoardID     string `json:"-" validate:"required,uuid"`
+	CardID      string `json:"-" validate:"required,uuid"`
+	DependsOnID string `json:"-" validate:"required,uuid"`
+}
+
+// DependencyCard is the other end of a dependency as shown on a card.
+type DependencyCard struct {
+	ID        string `json:"id"`
+	ListID    string `json:"listID"`
+	Title     string `json:"title"`
+	Completed bool   `json:"completed"`
+}
@@ -0,0 +1,183 @@
+package types
+
+import "time"
+
+// BoardExportVersion is bumped whenever BoardExport changes shape; imports
+// refuse documents from a newer version.
+const BoardExportVersion = 1
+
+// Board export formats.
+const (
+	ExportFormatJSON = "json"
+	ExportFormatCSV  = "csv"
+)
+
+// BoardExport is the document written by the board export and read back by
+// the import. Records keep their original IDs so references between them
+// can be followed; the import gives everything new IDs. Cards come last and
+// flat, so the export can stream them.
+type BoardExport struct {
+	Version    int             `json:"version" validate:"required,min=1"`
+	ExportedAt time.Time       `json:"exported_at"`
+	Board      *ExportBoard    `json:"board" validate:"required"`
+	Labels     []*ExportLabel  `json:"labels" validate:"max=1000,dive,required"`
+	Members    []*ExportMember `json:"members" validate:"dive,required"`
+	Lists      []*ExportList   `json:"lists" validate:"max=1000,dive,required"`
+	Cards      []*ExportCard   `json:"cards" validate:"max=10000,dive,required"`
+}
+
+type ExportBoard struct {
+	ID          string `json:"id"`
+	Name        string `json:"name" validate:"required,max=20"`
+	Description string `json:"description,omitempty" validate:"max=1000"`
+	Background  string `json:"background,omitempty"`
+	Visibility  string `json:"visibility,omitempty" validate:"omitempty,oneof=private team public"`
+}
+
+type ExportLabel struct {
+	ID    string `json:"id" validate:"required"`
+	Name  string `json:"name"`
+	Color string `json:"color" validate:"required,max=7"`
+}
+
+type ExportMember struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Email    string `json:"email" validate:"omitempty,email"`
+	Role     string `json:"role"`
+}
+
+type ExportList struct {
+	ID       string  `json:"id" validate:"required"`
+	Name     string  `json:"name" validate:"required,max=255"`
+	Position float64 `json:"position"`
+	Color    string  `json:"color,omitempty" validate:"max=7"`
+	Archived bool    `json:"archived"`
+}
+
+type ExportCard struct {
+	ID          string     `json:"id" validate:"required"`
+	ListID      string     `json:"list_id" validate:"required"`
+	Title       string     `json:"title" validate:"required,max=255"`
+	Description string     `json:"description,omitempty"`
+	Position    float64    `json:"position"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	StartDate   *time.Time `json:"start_date,omitempty"`
+	Cover       string     `json:"cover,omitempty"`
+	CoverSize   string     `json:"cover_size,omitempty"`
+	Archived    bool       `json:"archived"`
+	Completed   bool       `json:"completed"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LabelIDs    []string   `json:"label_ids"`
+	MemberIDs   []string   `json:"member_ids"`
+	// BlockedBy holds the IDs of the cards this card depends on.
+	BlockedBy   []string            `json:"blocked_by"`
+	VoteCount   int                 `json:"vote_count"`
+	Checklists  []*ExportChecklist  `json:"checklists" validate:"dive,required"`
+	Comments    []*ExportComment    `json:"comments" validate:"dive,required"`
+	Attachments []*ExportAttachment `json:"attachments"`
+}
+
+type ExportChecklist struct {
+	ID       string                 `json:"id"`
+	Name     string                 `json:"name" validate:"required,max=255"`
+	Position float64                `json:"position"`
+	Items    []*ExportChecklistItem `json:"items" validate:"dive,required"`
+}
+
+type ExportChecklistItem struct {
+	ID        string     `json:"id"`
+	Text      string     `json:"text" validate:"required"`
+	Completed bool       `json:"completed"`
+	Position  float64    `json:"position"`
+	DueDate   *time.Time `json:"due_date,omitempty"`
+}
+
+type ExportComment struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Username  string    `json:"username"`
+	Content   string    `json:"content" validate:"required,max=5000"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ExportAttachment describes a file without its contents, which stay in
+// blob storage.
+type ExportAttachment struct {
+	ID         string    `json:"id"`
+	FileName   string    `json:"file_name"`
+	FileType   string    `json:"file_type,omitempty"`
+	FileSize   int       `json:"file_size"`
+	UploadedBy string    `json:"uploaded_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ImportBoard carries the document to import and who imports it.
+type ImportBoard struct {
+	OwnerID string       `validate:"required,uuid"`
+	Export  *BoardExport `validate:"required"`
+	// Members maps exported member IDs to the users they are imported as.
+	// Mapped users join the board with their exported role and keep their
+	// card assignments and comments; the assignments of unmapped members
+	// are dropped and their comments are kept under the owner with the
+	// author noted. The owner is always added as admin.
+	Members map[string]string `validate:"-"`
+	// Invitations are created pending on the new board; the store fills in
+	// the board.
+	Invitations []*BoardInvitation `validate:"-"`
+}
+
+// BoardImportResult reports what the import created and what it left out.
+type BoardImportResult struct {
+	BoardID string `json:"board_id"`
+	Lists   int    `json:"lists"`
+	Cards   int    `json:"cards"`
+	// Members counts the mapped users who joined besides the owner.
+	Members int `json:"members"`
+	Invited int `json:"invited"`
+	// SkippedMembers counts exported members who were neither mapped nor
+	// invited.
+	SkippedMembers int `json:"skipped_members"`
+	// SkippedAttachments counts attachments left out because their files
+	// are not part of the export.
+	SkippedAttachments int `json:"skipped_attachments"`
+}
+
+// ImportEntityReport tells how many records of one kind an import carried
+// over and how many it left out, and why.
+type ImportEntityReport struct {
+	Imported int      `json:"imported"`
+	Skipped  int      `json:"skipped"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Skip counts a record that was left out, noting why when reason is set.
+func (r *ImportEntityReport) Skip(reason string) {
+	r.Skipped++
+	if reason != "" {
+		r.Warnings = append(r.Warnings, reason)
+	}
+}
+
+// ImportMemberReport tells how the members of an imported board were
+// carried over.
+type ImportMemberReport struct {
+	Matched  int      `json:"matched"`
+	Invited  int      `json:"invited"`
+	Skipped  int      `json:"skipped"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ImportReport is the per-entity report of an import from another tool.
+type ImportReport struct {
+	BoardID     string             `json:"board_id,omitempty"`
+	Board       ImportEntityReport `json:"board"`
+	Lists       ImportEntityReport `json:"lists"`
+	Labels      ImportEntityReport `json:"labels"`
+	Cards       ImportEntityReport `json:"cards"`
+	Checklists  ImportEntityReport `json:"checklists"`
+	CheckItems  ImportEntityReport `json:"check_items"`
+	Comments    ImportEntityReport `json:"comments"`
+	Attachments ImportEntityReport `json:"attachments"`
+	Members     ImportMemberReport `json:"members"`
+}
@@ -0,0 +1,38 @@
+package types
+
+import "time"
+
+// Invitation statuses, shared by board and workspace invitations. Only
+// pending invitations can be accepted, declined, resent or revoked.
+const (
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+	InvitationDeclined = "declined"
+	InvitationRevoked  = "revoked"
+)
+
+type CreateJoinLink struct {
+	BoardID   string `json:"-" validate:"-"`
+	CreatedBy string `json:"-" validate:"-"`
+	Token     string `json:"-" validate:"-"`
+	// Join links never grant admin; promote members afterwards instead.
+	Role      string     `json:"role" validate:"required,oneof=normal observer"`
+	MaxUses   *int       `json:"max_uses" validate:"omitempty,min=1,max=1000"`
+	ExpiresAt *time.Time `json:"expires_at" validate:"omitempty,gt"`
+}
+
+type JoinLink struct {
+	ID        string     `json:"id"`
+	BoardID   string     `json:"-"`
+	Token     string     `json:"token"`
+	Role      string     `json:"role"`
+	MaxUses   *int       `json:"max_uses"`
+	Uses      int        `json:"uses"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type JoinBoard struct {
+	Token string `json:"token" validate:"required"`
+}
@@ -8,7 +8,7 @@ type CreateLabel struct {
 
 type ModifyLabel struct {
 	ID    string `json:"id" validate:"required,uuid"`
-	Type  string `json:"type" validate:"required,one of=update,delete"`
+	Type  string `json:"type" validate:"required,oneof=update delete"`
 	Name  string `json:"name" validate:"omitempty"`
 	Color string `json:"color" validate:"omitempty,hexcolor"`
 
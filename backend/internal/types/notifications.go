@@ -0,0 +1,30 @@
+package types
+
+import "time"
+
+const (
+	NotificationCardAssigned    = "card_assigned"
+	NotificationMentioned       = "mentioned"
+	NotificationBoardInvitation = "board_invitation"
+	NotificationDueDate         = "due_date"
+)
+
+type CreateNotification struct {
+	UserID  string
+	Type    string
+	BoardID string
+	CardID  string
+	ActorID string
+	DueDate *time.Time
+}
+
+type Notification struct {
+	ID        string     `json:"id"`
+	Type      string     `json:"type"`
+	BoardID   string     `json:"boardID,omitempty"`
+	CardID    string     `json:"cardID,omitempty"`
+	ActorID   string     `json:"actorID,omitempty"`
+	DueDate   *time.Time `json:"dueDate,omitempty"`
+	Read      bool       `json:"read"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
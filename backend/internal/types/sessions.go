@@ -0,0 +1,35 @@
+package types
+
+import "time"
+
+// CreateSession starts a new session for a login. The store fills in ID.
+type CreateSession struct {
+	ID        string
+	UserID    string
+	TokenHash string
+	UserAgent string
+	IP        string
+	ExpiresAt time.Time
+}
+
+// RotateSession exchanges the refresh token hashed as TokenHash for the
+// one hashed as NextTokenHash.
+type RotateSession struct {
+	TokenHash     string
+	NextTokenHash string
+	IP            string
+	ExpiresAt     time.Time
+}
+
+type Session struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"userAgent"`
+	IP         string    `json:"ip"`
+	Current    bool      `json:"current"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+	// AuthenticatedAt is when the user last proved their identity in this
+	// session: at login or by re-authenticating.
+	AuthenticatedAt time.Time `json:"authenticatedAt"`
+	ExpiresAt       time.Time `json:"expiresAt"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
@@ -0,0 +1,23 @@
+package types
+
+import "time"
+
+type CreateShareToken struct {
+	BoardID   string     `json:"-" validate:"-"`
+	CreatedBy string     `json:"-" validate:"-"`
+	TokenHash string     `json:"-" validate:"-"`
+	Prefix    string     `json:"-" validate:"-"`
+	ExpiresAt *time.Time `json:"expires_at" validate:"omitempty,gt"`
+}
+
+// ShareToken gives anyone holding it read-only access to a board, without
+// an account, until it expires or is revoked. Only the hash of the token is
+// stored; the raw token is shown once, when the share link is created.
+type ShareToken struct {
+	ID        string     `json:"id"`
+	BoardID   string     `json:"-"`
+	Prefix    string     `json:"prefix"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -0,0 +1,75 @@
+package types
+
+import "time"
+
+// TemplateStructureVersion is bumped whenever TemplateStructure changes
+// shape, so older templates can still be read.
+const TemplateStructureVersion = 1
+
+type CreateBoardTemplate struct {
+	BoardID     string `json:"-" validate:"required,uuid"`
+	CreatedBy   string `json:"-" validate:"required,uuid"`
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description" validate:"omitempty,max=1000"`
+	Public      bool   `json:"public"`
+	// SkipCards saves only the lists and labels of the board.
+	SkipCards bool `json:"skipCards"`
+}
+
+type CreateBoardFromTemplate struct {
+	TemplateID string `json:"-" validate:"required,uuid"`
+	OwnerID    string `json:"-" validate:"required,uuid"`
+	Name       string `json:"name" validate:"required,max=20"`
+	Background string `json:"background" validate:"omitempty,color_or_url"`
+	Visibility string `json:"visibility,omitempty" validate:"omitempty,oneof=private team public"`
+}
+
+type BoardTemplate struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Public      bool               `json:"public"`
+	CreatedBy   string             `json:"createdBy"`
+	Structure   *TemplateStructure `json:"structure"`
+	CreatedAt   time.Time          `json:"createdAt"`
+}
+
+// TemplateStructure is the JSON document stored in BoardTemplate.structure.
+// Cards refer to labels by their position in Labels.
+type TemplateStructure struct {
+	Version    int              `json:"version"`
+	Background string           `json:"background,omitempty"`
+	Labels     []*TemplateLabel `json:"labels"`
+	Lists      []*TemplateList  `json:"lists"`
+}
+
+type TemplateLabel struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type TemplateList struct {
+	Name     string          `json:"name"`
+	Position float64         `json:"position"`
+	Color    string          `json:"color,omitempty"`
+	Cards    []*TemplateCard `json:"cards,omitempty"`
+}
+
+type TemplateCard struct {
+	Title       string               `json:"title"`
+	Description string               `json:"description,omitempty"`
+	Position    float64              `json:"position"`
+	Labels      []int                `json:"labels,omitempty"`
+	Checklists  []*TemplateChecklist `json:"checklists,omitempty"`
+}
+
+type TemplateChecklist struct {
+	Name     string                   `json:"name"`
+	Position float64                  `json:"position"`
+	Items    []*TemplateChecklistItem `json:"items,omitempty"`
+}
+
+type TemplateChecklistItem struct {
+	Text     string  `json:"text"`
+	Position float64 `json:"position"`
+}
@@ -0,0 +1,42 @@
+package types
+
+import "time"
+
+// TwoFactor is a user's TOTP state. Secret is encrypted at rest and is set
+// from enrollment on; the factor only applies once EnabledAt is set.
+type TwoFactor struct {
+	Secret    string
+	EnabledAt *time.Time
+	LastStep  *int64
+}
+
+func (t *TwoFactor) Enabled() bool {
+	return t.EnabledAt != nil
+}
+
+type TwoFactorEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// TwoFactorCode is a second factor: a TOTP code or one of the user's
+// recovery codes.
+type TwoFactorCode struct {
+	Code         string `json:"code" validate:"required_without=RecoveryCode,omitempty,numeric,len=6"`
+	RecoveryCode string `json:"recoveryCode" validate:"required_without=Code,omitempty,max=32"`
+}
+
+type ConfirmTwoFactor struct {
+	Code string `json:"code" validate:"required,numeric,len=6"`
+}
+
+type TwoFactorLogin struct {
+	ChallengeToken string `json:"challengeToken" validate:"required"`
+	TwoFactorCode
+}
+
+type EnableTwoFactor struct {
+	UserID             string
+	Step               int64
+	RecoveryCodeHashes []string
+}
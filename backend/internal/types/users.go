@@ -30,36 +30,52 @@ type User struct {
 	Username      string    `json:"username"`
 	Email         string    `json:"email"`
 	Password      string    `json:"-"`
-	RefereshToken string    `json:"refresh_token"`
+	EmailVerified bool      `json:"email_verified"`
+	SessionID     string    `json:"session_id,omitempty"`
 	CreatedAt     time.Time `json:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at"`
+
+	// TwoFactorEnabled is filled on lookups that gate login and kept out of
+	// token claims.
+	TwoFactorEnabled bool `json:"-"`
+
+	// APITokenID and Scopes are set when the request authenticated with a
+	// personal access token rather than a session.
+	APITokenID string   `json:"-"`
+	Scopes     []string `json:"-"`
 }
 
+// CreateOAuthUser is a provider-vouched identity signing in. ID is set by
+// the store to the user it resolved to.
 type CreateOAuthUser struct {
 	ID                string `json:"id"`
 	Email             string `json:"email" validate:"required,email"`
-	Provider          string `json:"provider" validate:"required,oneof=google"`
+	EmailVerified     bool   `json:"email_verified"`
+	Provider          string `json:"provider" validate:"required,max=32"`
 	ProviderAccountID string `json:"provider_account_id" validate:"required"`
-}
-
-type GoogleOAuthUserInfo struct {
-	ID            string `json:"id"`
-	Email         string `json:"email"`
-	VerifiedEmail bool   `json:"verified_email"`
-	Name          string `json:"name"`
-	GivenName     string `json:"given_name"`
-	FamilyName    string `json:"family_name"`
-	Picture       string `json:"picture"`
-	Locale        string `json:"locale"`
+	Username          string `json:"username"`
+	FirstName         string `json:"first_name"`
+	LastName          string `json:"last_name"`
+	Avatar            string `json:"avatar"`
 }
 
 type UserContextKey string
 
 var UserCtxKey = UserContextKey("user")
 
+type ResendEmailVerification struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
 type PasswordResetRequest struct {
 	Email string `json:"email" validate:"required,email"`
 }
+
+const (
+	TokenScopeEmailVerification = "email_verification"
+	TokenScopeResetPassword     = "reset_password"
+)
+
 type Token struct {
 	UserID string    `json:"user_id"`
 	Token  string    `json:"token"`
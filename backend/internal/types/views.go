@@ -0,0 +1,101 @@
+package types
+
+import "time"
+
+const (
+	ViewTable    = "table"
+	ViewCalendar = "calendar"
+	ViewTimeline = "timeline"
+)
+
+// ViewFilters narrows the cards a view shows. Empty fields match everything.
+type ViewFilters struct {
+	ListIDs         []string `json:"listIDs,omitempty" validate:"omitempty,max=50,dive,uuid"`
+	LabelIDs        []string `json:"labelIDs,omitempty" validate:"omitempty,max=50,dive,uuid"`
+	MemberIDs       []string `json:"memberIDs,omitempty" validate:"omitempty,max=50,dive,uuid"`
+	Completed       *bool    `json:"completed,omitempty"`
+	IncludeArchived bool     `json:"includeArchived,omitempty"`
+	Search          string   `json:"search,omitempty" validate:"omitempty,max=100"`
+}
+
+// ViewSettings is the JSON document stored in View.settings. SortBy and
+// SortOrder apply to table views, GroupBy to timeline views.
+type ViewSettings struct {
+	Filters   ViewFilters `json:"filters"`
+	SortBy    string      `json:"sortBy,omitempty" validate:"omitempty,oneof=title list position start due completed created"`
+	SortOrder string      `json:"sortOrder,omitempty" validate:"omitempty,oneof=asc desc"`
+	GroupBy   string      `json:"groupBy,omitempty" validate:"omitempty,oneof=list member"`
+}
+
+type CreateView struct {
+	BoardID  string       `json:"-" validate:"required,uuid"`
+	Name     string       `json:"name" validate:"required,max=50"`
+	Type     string       `json:"type" validate:"required,oneof=table calendar timeline"`
+	Settings ViewSettings `json:"settings"`
+}
+
+type UpdateView struct {
+	ViewID   string        `json:"-" validate:"required,uuid"`
+	Name     *string       `json:"name" validate:"omitempty,max=50"`
+	Settings *ViewSettings `json:"settings" validate:"omitempty"`
+}
+
+type View struct {
+	ID        string       `json:"id"`
+	BoardID   string       `json:"boardID"`
+	Name      string       `json:"name"`
+	Type      string       `json:"type"`
+	Settings  ViewSettings `json:"settings"`
+	CreatedAt time.Time    `json:"createdAt"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+}
+
+// ViewCardQuery selects the cards for a view. A non-nil From/To keeps cards
+// whose start or due date falls in [From, To).
+type ViewCardQuery struct {
+	BoardID string
+	Filters *ViewFilters
+	From    *time.Time
+	To      *time.Time
+}
+
+// ViewCard is the card shape shared by every view projection.
+type ViewCard struct {
+	ID        string        `json:"id"`
+	Title     string        `json:"title"`
+	ListID    string        `json:"listID"`
+	ListName  string        `json:"listName"`
+	Position  float64       `json:"position"`
+	StartDate *time.Time    `json:"startDate,omitempty"`
+	DueDate   *time.Time    `json:"dueDate,omitempty"`
+	Completed bool          `json:"completed"`
+	Archived  bool          `json:"archived"`
+	Labels    []*BoardLabel `json:"labels"`
+	Members   []*ViewMember `json:"members"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
+type ViewMember struct {
+	UserID   string `json:"userID"`
+	Username string `json:"username"`
+}
+
+type CalendarDay struct {
+	Date  string      `json:"date"`
+	Cards []*ViewCard `json:"cards"`
+}
+
+type TimelineGroup struct {
+	ID    string          `json:"id"`
+	Name  string          `json:"name"`
+	Items []*TimelineItem `json:"items"`
+}
+
+type TimelineItem struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	ListID    string    `json:"listID"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Completed bool      `json:"completed"`
+}
@@ -0,0 +1,33 @@
+package types
+
+import "time"
+
+// VotingPowerUp is the PowerUp.name under which a board's voting settings
+// are stored.
+const VotingPowerUp = "voting"
+
+// VotingSettings is the board-level voting configuration. Enabled maps to
+// PowerUp.enabled; the rest lives in PowerUp.config.
+type VotingSettings struct {
+	Enabled           bool `json:"enabled"`
+	RestrictObservers bool `json:"restrictObservers"`
+}
+
+type UpdateVotingSettings struct {
+	BoardID           string `json:"-" validate:"required,uuid"`
+	Enabled           *bool  `json:"enabled"`
+	RestrictObservers *bool  `json:"restrictObservers"`
+}
+
+type ToggleCardVote struct {
+	BoardID string `json:"-" validate:"required,uuid"`
+	CardID  string `json:"-" validate:"required,uuid"`
+	UserID  string `json:"-" validate:"required,uuid"`
+}
+
+type CardVoter struct {
+	UserID   string    `json:"userID"`
+	Username string    `json:"username"`
+	FullName string    `json:"fullName"`
+	VotedAt  time.Time `json:"votedAt"`
+}
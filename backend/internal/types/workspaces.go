@@ -0,0 +1,69 @@
+package types
+
+import "time"
+
+// Workspace roles. Admins manage the workspace, its members and invitations;
+// members see its team boards and can create boards in it.
+const (
+	WorkspaceRoleAdmin  = "admin"
+	WorkspaceRoleMember = "member"
+)
+
+type CreateWorkspace struct {
+	Name        string `json:"name" validate:"required,max=50"`
+	Description string `json:"description" validate:"omitempty,max=1000"`
+
+	CreatorID string `json:"-" validate:"-"`
+}
+
+type UpdateWorkspace struct {
+	WorkspaceID string  `json:"-"`
+	Name        *string `json:"name" validate:"omitempty,min=1,max=50"`
+	Description *string `json:"description" validate:"omitempty,max=1000"`
+}
+
+type Workspace struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Role        string    `json:"role,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type WorkspaceDetail struct {
+	Workspace
+	Members []*WorkspaceMember `json:"members"`
+}
+
+type WorkspaceMember struct {
+	WorkspaceID string `json:"-"`
+	UserID      string `json:"id"`
+	Username    string `json:"username"`
+	Email       string `json:"email"`
+	Role        string `json:"role"`
+}
+
+type WorkspaceMemberContextKey string
+
+var WorkspaceMemberCtxKey = WorkspaceMemberContextKey("workspace_member")
+
+type WorkspaceInvitation struct {
+	WorkspaceID string    `json:"-" validate:"required,uuid"`
+	Email       string    `json:"email" validate:"required,email"`
+	InvitedBy   string    `json:"-" validate:"required,uuid"`
+	Token       string    `json:"-" validate:"required"`
+	ExpiredAt   time.Time `json:"-" validate:"required,gt"`
+	Role        string    `json:"role" validate:"required,oneof=admin member"`
+	Status      string    `json:"-" validate:"-"`
+}
+
+type UpdateWorkspaceMemberRole struct {
+	WorkspaceID string `json:"-" validate:"required,uuid"`
+	UserID      string `json:"-" validate:"required,uuid"`
+	Role        string `json:"role" validate:"required,oneof=admin member"`
+}
+
+type MoveBoard struct {
+	BoardID     string `json:"-" validate:"required,uuid"`
+	WorkspaceID string `json:"workspace_id" validate:"required,uuid"`
+}
@@ -0,0 +1,181 @@
+// Package views turns a board's cards into the shapes the saved views
+// render: table rows, calendar days and timeline spans.
+package views
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+const dateLayout = "2006-01-02"
+
+// Table returns the cards sorted by sortBy. Cards without the sorted date
+// always come last so they do not crowd the top of the table.
+func Table(cards []*types.ViewCard, sortBy, sortOrder string) []*types.ViewCard {
+	rows := slices.Clone(cards)
+	desc := sortOrder == "desc"
+
+	slices.SortStableFunc(rows, func(a, b *types.ViewCard) int {
+		switch sortBy {
+		case "start":
+			return compareDates(a.StartDate, b.StartDate, desc)
+		case "due":
+			return compareDates(a.DueDate, b.DueDate, desc)
+		}
+
+		var c int
+		switch sortBy {
+		case "title":
+			c = cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+		case "list":
+			c = cmp.Or(cmp.Compare(a.ListName, b.ListName), cmp.Compare(a.Position, b.Position))
+		case "completed":
+			c = compareBools(a.Completed, b.Completed)
+		case "created":
+			c = a.CreatedAt.Compare(b.CreatedAt)
+		default:
+			c = cmp.Compare(a.Position, b.Position)
+		}
+		if desc {
+			return -c
+		}
+		return c
+	})
+
+	return rows
+}
+
+func compareDates(a, b *time.Time, desc bool) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	case desc:
+		return b.Compare(*a)
+	default:
+		return a.Compare(*b)
+	}
+}
+
+func compareBools(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// Calendar buckets cards by due date into one entry per day in [from, to),
+// using loc to decide which day a due date falls on.
+func Calendar(cards []*types.ViewCard, from, to time.Time, loc *time.Location) []*types.CalendarDay {
+	byDay := make(map[string][]*types.ViewCard)
+	for _, card := range cards {
+		if card.DueDate == nil || card.DueDate.Before(from) || !card.DueDate.Before(to) {
+			continue
+		}
+		day := card.DueDate.In(loc).Format(dateLayout)
+		byDay[day] = append(byDay[day], card)
+	}
+
+	var days []*types.CalendarDay
+	start := time.Date(from.In(loc).Year(), from.In(loc).Month(), from.In(loc).Day(), 0, 0, 0, 0, loc)
+	for d := start; d.Before(to); d = d.AddDate(0, 0, 1) {
+		day := d.Format(dateLayout)
+		dayCards := byDay[day]
+		if dayCards == nil {
+			dayCards = []*types.ViewCard{}
+		}
+		slices.SortStableFunc(dayCards, func(a, b *types.ViewCard) int {
+			return a.DueDate.Compare(*b.DueDate)
+		})
+		days = append(days, &types.CalendarDay{Date: day, Cards: dayCards})
+	}
+
+	return days
+}
+
+// Timeline spans each card from its start date to its due date, grouped by
+// list or by member. A card with only one of the dates is shown as a single
+// point; cards with neither are left out. Under member grouping a card
+// appears once per member, and unassigned cards are collected last.
+func Timeline(cards []*types.ViewCard, groupBy string) []*types.TimelineGroup {
+	var groups []*types.TimelineGroup
+	index := make(map[string]*types.TimelineGroup)
+
+	add := func(id, name string, item *types.TimelineItem) {
+		group, ok := index[id]
+		if !ok {
+			group = &types.TimelineGroup{ID: id, Name: name}
+			index[id] = group
+			groups = append(groups, group)
+		}
+		group.Items = append(group.Items, item)
+	}
+
+	var unassigned []*types.TimelineItem
+	for _, card := range cards {
+		item := timelineItem(card)
+		if item == nil {
+			continue
+		}
+
+		if groupBy != "member" {
+			add(card.ListID, card.ListName, item)
+			continue
+		}
+
+		if len(card.Members) == 0 {
+			unassigned = append(unassigned, item)
+			continue
+		}
+		for _, member := range card.Members {
+			add(member.UserID, member.Username, item)
+		}
+	}
+
+	if len(unassigned) > 0 {
+		groups = append(groups, &types.TimelineGroup{Name: "Unassigned", Items: unassigned})
+	}
+
+	for _, group := range groups {
+		slices.SortStableFunc(group.Items, func(a, b *types.TimelineItem) int {
+			return a.Start.Compare(b.Start)
+		})
+	}
+
+	return groups
+}
+
+func timelineItem(card *types.ViewCard) *types.TimelineItem {
+	start, end := card.StartDate, card.DueDate
+	switch {
+	case start == nil && end == nil:
+		return nil
+	case start == nil:
+		start = end
+	case end == nil:
+		end = start
+	}
+	if end.Before(*start) {
+		start, end = end, start
+	}
+
+	return &types.TimelineItem{
+		ID:        card.ID,
+		Title:     card.Title,
+		ListID:    card.ListID,
+		Start:     *start,
+		End:       *end,
+		Completed: card.Completed,
+	}
+}
@@ -0,0 +1,83 @@
+package views
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vaidik-bajpai/Nexus/backend/internal/types"
+)
+
+func date(day int) *time.Time {
+	t := time.Date(2024, time.March, day, 12, 0, 0, 0, time.UTC)
+	return &t
+}
+
+func ids[T any](items []T, id func(T) string) []string {
+	res := make([]string, 0, len(items))
+	for _, item := range items {
+		res = append(res, id(item))
+	}
+	return res
+}
+
+func cardID(c *types.ViewCard) string { return c.ID }
+
+func TestTable(t *testing.T) {
+	cards := []*types.ViewCard{
+		{ID: "a", Title: "beta", Position: 2, DueDate: date(5)},
+		{ID: "b", Title: "Alpha", Position: 3},
+		{ID: "c", Title: "gamma", Position: 1, DueDate: date(2)},
+	}
+
+	assert.Equal(t, []string{"c", "a", "b"}, ids(Table(cards, "", ""), cardID))
+	assert.Equal(t, []string{"b", "a", "c"}, ids(Table(cards, "title", "asc"), cardID))
+	assert.Equal(t, []string{"c", "a", "b"}, ids(Table(cards, "due", "asc"), cardID))
+	assert.Equal(t, []string{"a", "c", "b"}, ids(Table(cards, "due", "desc"), cardID), "cards without a due date stay last")
+	assert.Equal(t, "a", cards[0].ID, "input is not reordered")
+}
+
+func TestCalendar(t *testing.T) {
+	cards := []*types.ViewCard{
+		{ID: "a", DueDate: date(2)},
+		{ID: "b", DueDate: date(3)},
+		{ID: "c", DueDate: date(2)},
+		{ID: "d"},
+		{ID: "e", DueDate: date(10)},
+	}
+
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	days := Calendar(cards, from, from.AddDate(0, 0, 4), time.UTC)
+
+	require.Len(t, days, 4)
+	assert.Equal(t, "2024-03-01", days[0].Date)
+	assert.Empty(t, days[0].Cards)
+	assert.Equal(t, []string{"a", "c"}, ids(days[1].Cards, cardID))
+	assert.Equal(t, []string{"b"}, ids(days[2].Cards, cardID))
+}
+
+func TestTimeline(t *testing.T) {
+	alice := &types.ViewMember{UserID: "u1", Username: "alice"}
+	bob := &types.ViewMember{UserID: "u2", Username: "bob"}
+	cards := []*types.ViewCard{
+		{ID: "a", ListID: "todo", ListName: "To do", StartDate: date(4), DueDate: date(8), Members: []*types.ViewMember{alice, bob}},
+		{ID: "b", ListID: "done", ListName: "Done", DueDate: date(1), Members: []*types.ViewMember{alice}},
+		{ID: "c", ListID: "todo", ListName: "To do", StartDate: date(2)},
+		{ID: "d", ListID: "todo", ListName: "To do"},
+	}
+
+	itemID := func(i *types.TimelineItem) string { return i.ID }
+
+	byList := Timeline(cards, "list")
+	require.Len(t, byList, 2)
+	assert.Equal(t, "To do", byList[0].Name)
+	assert.Equal(t, []string{"c", "a"}, ids(byList[0].Items, itemID))
+	assert.Equal(t, *date(2), byList[0].Items[0].End, "a card with only a start date is a single point")
+
+	byMember := Timeline(cards, "member")
+	require.Len(t, byMember, 3)
+	assert.Equal(t, []string{"alice", "bob", "Unassigned"}, ids(byMember, func(g *types.TimelineGroup) string { return g.Name }))
+	assert.Equal(t, []string{"b", "a"}, ids(byMember[0].Items, itemID))
+	assert.Equal(t, []string{"c"}, ids(byMember[2].Items, itemID))
+}
@@ -1,11 +1,13 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	_ "github.com/joho/godotenv/autoload"
 	"github.com/vaidik-bajpai/Nexus/backend/internal/db"
@@ -15,6 +17,9 @@ import (
 
 func main() {
 	port := flag.Int("port", 8080, "server port address")
+	reminderInterval := flag.Duration("reminder-interval", 15*time.Minute, "how often to check for approaching due dates")
+	reminderWindow := flag.Duration("reminder-window", 24*time.Hour, "notify card members this long before a card is due")
+	automationInterval := flag.Duration("automation-interval", time.Minute, "how often to run due date automations")
 	flag.Parse()
 
 	dbClient := db.NewDB()
@@ -24,6 +29,11 @@ func main() {
 	hdl := handler.NewHandler(store)
 	mux := hdl.SetupRoutes()
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go hdl.RunDueDateReminders(ctx, *reminderInterval, *reminderWindow)
+	go hdl.RunAutomations(ctx, *automationInterval)
+
 	srv := http.Server{
 		Addr:    fmt.Sprintf(":%d", *port),
 		Handler: mux,